@@ -43,6 +43,7 @@ func Init() error {
 			TLSEnabled:  getEnv("TLS_ENABLED", "false") == "true",
 			TLSCertFile: getEnv("TLS_CERT_FILE", "./certs/server.crt"),
 			TLSKeyFile:  getEnv("TLS_KEY_FILE", "./certs/server.key"),
+			TrustProxy:  getEnv("TRUST_PROXY", "false") == "true",
 		},
 		SMS: SMSConfig{
 			APIBaseURL:  getEnv("SMS_API_BASE_URL", "https://api.example.com"),
@@ -57,9 +58,22 @@ func Init() error {
 			AdminKey:     getEnv("KAKAO_ADMIN_KEY", ""),
 		},
 		Session: SessionConfig{
-			SecretKey: getEnv("SESSION_SECRET_KEY", "your-secret-key-change-this-in-production"),
-			MaxAge:    getEnvAsInt("SESSION_MAX_AGE", 3600),
-			Secure:    getEnv("SESSION_SECURE", "false") == "true",
+			SecretKey:   getEnv("SESSION_SECRET_KEY", "your-secret-key-change-this-in-production"),
+			MaxAge:      getEnvAsInt("SESSION_MAX_AGE", 3600),
+			Secure:      getEnv("SESSION_SECURE", "false") == "true",
+			IdleTimeout: getEnvAsInt("SESSION_IDLE_TIMEOUT", 1800),
+		},
+		LoginSecurity: LoginSecurityConfig{
+			UserLockThreshold: getEnvAsInt("LOGIN_USER_LOCK_THRESHOLD", 5),
+			UserWindowMinutes: getEnvAsInt("LOGIN_USER_WINDOW_MINUTES", 1440),
+			IPLockThreshold:   getEnvAsInt("LOGIN_IP_LOCK_THRESHOLD", 20),
+			IPWindowMinutes:   getEnvAsInt("LOGIN_IP_WINDOW_MINUTES", 60),
+			LockBaseSeconds:   getEnvAsInt("LOGIN_LOCK_BASE_SECONDS", 60),
+			LockMaxSeconds:    getEnvAsInt("LOGIN_LOCK_MAX_SECONDS", 3600),
+		},
+		Customer: CustomerConfig{
+			DuplicatePolicy:    getEnv("CUSTOMER_DUPLICATE_POLICY", "flag"),
+			TrashRetentionDays: getEnvAsInt("CUSTOMER_TRASH_RETENTION_DAYS", 30),
 		},
 	}
 
@@ -30,6 +30,7 @@ type ServerConfig struct {
 	TLSEnabled  bool
 	TLSCertFile string
 	TLSKeyFile  string
+	TrustProxy  bool // 리버스 프록시 뒤에서 X-Forwarded-For/X-Real-IP 헤더를 클라이언트 IP로 신뢰
 }
 
 // SMSConfig - SMS API 설정 구조체
@@ -50,17 +51,36 @@ type KakaoOAuthConfig struct {
 
 // SessionConfig - 세션 설정 구조체
 type SessionConfig struct {
-	SecretKey string
-	MaxAge    int
-	Secure    bool
+	SecretKey   string
+	MaxAge      int
+	Secure      bool
+	IdleTimeout int // 마지막 활동 후 세션 만료까지 시간 (초, 0이면 사용 안 함)
+}
+
+// LoginSecurityConfig - 로그인 무차별 대입 방어 설정 구조체
+type LoginSecurityConfig struct {
+	UserLockThreshold int // 계정별 잠금 시작 연속 실패 횟수
+	UserWindowMinutes int // 계정별 실패 집계 기간 (분)
+	IPLockThreshold   int // IP별 잠금 시작 실패 횟수
+	IPWindowMinutes   int // IP별 실패 집계 기간 (분)
+	LockBaseSeconds   int // 첫 잠금 시간 (초), 이후 실패마다 2배씩 증가
+	LockMaxSeconds    int // 최대 잠금 시간 (초)
+}
+
+// CustomerConfig - 고객 등록 설정 구조체
+type CustomerConfig struct {
+	DuplicatePolicy    string // 같은 전화번호 고객 등록 시 처리 방식: flag(중복 표시 후 등록), attach(기존 고객에 연결), reject(등록 거부)
+	TrashRetentionDays int    // 삭제한 고객을 휴지통에 보관하는 기간 (일, 지나면 영구 삭제, 0이면 자동 영구 삭제 안 함)
 }
 
 // Config - 전체 설정 구조체
 type Config struct {
-	Env        Environment
-	DB         DBConfig
-	Server     ServerConfig
-	SMS        SMSConfig
-	KakaoOAuth KakaoOAuthConfig
-	Session    SessionConfig
+	Env           Environment
+	DB            DBConfig
+	Server        ServerConfig
+	SMS           SMSConfig
+	KakaoOAuth    KakaoOAuthConfig
+	Session       SessionConfig
+	LoginSecurity LoginSecurityConfig
+	Customer      CustomerConfig
 }
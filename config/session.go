@@ -2,25 +2,31 @@ package config
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/sessions"
 )
 
-// SessionStore - 세션 스토어 (쿠키 기반)
-var SessionStore *sessions.CookieStore
+// SessionStore - 세션 스토어 (DB 기반 서버 세션, 쿠키에는 세션 ID만 저장)
+var SessionStore sessions.Store
+
+// SessionStoreFactory - 세션 스토어 생성 함수
+// config 패키지는 database 패키지를 import 할 수 없으므로 생성 함수를 주입받음 (database.NewSessionStore)
+type SessionStoreFactory func(secret []byte, options *sessions.Options, idleTimeout time.Duration) sessions.Store
 
 // InitSession - 세션 초기화
-func InitSession() {
+func InitSession(newStore SessionStoreFactory) {
 	cfg := GetConfig()
 	secret := []byte(cfg.Session.SecretKey)
-	SessionStore = sessions.NewCookieStore(secret)
 
 	// 세션 옵션 설정
-	SessionStore.Options = &sessions.Options{
+	options := &sessions.Options{
 		Path:     "/",
 		MaxAge:   cfg.Session.MaxAge,
 		HttpOnly: true,
 		Secure:   cfg.Session.Secure,
 		SameSite: http.SameSiteLaxMode,
 	}
+
+	SessionStore = newStore(secret, options, time.Duration(cfg.Session.IdleTimeout)*time.Second)
 }
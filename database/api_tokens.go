@@ -0,0 +1,202 @@
+package database
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"strings"
+)
+
+// API 토큰 허용 범위 (api_tokens.scopes)
+const (
+	APIScopeCustomersRead  = "customers:read"  // 고객/통계 조회
+	APIScopeCustomersWrite = "customers:write" // 고객 수정/삭제, 통화 처리, 예약
+	APIScopeSMSSend        = "sms:send"        // SMS 발송 및 발송에 필요한 설정/템플릿 조회
+)
+
+// APIScopes - API 토큰 허용 범위 목록 (표시 순서)
+var APIScopes = []string{APIScopeCustomersRead, APIScopeCustomersWrite, APIScopeSMSSend}
+
+// APIScopeDisplayNames - API 토큰 허용 범위 화면 표시 이름
+var APIScopeDisplayNames = map[string]string{
+	APIScopeCustomersRead:  "고객 조회",
+	APIScopeCustomersWrite: "고객 수정",
+	APIScopeSMSSend:        "SMS 발송",
+}
+
+// APITokenPrefix - 발급 토큰 접두어 (로그/코드에서 토큰임을 식별하기 위함)
+const APITokenPrefix = "cct_"
+
+// APIToken - API 토큰 구조체
+type APIToken struct {
+	Seq         int
+	UserSeq     int
+	UserID      string
+	Name        string
+	TokenPrefix string
+	Scopes      []string
+	BranchSeq   sql.NullInt64
+	BranchName  string
+	ExpiresAt   string
+	LastUsedAt  string
+	LastUsedIP  string
+	RevokedAt   string
+	CreatedBy   string
+	CreatedDate string
+	IsExpired   bool
+}
+
+// HasScope - 토큰에 해당 범위가 있는지 확인
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidAPIScope - 정의된 API 토큰 범위인지 확인
+func IsValidAPIScope(scope string) bool {
+	_, ok := APIScopeDisplayNames[scope]
+	return ok
+}
+
+// HashAPIToken - 토큰 원문의 SHA-256 해시 (DB 저장/조회용)
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIToken - API 토큰 발급
+// 파라미터: userSeq (소유자), name (토큰 이름), scopes (허용 범위), branchSeq (요청 지점, nil 가능),
+//
+//	expiresDays (유효 기간 일수, 0이면 만료 없음), createdBy (발급한 사용자 아이디)
+//
+// 반환: 토큰 원문 (다시 조회할 수 없으므로 발급 직후 사용자에게 보여줘야 함), 에러
+func CreateAPIToken(userSeq int, name string, scopes []string, branchSeq *int, expiresDays int, createdBy string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("CreateAPIToken - random error: %v", err)
+		return "", err
+	}
+	token := APITokenPrefix + hex.EncodeToString(b)
+
+	var expiresAt interface{}
+	if expiresDays > 0 {
+		expiresAt = expiresDays
+	}
+
+	query := `INSERT INTO api_tokens (user_seq, name, token_prefix, token_hash, scopes, branch_seq, expires_at, created_by, createdDate)
+	          VALUES (?, ?, ?, ?, ?, ?, NOW() + INTERVAL ? DAY, ?, NOW())`
+	_, err := DB.Exec(query, userSeq, name, token[:len(APITokenPrefix)+8], HashAPIToken(token),
+		strings.Join(scopes, ","), branchSeq, expiresAt, createdBy)
+	if err != nil {
+		log.Printf("CreateAPIToken error: %v", err)
+		return "", err
+	}
+
+	log.Printf("CreateAPIToken success - UserSeq: %d, Name: %s, Scopes: %v", userSeq, name, scopes)
+	return token, nil
+}
+
+// GetActiveAPITokenByHash - 폐기/만료되지 않은 토큰 조회 (없으면 nil)
+func GetActiveAPITokenByHash(tokenHash string) (*APIToken, error) {
+	query := `
+		SELECT seq, user_seq, name, token_prefix, scopes, branch_seq
+		FROM api_tokens
+		WHERE token_hash = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	var t APIToken
+	var scopes string
+	err := DB.QueryRow(query, tokenHash).Scan(&t.Seq, &t.UserSeq, &t.Name, &t.TokenPrefix, &scopes, &t.BranchSeq)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("GetActiveAPITokenByHash error: %v", err)
+		return nil, err
+	}
+
+	t.Scopes = splitScopes(scopes)
+	return &t, nil
+}
+
+// TouchAPIToken - 토큰 마지막 사용 일시/IP 갱신
+func TouchAPIToken(tokenSeq int, ipAddress string) error {
+	_, err := DB.Exec(`UPDATE api_tokens SET last_used_at = NOW(), last_used_ip = ? WHERE seq = ?`, ipAddress, tokenSeq)
+	if err != nil {
+		log.Printf("TouchAPIToken error: %v", err)
+	}
+	return err
+}
+
+// GetAPITokens - API 토큰 목록 조회 (사용 가능한 토큰 먼저, 최신 발급순)
+// 파라미터: userSeq (소유자, 0이면 전체)
+func GetAPITokens(userSeq int) ([]APIToken, error) {
+	query := `
+		SELECT t.seq, t.user_seq, u.user_id, t.name, t.token_prefix, t.scopes, t.branch_seq, COALESCE(b.branchName, ''),
+		       COALESCE(DATE_FORMAT(t.expires_at, '%Y-%m-%d %H:%i'), ''),
+		       COALESCE(DATE_FORMAT(t.last_used_at, '%Y-%m-%d %H:%i'), ''),
+		       COALESCE(t.last_used_ip, ''),
+		       COALESCE(DATE_FORMAT(t.revoked_at, '%Y-%m-%d %H:%i'), ''),
+		       COALESCE(t.created_by, ''),
+		       DATE_FORMAT(t.createdDate, '%Y-%m-%d %H:%i'),
+		       (t.expires_at IS NOT NULL AND t.expires_at <= NOW())
+		FROM api_tokens t
+		INNER JOIN user_info u ON t.user_seq = u.seq
+		LEFT JOIN branches b ON t.branch_seq = b.seq
+		WHERE (? = 0 OR t.user_seq = ?)
+		ORDER BY (t.revoked_at IS NULL) DESC, t.createdDate DESC
+		LIMIT 200
+	`
+
+	rows, err := DB.Query(query, userSeq, userSeq)
+	if err != nil {
+		log.Printf("GetAPITokens error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []APIToken
+	for rows.Next() {
+		var t APIToken
+		var scopes string
+		if err := rows.Scan(&t.Seq, &t.UserSeq, &t.UserID, &t.Name, &t.TokenPrefix, &scopes, &t.BranchSeq, &t.BranchName,
+			&t.ExpiresAt, &t.LastUsedAt, &t.LastUsedIP, &t.RevokedAt, &t.CreatedBy, &t.CreatedDate, &t.IsExpired); err != nil {
+			log.Printf("GetAPITokens scan error: %v", err)
+			return nil, err
+		}
+		t.Scopes = splitScopes(scopes)
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken - API 토큰 폐기
+// 파라미터: tokenSeq (토큰 seq), userSeq (소유자 확인용, 0이면 소유자 무관 - 관리자용)
+// 반환: 폐기된 토큰 수 (0이면 없거나 이미 폐기됨)
+func RevokeAPIToken(tokenSeq, userSeq int) (int64, error) {
+	query := `UPDATE api_tokens SET revoked_at = NOW() WHERE seq = ? AND (? = 0 OR user_seq = ?) AND revoked_at IS NULL`
+	rows, err := Update(query, tokenSeq, userSeq, userSeq)
+	if err != nil {
+		log.Printf("RevokeAPIToken error: %v", err)
+		return 0, err
+	}
+	return rows, nil
+}
+
+// splitScopes - 쉼표 구분 범위 문자열을 목록으로 변환
+func splitScopes(scopes string) []string {
+	var result []string
+	for _, s := range strings.Split(scopes, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,361 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// 감사 로그 작업 종류 (audit_log.action)
+const (
+	AuditCustomerDelete           = "customer.delete"
+	AuditCustomerRestore          = "customer.restore"
+	AuditCustomerPurge            = "customer.purge"
+	AuditCustomerUpdateName       = "customer.update_name"
+	AuditCustomerUpdateComment    = "customer.update_comment"
+	AuditCustomerStatusChange     = "customer.status_change"
+	AuditCustomerStatusRevert     = "customer.status_revert"
+	AuditCustomerDuplicateAttach  = "customer.duplicate_attach"
+	AuditCustomerMerge            = "customer.merge"
+	AuditCustomerImport           = "customer.import"
+	AuditCustomerExport           = "customer.export"
+	AuditCustomerAssign           = "customer.assign"
+	AuditCustomerTransfer         = "customer.transfer"
+	AuditCustomerTagAdd           = "customer.tag_add"
+	AuditCustomerTagRemove        = "customer.tag_remove"
+	AuditCustomerCallbackCreate   = "customer.callback_create"
+	AuditCustomerCallbackComplete = "customer.callback_complete"
+	AuditCustomerCallbackCancel   = "customer.callback_cancel"
+	AuditCustomerNoteAdd          = "customer.note_add"
+	AuditCustomerNoteUpdate       = "customer.note_update"
+	AuditCustomerNotePin          = "customer.note_pin"
+	AuditCustomerPhoneReveal      = "customer.phone_reveal"
+	AuditSMSSend                  = "sms.send"
+	AuditSMSConfigSave            = "sms_config.save"
+	AuditSMSOptOutAdd             = "sms_opt_out.add"
+	AuditSMSOptOutDelete          = "sms_opt_out.delete"
+	AuditSMSOptOutImport          = "sms_opt_out.import"
+	AuditBranchCallLimitSave      = "branch.call_limit_save"
+	AuditCustomerStatusSave       = "customer_status.save"
+	AuditCustomerStatusDelete     = "customer_status.delete"
+	AuditAssignmentRuleSave       = "assignment_rule.save"
+	AuditAssignmentRuleDelete     = "assignment_rule.delete"
+	AuditCustomerTagSave          = "customer_tag.save"
+	AuditCustomerTagDelete        = "customer_tag.delete"
+	AuditBranchDelete             = "branch.delete"
+	AuditTemplateSetDefault       = "message_template.set_default"
+	AuditNoticeCreate             = "notice.create"
+	AuditNoticeUpdate             = "notice.update"
+	AuditNoticeDelete             = "notice.delete"
+)
+
+// AuditActions - 감사 로그 작업 종류 목록 (필터 표시 순서)
+var AuditActions = []string{
+	AuditCustomerDelete,
+	AuditCustomerRestore,
+	AuditCustomerPurge,
+	AuditCustomerUpdateName,
+	AuditCustomerUpdateComment,
+	AuditCustomerStatusChange,
+	AuditCustomerStatusRevert,
+	AuditCustomerDuplicateAttach,
+	AuditCustomerMerge,
+	AuditCustomerImport,
+	AuditCustomerExport,
+	AuditCustomerAssign,
+	AuditCustomerTransfer,
+	AuditCustomerTagAdd,
+	AuditCustomerTagRemove,
+	AuditCustomerCallbackCreate,
+	AuditCustomerCallbackComplete,
+	AuditCustomerCallbackCancel,
+	AuditCustomerNoteAdd,
+	AuditCustomerNoteUpdate,
+	AuditCustomerNotePin,
+	AuditCustomerPhoneReveal,
+	AuditSMSSend,
+	AuditSMSConfigSave,
+	AuditSMSOptOutAdd,
+	AuditSMSOptOutDelete,
+	AuditSMSOptOutImport,
+	AuditBranchCallLimitSave,
+	AuditCustomerStatusSave,
+	AuditCustomerStatusDelete,
+	AuditAssignmentRuleSave,
+	AuditAssignmentRuleDelete,
+	AuditCustomerTagSave,
+	AuditCustomerTagDelete,
+	AuditBranchDelete,
+	AuditTemplateSetDefault,
+	AuditNoticeCreate,
+	AuditNoticeUpdate,
+	AuditNoticeDelete,
+}
+
+// AuditActionDisplayNames - 감사 로그 작업 종류 화면 표시 이름
+var AuditActionDisplayNames = map[string]string{
+	AuditCustomerDelete:           "고객 삭제 (휴지통)",
+	AuditCustomerRestore:          "고객 복원",
+	AuditCustomerPurge:            "고객 영구 삭제",
+	AuditCustomerUpdateName:       "고객 이름 변경",
+	AuditCustomerUpdateComment:    "고객 코멘트 변경",
+	AuditCustomerStatusChange:     "고객 상태 변경",
+	AuditCustomerStatusRevert:     "고객 상태 되돌리기",
+	AuditCustomerDuplicateAttach:  "중복 유입 연결",
+	AuditCustomerMerge:            "고객 병합",
+	AuditCustomerImport:           "고객 일괄 가져오기",
+	AuditCustomerExport:           "고객 목록 내보내기",
+	AuditCustomerAssign:           "고객 지점 배정",
+	AuditCustomerTransfer:         "고객 지점 이동",
+	AuditCustomerTagAdd:           "고객 태그 추가",
+	AuditCustomerTagRemove:        "고객 태그 제거",
+	AuditCustomerCallbackCreate:   "콜백 등록",
+	AuditCustomerCallbackComplete: "콜백 완료",
+	AuditCustomerCallbackCancel:   "콜백 취소",
+	AuditCustomerNoteAdd:          "고객 메모 추가",
+	AuditCustomerNoteUpdate:       "고객 메모 수정",
+	AuditCustomerNotePin:          "고객 메모 고정 변경",
+	AuditCustomerPhoneReveal:      "고객 전화번호 열람",
+	AuditSMSSend:                  "SMS 발송",
+	AuditSMSConfigSave:            "SMS 연동 설정 저장",
+	AuditSMSOptOutAdd:             "수신거부 번호 등록",
+	AuditSMSOptOutDelete:          "수신거부 번호 해제",
+	AuditSMSOptOutImport:          "수신거부 목록 가져오기",
+	AuditBranchCallLimitSave:      "통화 횟수 제한 변경",
+	AuditCustomerStatusSave:       "고객 상태 저장",
+	AuditCustomerStatusDelete:     "고객 상태 삭제",
+	AuditAssignmentRuleSave:       "자동 배정 규칙 저장",
+	AuditAssignmentRuleDelete:     "자동 배정 규칙 삭제",
+	AuditCustomerTagSave:          "고객 태그 저장",
+	AuditCustomerTagDelete:        "고객 태그 삭제",
+	AuditBranchDelete:             "지점 삭제",
+	AuditTemplateSetDefault:       "기본 메시지 템플릿 설정",
+	AuditNoticeCreate:             "공지사항 등록",
+	AuditNoticeUpdate:             "공지사항 수정",
+	AuditNoticeDelete:             "공지사항 삭제",
+}
+
+// AuditActor - 감사 로그 작업자 정보 (middleware.GetAuditActor로 생성)
+type AuditActor struct {
+	UserSeq   int
+	UserID    string
+	IPAddress string
+}
+
+// AuditLog - 감사 로그 구조체
+type AuditLog struct {
+	Seq         int64
+	UserID      string
+	Action      string
+	EntityType  string
+	EntityID    string
+	BeforeData  string
+	AfterData   string
+	IPAddress   string
+	CreatedDate string
+}
+
+// AuditFilter - 감사 로그 검색 조건
+type AuditFilter struct {
+	UserID     string // 작업자 아이디 (부분 일치)
+	Action     string // 작업 종류
+	EntityType string // 대상 종류
+	EntityID   string // 대상 ID
+	DateFrom   string // 시작일 (YYYY-MM-DD)
+	DateTo     string // 종료일 (YYYY-MM-DD, 당일 포함)
+}
+
+// auditExecer - 감사 로그 기록에 사용하는 DB 또는 트랜잭션
+type auditExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// auditQueryer - 변경 전 값 조회에 사용하는 DB 또는 트랜잭션
+type auditQueryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// RecordAudit - 감사 로그 기록 (트랜잭션 밖의 작업용, 예: 외부 SMS 발송)
+// 파라미터: actor (작업자), action (작업 종류), entityType (대상 종류), entityID (대상 ID), before/after (변경 전/후 값, nil이면 NULL)
+func RecordAudit(actor AuditActor, action, entityType string, entityID interface{}, before, after interface{}) error {
+	return recordAudit(DB, actor, action, entityType, entityID, before, after)
+}
+
+// recordAudit - 감사 로그 기록 (변경 작업과 같은 트랜잭션에서 호출해 함께 커밋/롤백)
+func recordAudit(exec auditExecer, actor AuditActor, action, entityType string, entityID interface{}, before, after interface{}) error {
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		log.Printf("recordAudit - before marshal error: %v", err)
+		return err
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		log.Printf("recordAudit - after marshal error: %v", err)
+		return err
+	}
+
+	var userSeq interface{}
+	if actor.UserSeq > 0 {
+		userSeq = actor.UserSeq
+	}
+
+	query := `INSERT INTO audit_log (user_seq, user_id, action, entity_type, entity_id, before_data, after_data, ip_address, createdDate)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	_, err = exec.Exec(query, userSeq, actor.UserID, action, entityType, fmt.Sprint(entityID), beforeJSON, afterJSON, actor.IPAddress)
+	if err != nil {
+		log.Printf("recordAudit error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// auditJSON - 감사 로그 값을 JSON 문자열로 변환 (nil이면 NULL)
+func auditJSON(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if m, ok := v.(map[string]interface{}); ok && m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// auditSnapshot - 변경 전 행을 컬럼명 → 값 맵으로 조회 (행이 없으면 nil)
+// 감사 로그의 before 값으로 사용하며, 쿼리에는 기록할 컬럼만 명시할 것
+func auditSnapshot(q auditQueryer, query string, args ...interface{}) (map[string]interface{}, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if values[i] == nil {
+			snapshot[column] = nil
+		} else {
+			snapshot[column] = string(values[i])
+		}
+	}
+	return snapshot, nil
+}
+
+// buildAuditFilterConditions - 감사 로그 검색 조건 생성
+func buildAuditFilterConditions(filter AuditFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id LIKE ?")
+		args = append(args, "%"+filter.UserID+"%")
+	}
+
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = ?")
+		args = append(args, filter.EntityType)
+	}
+
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, filter.EntityID)
+	}
+
+	if filter.DateFrom != "" {
+		conditions = append(conditions, "createdDate >= ?")
+		args = append(args, filter.DateFrom)
+	}
+
+	if filter.DateTo != "" {
+		conditions = append(conditions, "createdDate < DATE_ADD(?, INTERVAL 1 DAY)")
+		args = append(args, filter.DateTo)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return whereClause, args
+}
+
+// GetAuditLogsCount - 감사 로그 건수 조회
+// 파라미터: filter (검색 조건)
+func GetAuditLogsCount(filter AuditFilter) (int, error) {
+	whereClause, args := buildAuditFilterConditions(filter)
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM audit_log %s`, whereClause)
+
+	var count int
+	if err := DB.QueryRow(query, args...).Scan(&count); err != nil {
+		log.Printf("GetAuditLogsCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetAuditLogs - 감사 로그 목록 조회 (최신순, 페이징)
+// 파라미터: filter (검색 조건), page (페이지 번호), itemsPerPage (페이지당 항목 수)
+func GetAuditLogs(filter AuditFilter, page, itemsPerPage int) ([]AuditLog, error) {
+	whereClause, args := buildAuditFilterConditions(filter)
+	offset := (page - 1) * itemsPerPage
+
+	query := fmt.Sprintf(`
+		SELECT seq, user_id, action, entity_type, entity_id,
+		       COALESCE(before_data, ''), COALESCE(after_data, ''), ip_address,
+		       DATE_FORMAT(createdDate, '%%Y-%%m-%%d %%H:%%i:%%s')
+		FROM audit_log
+		%s
+		ORDER BY createdDate DESC, seq DESC
+		LIMIT ? OFFSET ?
+	`, whereClause)
+
+	args = append(args, itemsPerPage, offset)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("GetAuditLogs error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []AuditLog
+	for rows.Next() {
+		var a AuditLog
+		if err := rows.Scan(&a.Seq, &a.UserID, &a.Action, &a.EntityType, &a.EntityID,
+			&a.BeforeData, &a.AfterData, &a.IPAddress, &a.CreatedDate); err != nil {
+			log.Printf("GetAuditLogs scan error: %v", err)
+			return nil, err
+		}
+		logs = append(logs, a)
+	}
+
+	return logs, nil
+}
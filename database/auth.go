@@ -1,28 +1,103 @@
 package database
 
 import (
+	"crypto/subtle"
 	"database/sql"
 	"log"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 // User - 사용자 정보 구조체
 type User struct {
-	Seq          int
-	BranchSeq    sql.NullInt64
-	UserID       string
-	UserPassword string
+	Seq                int
+	BranchSeq          sql.NullInt64
+	UserID             string
+	UserPassword       string
+	Role               string // super_admin, branch_manager, caller
+	IsActive           bool   // false면 로그인 불가
+	MustChangePassword bool   // true면 비밀번호 변경 전까지 다른 화면 접근 불가
+	TOTPEnabled        bool   // true면 로그인 시 2단계 인증 코드 필요
+}
+
+// HashPassword - 비밀번호를 bcrypt 해시로 변환
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// isPasswordHashed - 저장된 비밀번호가 bcrypt 해시 형식인지 확인
+// bcrypt 해시는 "$2a$", "$2b$", "$2y$" 접두사로 시작함
+func isPasswordHashed(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") ||
+		strings.HasPrefix(stored, "$2b$") ||
+		strings.HasPrefix(stored, "$2y$")
+}
+
+// verifyPassword - 저장된 비밀번호와 입력 비밀번호 비교
+// 반환: (일치 여부, 해시 재저장 필요 여부)
+func verifyPassword(stored, password string) (bool, bool) {
+	if isPasswordHashed(stored) {
+		if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)); err != nil {
+			return false, false
+		}
+		// cost가 바뀐 경우 다음 로그인 때 새 cost로 재해시
+		cost, err := bcrypt.Cost([]byte(stored))
+		return true, err == nil && cost != bcrypt.DefaultCost
+	}
+
+	// 마이그레이션 이전의 평문 비밀번호 (상수 시간 비교)
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+		return false, false
+	}
+	return true, true
+}
+
+// UpdateUserPassword - 사용자 비밀번호를 해시로 저장
+func UpdateUserPassword(userSeq int, password string) error {
+	hashed, err := HashPassword(password)
+	if err != nil {
+		log.Printf("UpdateUserPassword - hash error: %v", err)
+		return err
+	}
+
+	query := `UPDATE user_info SET user_password = ?, lastUpdateDate = CURDATE() WHERE seq = ?`
+	if _, err := DB.Exec(query, hashed, userSeq); err != nil {
+		log.Printf("UpdateUserPassword - update error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifyUserPassword - 사용자의 현재 비밀번호 확인 (비밀번호 변경 시 본인 확인용)
+func VerifyUserPassword(userSeq int, password string) bool {
+	var stored string
+	query := `SELECT user_password FROM user_info WHERE seq = ?`
+	if err := DB.QueryRow(query, userSeq).Scan(&stored); err != nil {
+		log.Printf("VerifyUserPassword - query error: %v", err)
+		return false
+	}
+
+	matched, _ := verifyPassword(stored, password)
+	return matched
 }
 
-// AuthenticateUser - 사용자 인증 (DB 기반, 평문 비밀번호)
-// user_id로 사용자를 조회하고 평문으로 비밀번호 검증
+// AuthenticateUser - 사용자 인증 (DB 기반, bcrypt 해시 비밀번호)
+// user_id로 사용자를 조회하고 bcrypt로 비밀번호 검증
+// 평문으로 저장된 기존 계정은 로그인 성공 시 해시로 자동 전환
 // 반환: (인증 성공 여부, 사용자 seq)
 func AuthenticateUser(username, password string) (bool, int) {
 	log.Printf("Authentication attempt - Username: %s", username)
 
 	// DB에서 사용자 정보 조회
 	var user User
-	query := `SELECT seq, branch_seq, user_id, user_password 
-	          FROM user_info 
+	query := `SELECT seq, branch_seq, user_id, user_password, role
+	          FROM user_info
 	          WHERE user_id = ?`
 
 	err := DB.QueryRow(query, username).Scan(
@@ -30,6 +105,7 @@ func AuthenticateUser(username, password string) (bool, int) {
 		&user.BranchSeq,
 		&user.UserID,
 		&user.UserPassword,
+		&user.Role,
 	)
 
 	if err != nil {
@@ -41,12 +117,23 @@ func AuthenticateUser(username, password string) (bool, int) {
 		return false, 0
 	}
 
-	// 평문 비밀번호 비교 (테스트용)
-	if user.UserPassword != password {
+	// 비밀번호 검증
+	matched, needsRehash := verifyPassword(user.UserPassword, password)
+	if !matched {
 		log.Printf("Authentication failed - Invalid password for user: %s", username)
 		return false, 0
 	}
 
+	// 평문 또는 구버전 해시는 로그인 성공 시 해시로 업그레이드
+	if needsRehash {
+		if err := UpdateUserPassword(user.Seq, password); err != nil {
+			// 업그레이드 실패해도 로그인은 허용 (다음 로그인 때 재시도)
+			log.Printf("Authentication - password hash upgrade failed for user: %s, error: %v", username, err)
+		} else {
+			log.Printf("Authentication - password upgraded to bcrypt hash for user: %s", username)
+		}
+	}
+
 	log.Printf("Authentication successful for user: %s (seq: %d)", username, user.Seq)
 	return true, user.Seq
 }
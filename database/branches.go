@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 )
@@ -51,21 +52,34 @@ func UpdateBranch(id int, name, alias, manager, address, directions string) (int
 	return rowsAffected, nil
 }
 
-// DeleteBranch - 지점 삭제
-// 파라미터: id (지점 ID)
+// DeleteBranch - 지점 삭제 (삭제 전 지점 정보를 감사 로그에 기록)
+// 파라미터: actor (작업자), id (지점 ID)
 // 반환: 영향받은 행 수, 에러
-func DeleteBranch(id int) (int64, error) {
-	query := `DELETE FROM branches WHERE seq = ?`
+func DeleteBranch(actor AuditActor, id int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `
+			SELECT branchName, alias, branch_manager, address, directions,
+			       (SELECT COUNT(*) FROM customers WHERE branch_seq = b.seq AND deleted_at IS NULL) AS customer_count
+			FROM branches b WHERE seq = ? FOR UPDATE`, id)
+		if err != nil {
+			return err
+		}
 
-	result, err := DB.Exec(query, id)
-	if err != nil {
-		log.Printf("DeleteBranch error: %v", err)
-		return 0, err
-	}
+		result, err := tx.Exec(`DELETE FROM branches WHERE seq = ?`, id)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
+		rowsAffected, err = result.RowsAffected()
+		if err != nil || rowsAffected == 0 {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditBranchDelete, "branch", id, before, nil)
+	})
 	if err != nil {
-		log.Printf("DeleteBranch get rows affected error: %v", err)
+		log.Printf("DeleteBranch error: %v", err)
 		return 0, err
 	}
 
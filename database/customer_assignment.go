@@ -0,0 +1,493 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+)
+
+// 자동 배정 규칙 종류 (customer_assignment_rules.rule_type)
+const (
+	AssignmentRuleRegion     = "region"      // 고객 지역에 match_value가 포함되면 배정
+	AssignmentRuleAdSource   = "ad_source"   // 고객 광고 출처가 match_value와 같으면 배정
+	AssignmentRuleRoundRobin = "round_robin" // 다른 규칙에 맞지 않는 고객을 규칙 지점에 번갈아 배정
+)
+
+// AssignmentRuleTypes - 자동 배정 규칙 종류 (적용 순서)
+var AssignmentRuleTypes = []string{AssignmentRuleRegion, AssignmentRuleAdSource, AssignmentRuleRoundRobin}
+
+// AssignmentRuleTypeNames - 자동 배정 규칙 종류 화면 표시 이름
+var AssignmentRuleTypeNames = map[string]string{
+	AssignmentRuleRegion:     "지역",
+	AssignmentRuleAdSource:   "광고 출처",
+	AssignmentRuleRoundRobin: "순환 배정",
+}
+
+// AssignmentRule - 미배정 고객 자동 배정 규칙
+type AssignmentRule struct {
+	Seq            int
+	RuleType       string
+	MatchValue     string // 비교 값 (round_robin은 빈 문자열)
+	BranchSeq      int
+	BranchName     string
+	Priority       int // 같은 종류 규칙 간 적용 순서 (작을수록 먼저)
+	IsActive       bool
+	LastAssignedAt sql.NullTime
+}
+
+// UnassignedCustomer - 지점 미배정 고객 (배정 대기함 목록)
+type UnassignedCustomer struct {
+	Seq         int
+	Name        string
+	PhoneNumber string
+	AdSource    *string
+	Region      *string
+	Comment     *string
+	Status      string
+	CreatedDate string
+	DuplicateOf *int // 중복 의심 기존 고객 seq (없으면 nil)
+}
+
+// GetAssignmentRules - 자동 배정 규칙 목록 (적용 순서)
+func GetAssignmentRules() ([]AssignmentRule, error) {
+	rows, err := DB.Query(`
+		SELECT r.seq, r.rule_type, r.match_value, r.branch_seq, b.branchName, r.priority, r.is_active, r.last_assigned_at
+		FROM customer_assignment_rules r
+		JOIN branches b ON b.seq = r.branch_seq
+		ORDER BY FIELD(r.rule_type, ?, ?, ?), r.priority, r.seq
+	`, AssignmentRuleRegion, AssignmentRuleAdSource, AssignmentRuleRoundRobin)
+	if err != nil {
+		log.Printf("GetAssignmentRules error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []AssignmentRule
+	for rows.Next() {
+		var rule AssignmentRule
+		if err := rows.Scan(&rule.Seq, &rule.RuleType, &rule.MatchValue, &rule.BranchSeq, &rule.BranchName,
+			&rule.Priority, &rule.IsActive, &rule.LastAssignedAt); err != nil {
+			log.Printf("GetAssignmentRules scan error: %v", err)
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// CreateAssignmentRule - 자동 배정 규칙 추가
+// 파라미터: actor (작업자), rule (RuleType, MatchValue, BranchSeq, Priority 사용)
+// 반환: 생성된 규칙 seq, 에러
+func CreateAssignmentRule(actor AuditActor, rule AssignmentRule) (int64, error) {
+	var seq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			INSERT INTO customer_assignment_rules (rule_type, match_value, branch_seq, priority, is_active, createdDate)
+			VALUES (?, ?, ?, ?, 1, NOW())
+		`, rule.RuleType, rule.MatchValue, rule.BranchSeq, rule.Priority)
+		if err != nil {
+			return err
+		}
+
+		seq, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditAssignmentRuleSave, "assignment_rule", seq, nil, map[string]interface{}{
+			"rule_type":   rule.RuleType,
+			"match_value": rule.MatchValue,
+			"branch_seq":  rule.BranchSeq,
+			"priority":    rule.Priority,
+		})
+	})
+	if err != nil {
+		log.Printf("CreateAssignmentRule error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[Assignment] CreateAssignmentRule 완료 - Seq: %d, Type: %s, Value: %s, BranchSeq: %d", seq, rule.RuleType, rule.MatchValue, rule.BranchSeq)
+	return seq, nil
+}
+
+// SetAssignmentRuleActive - 자동 배정 규칙 사용/중지
+// 반환: 수정된 행 수, 에러
+func SetAssignmentRuleActive(actor AuditActor, ruleSeq int, active bool) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`UPDATE customer_assignment_rules SET is_active = ? WHERE seq = ? AND is_active <> ?`, active, ruleSeq, active)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil || rowsAffected == 0 {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditAssignmentRuleSave, "assignment_rule", ruleSeq,
+			map[string]interface{}{"is_active": !active}, map[string]interface{}{"is_active": active})
+	})
+	if err != nil {
+		log.Printf("SetAssignmentRuleActive error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// DeleteAssignmentRule - 자동 배정 규칙 삭제
+// 반환: 삭제된 행 수, 에러
+func DeleteAssignmentRule(actor AuditActor, ruleSeq int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `
+			SELECT rule_type, match_value, branch_seq, priority, is_active
+			FROM customer_assignment_rules WHERE seq = ? FOR UPDATE`, ruleSeq)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+
+		result, err := tx.Exec(`DELETE FROM customer_assignment_rules WHERE seq = ?`, ruleSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditAssignmentRuleDelete, "assignment_rule", ruleSeq, before, nil)
+	})
+	if err != nil {
+		log.Printf("DeleteAssignmentRule error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// activeAssignmentRulesTx - 사용 중인 자동 배정 규칙 조회 (순환 배정 순서를 지키기 위해 규칙 행을 잠금)
+func activeAssignmentRulesTx(tx *sql.Tx) ([]AssignmentRule, error) {
+	rows, err := tx.Query(`
+		SELECT r.seq, r.rule_type, r.match_value, r.branch_seq, b.branchName, r.priority, r.is_active, r.last_assigned_at
+		FROM customer_assignment_rules r
+		JOIN branches b ON b.seq = r.branch_seq
+		WHERE r.is_active = 1
+		ORDER BY r.priority, r.seq
+		FOR UPDATE
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []AssignmentRule
+	for rows.Next() {
+		var rule AssignmentRule
+		if err := rows.Scan(&rule.Seq, &rule.RuleType, &rule.MatchValue, &rule.BranchSeq, &rule.BranchName,
+			&rule.Priority, &rule.IsActive, &rule.LastAssignedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// pickAssignmentRule - 고객에게 적용할 규칙 선택 (지역 → 광고 출처 → 순환 배정 순서)
+// 순환 배정은 마지막 배정이 가장 오래된 규칙 (한 번도 배정하지 않은 규칙 우선)
+// 반환: rules 안의 인덱스 (맞는 규칙이 없으면 -1)
+func pickAssignmentRule(rules []AssignmentRule, region, adSource string) int {
+	region = strings.ToLower(strings.TrimSpace(region))
+	adSource = strings.TrimSpace(adSource)
+
+	if region != "" {
+		for i, rule := range rules {
+			if rule.RuleType == AssignmentRuleRegion && rule.MatchValue != "" &&
+				strings.Contains(region, strings.ToLower(rule.MatchValue)) {
+				return i
+			}
+		}
+	}
+
+	if adSource != "" {
+		for i, rule := range rules {
+			if rule.RuleType == AssignmentRuleAdSource && strings.EqualFold(rule.MatchValue, adSource) {
+				return i
+			}
+		}
+	}
+
+	picked := -1
+	for i, rule := range rules {
+		if rule.RuleType != AssignmentRuleRoundRobin {
+			continue
+		}
+		if picked < 0 || assignedBefore(rule.LastAssignedAt, rules[picked].LastAssignedAt) {
+			picked = i
+		}
+	}
+	return picked
+}
+
+// assignedBefore - a가 b보다 먼저 배정되었는지 (배정 이력이 없으면 가장 먼저로 취급)
+func assignedBefore(a, b sql.NullTime) bool {
+	if !a.Valid {
+		return b.Valid
+	}
+	return b.Valid && a.Time.Before(b.Time)
+}
+
+// markAssignmentRuleUsedTx - 규칙의 마지막 배정 일시 갱신 (같은 트랜잭션에서 연속 배정해도 순서가 유지되도록 이전 값보다 늦은 시각 사용)
+func markAssignmentRuleUsedTx(tx *sql.Tx, rules []AssignmentRule, index int) error {
+	now := time.Now()
+	for _, rule := range rules {
+		if rule.LastAssignedAt.Valid && !now.After(rule.LastAssignedAt.Time) {
+			now = rule.LastAssignedAt.Time.Add(time.Microsecond)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE customer_assignment_rules SET last_assigned_at = ? WHERE seq = ?`, now, rules[index].Seq); err != nil {
+		return err
+	}
+	rules[index].LastAssignedAt = sql.NullTime{Time: now, Valid: true}
+	return nil
+}
+
+// assignmentAuditData - 지점 배정 감사 로그 값 (rule이 nil이면 수동 배정)
+func assignmentAuditData(branchSeq int, branchName string, rule *AssignmentRule) map[string]interface{} {
+	data := map[string]interface{}{
+		"branch_seq":  branchSeq,
+		"branch_name": branchName,
+	}
+	if rule != nil {
+		data["rule_seq"] = rule.Seq
+		data["rule_type"] = rule.RuleType
+		data["match_value"] = rule.MatchValue
+	}
+	return data
+}
+
+// CreateConsultationCustomer - 상담신청 고객 등록 (자동 배정 규칙에 맞으면 해당 지점으로, 없으면 미배정)
+// 파라미터: name (고객명), phoneNumber (전화번호), region (입력한 지역, 없으면 빈 문자열)
+// 반환: 등록 결과, 배정된 지점 seq (미배정이면 0), 에러 (reject 정책이면 *DuplicateCustomerError)
+func CreateConsultationCustomer(name, phoneNumber, region string) (*CustomerInsertResult, int, error) {
+	const adSource = "상담신청"
+
+	var result *CustomerInsertResult
+	assignedBranch := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		rules, err := activeAssignmentRulesTx(tx)
+		if err != nil {
+			return err
+		}
+
+		var branchSeq *int
+		index := pickAssignmentRule(rules, region, adSource)
+		if index >= 0 {
+			branchSeq = &rules[index].BranchSeq
+		}
+
+		result, err = createCustomerTx(tx, branchSeq, name, phoneNumber, "", "", adSource)
+		if err != nil {
+			return err
+		}
+
+		if region != "" {
+			if _, err := tx.Exec(`UPDATE customers SET region = ? WHERE seq = ? AND region IS NULL`, region, result.Seq); err != nil {
+				return err
+			}
+		}
+
+		// 기존 고객에 연결된 경우 기존 고객의 지점을 유지
+		if index < 0 || result.Attached {
+			return nil
+		}
+
+		if err := markAssignmentRuleUsedTx(tx, rules, index); err != nil {
+			return err
+		}
+		assignedBranch = rules[index].BranchSeq
+
+		return recordAudit(tx, AuditActor{}, AuditCustomerAssign, "customer", result.Seq,
+			map[string]interface{}{"branch_seq": nil}, assignmentAuditData(rules[index].BranchSeq, rules[index].BranchName, &rules[index]))
+	})
+	if err != nil {
+		if _, ok := err.(*DuplicateCustomerError); !ok {
+			log.Printf("CreateConsultationCustomer error: %v", err)
+		}
+		return nil, 0, err
+	}
+
+	log.Printf("[Assignment] CreateConsultationCustomer 완료 - ID: %d, Region: %s, BranchSeq: %d", result.Seq, region, assignedBranch)
+	return result, assignedBranch, nil
+}
+
+// GetUnassignedCustomersCount - 지점 미배정 고객 수
+func GetUnassignedCustomersCount() (int, error) {
+	count, err := Count(`SELECT COUNT(*) FROM customers WHERE branch_seq IS NULL AND deleted_at IS NULL`)
+	if err != nil {
+		log.Printf("GetUnassignedCustomersCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetUnassignedCustomers - 지점 미배정 고객 목록 (오래된 신청부터, 페이징 적용)
+func GetUnassignedCustomers(page, itemsPerPage int) ([]UnassignedCustomer, error) {
+	rows, err := DB.Query(`
+		SELECT seq, name, phone_number, ad_source, region, comment, status,
+		       DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i'), duplicate_of
+		FROM customers
+		WHERE branch_seq IS NULL AND deleted_at IS NULL
+		ORDER BY createdDate, seq
+		LIMIT ? OFFSET ?
+	`, itemsPerPage, (page-1)*itemsPerPage)
+	if err != nil {
+		log.Printf("GetUnassignedCustomers error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []UnassignedCustomer
+	for rows.Next() {
+		var c UnassignedCustomer
+		if err := rows.Scan(&c.Seq, &c.Name, &c.PhoneNumber, &c.AdSource, &c.Region, &c.Comment, &c.Status,
+			&c.CreatedDate, &c.DuplicateOf); err != nil {
+			log.Printf("GetUnassignedCustomers scan error: %v", err)
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+	return customers, rows.Err()
+}
+
+// AssignCustomers - 미배정 고객을 지점에 배정 (이미 배정된 고객은 건너뜀)
+// 파라미터: actor (작업자), customerSeqs (고객 seq 목록), branchSeq (배정할 지점)
+// 반환: 배정된 고객 수, 에러 (지점이 없으면 sql.ErrNoRows)
+func AssignCustomers(actor AuditActor, customerSeqs []int, branchSeq int) (int, error) {
+	if len(customerSeqs) == 0 {
+		return 0, nil
+	}
+
+	assigned := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		var branchName string
+		if err := tx.QueryRow(`SELECT branchName FROM branches WHERE seq = ?`, branchSeq).Scan(&branchName); err != nil {
+			return err
+		}
+
+		placeholders, args := inPlaceholders(customerSeqs)
+		rows, err := tx.Query(`SELECT seq FROM customers WHERE branch_seq IS NULL AND deleted_at IS NULL AND seq IN (`+placeholders+`) FOR UPDATE`, args...)
+		if err != nil {
+			return err
+		}
+		var targets []int64
+		for rows.Next() {
+			var seq int64
+			if err := rows.Scan(&seq); err != nil {
+				rows.Close()
+				return err
+			}
+			targets = append(targets, seq)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, seq := range targets {
+			if _, err := tx.Exec(`UPDATE customers SET branch_seq = ? WHERE seq = ?`, branchSeq, seq); err != nil {
+				return err
+			}
+			if err := recordAudit(tx, actor, AuditCustomerAssign, "customer", seq,
+				map[string]interface{}{"branch_seq": nil}, assignmentAuditData(branchSeq, branchName, nil)); err != nil {
+				return err
+			}
+		}
+		assigned = len(targets)
+		return nil
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("AssignCustomers error: %v", err)
+		}
+		return 0, err
+	}
+
+	log.Printf("[Assignment] AssignCustomers 완료 - BranchSeq: %d, 요청: %d, 배정: %d", branchSeq, len(customerSeqs), assigned)
+	return assigned, nil
+}
+
+// ApplyAssignmentRules - 현재 미배정 고객 전체에 자동 배정 규칙 적용 (맞는 규칙이 없는 고객은 미배정 유지)
+// 반환: 배정된 고객 수, 에러
+func ApplyAssignmentRules(actor AuditActor) (int, error) {
+	assigned := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		rules, err := activeAssignmentRulesTx(tx)
+		if err != nil || len(rules) == 0 {
+			return err
+		}
+
+		type pending struct {
+			seq      int64
+			region   string
+			adSource string
+		}
+
+		rows, err := tx.Query(`
+			SELECT seq, COALESCE(region, ''), COALESCE(ad_source, '')
+			FROM customers
+			WHERE branch_seq IS NULL AND deleted_at IS NULL
+			ORDER BY createdDate, seq
+			FOR UPDATE
+		`)
+		if err != nil {
+			return err
+		}
+		var customers []pending
+		for rows.Next() {
+			var p pending
+			if err := rows.Scan(&p.seq, &p.region, &p.adSource); err != nil {
+				rows.Close()
+				return err
+			}
+			customers = append(customers, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, c := range customers {
+			index := pickAssignmentRule(rules, c.region, c.adSource)
+			if index < 0 {
+				continue
+			}
+
+			rule := &rules[index]
+			if _, err := tx.Exec(`UPDATE customers SET branch_seq = ? WHERE seq = ?`, rule.BranchSeq, c.seq); err != nil {
+				return err
+			}
+			if err := markAssignmentRuleUsedTx(tx, rules, index); err != nil {
+				return err
+			}
+			if err := recordAudit(tx, actor, AuditCustomerAssign, "customer", c.seq,
+				map[string]interface{}{"branch_seq": nil}, assignmentAuditData(rule.BranchSeq, rule.BranchName, rule)); err != nil {
+				return err
+			}
+			assigned++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ApplyAssignmentRules error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[Assignment] ApplyAssignmentRules 완료 - 배정: %d", assigned)
+	return assigned, nil
+}
@@ -0,0 +1,267 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// BulkMaxCustomers - 일괄 처리 한 번에 선택할 수 있는 최대 고객 수
+const BulkMaxCustomers = 500
+
+// BulkResult - 일괄 처리 고객별 결과
+type BulkResult struct {
+	CustomerSeq int    `json:"customer_seq"`
+	Success     bool   `json:"success"`
+	Message     string `json:"message"` // 처리 내용 또는 실패 사유
+}
+
+// bulkCustomer - 일괄 처리 대상 고객 (lockBulkCustomersTx로 잠근 행)
+type bulkCustomer struct {
+	Status string
+}
+
+// bulkNotFoundMessage - 선택한 지점에 없거나 휴지통에 있는 고객의 결과 메시지
+const bulkNotFoundMessage = "고객을 찾을 수 없습니다 (다른 지점 또는 휴지통 고객)"
+
+// lockBulkCustomersTx - 지점 고객 중 선택한 고객을 잠그고 조회 (휴지통 고객 제외)
+// 반환: 고객 seq → 고객 정보 (목록에 없는 seq는 처리 대상이 아님), 에러
+func lockBulkCustomersTx(tx *sql.Tx, branchSeq int, customerSeqs []int) (map[int]bulkCustomer, error) {
+	placeholders, seqArgs := inPlaceholders(customerSeqs)
+	args := append([]interface{}{branchSeq}, seqArgs...)
+	rows, err := tx.Query(`
+		SELECT seq, status
+		FROM customers
+		WHERE branch_seq = ? AND deleted_at IS NULL AND seq IN (`+placeholders+`)
+		FOR UPDATE
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := map[int]bulkCustomer{}
+	for rows.Next() {
+		var seq int
+		var c bulkCustomer
+		if err := rows.Scan(&seq, &c.Status); err != nil {
+			return nil, err
+		}
+		customers[seq] = c
+	}
+	return customers, rows.Err()
+}
+
+// BulkChangeCustomerStatus - 선택한 고객 상태 일괄 변경 (하나의 트랜잭션)
+// 허용되지 않은 변경이나 같은 상태는 해당 고객만 실패로 기록하고 나머지는 계속 처리
+// 파라미터: actor (작업자), branchSeq (지점 seq, 지점 고객만 처리), customerSeqs, to (변경할 상태), reason (변경 사유)
+// 반환: 고객별 결과 (요청 순서), 에러 (등록되지 않은 상태면 sql.ErrNoRows)
+func BulkChangeCustomerStatus(actor AuditActor, branchSeq int, customerSeqs []int, to, reason string) ([]BulkResult, error) {
+	if len(customerSeqs) == 0 {
+		return nil, nil
+	}
+
+	var results []BulkResult
+	var events []*CustomerStatusEvent
+	err := Transaction(func(tx *sql.Tx) error {
+		defs, err := customerStatusDefsTx(tx)
+		if err != nil {
+			return err
+		}
+		toDef, ok := defs[to]
+		if !ok {
+			return sql.ErrNoRows
+		}
+
+		customers, err := lockBulkCustomersTx(tx, branchSeq, customerSeqs)
+		if err != nil {
+			return err
+		}
+
+		for _, seq := range customerSeqs {
+			c, ok := customers[seq]
+			if !ok {
+				results = append(results, BulkResult{CustomerSeq: seq, Message: bulkNotFoundMessage})
+				continue
+			}
+			if c.Status == to {
+				results = append(results, BulkResult{CustomerSeq: seq, Message: fmt.Sprintf("이미 '%s' 상태입니다", to)})
+				continue
+			}
+			if !CanTransitionCustomerStatus(lookupCustomerStatusDef(defs, c.Status), toDef) {
+				results = append(results, BulkResult{CustomerSeq: seq, Message: (&InvalidStatusTransitionError{From: c.Status, To: to}).Error()})
+				continue
+			}
+
+			event, err := applyCustomerStatusTx(tx, actor, seq, c.Status, to, reason, nil)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+			results = append(results, BulkResult{CustomerSeq: seq, Success: true, Message: fmt.Sprintf("'%s' → '%s'", c.Status, to)})
+		}
+		return nil
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("BulkChangeCustomerStatus error: %v", err)
+		}
+		return nil, err
+	}
+
+	for _, event := range events {
+		publishCustomerStatusEvent(event)
+	}
+
+	log.Printf("[Customer] BulkChangeCustomerStatus 완료 - BranchSeq: %d, Status: %s, 요청: %d, 변경: %d", branchSeq, to, len(customerSeqs), len(events))
+	return results, nil
+}
+
+// BulkDeleteCustomers - 선택한 고객 일괄 삭제 (휴지통으로 이동, 하나의 트랜잭션)
+// 카카오 가입 고객도 함께 이동하며, 카카오 연결 해제는 휴지통에서 영구 삭제될 때 처리
+// 파라미터: actor (작업자), branchSeq (지점 seq, 지점 고객만 처리), customerSeqs
+// 반환: 고객별 결과 (요청 순서), 에러
+func BulkDeleteCustomers(actor AuditActor, branchSeq int, customerSeqs []int) ([]BulkResult, error) {
+	if len(customerSeqs) == 0 {
+		return nil, nil
+	}
+
+	var results []BulkResult
+	deleted := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		customers, err := lockBulkCustomersTx(tx, branchSeq, customerSeqs)
+		if err != nil {
+			return err
+		}
+
+		for _, seq := range customerSeqs {
+			if _, ok := customers[seq]; !ok {
+				results = append(results, BulkResult{CustomerSeq: seq, Message: bulkNotFoundMessage})
+				continue
+			}
+
+			if _, err := deleteCustomerTx(tx, actor, seq); err != nil {
+				return err
+			}
+			deleted++
+			results = append(results, BulkResult{CustomerSeq: seq, Success: true, Message: "휴지통으로 이동"})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("BulkDeleteCustomers error: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[Customer] BulkDeleteCustomers 완료 - BranchSeq: %d, 요청: %d, 삭제: %d", branchSeq, len(customerSeqs), deleted)
+	return results, nil
+}
+
+// BulkTransferCustomers - 선택한 고객을 다른 지점으로 일괄 이동 (하나의 트랜잭션)
+// 태그는 지점별로 관리되므로 이동한 고객에서 이전 지점 태그를 제거
+// 파라미터: actor (작업자), branchSeq (현재 지점 seq, 지점 고객만 처리), customerSeqs, toBranchSeq (이동할 지점)
+// 반환: 고객별 결과 (요청 순서), 에러 (이동할 지점이 없으면 sql.ErrNoRows)
+func BulkTransferCustomers(actor AuditActor, branchSeq int, customerSeqs []int, toBranchSeq int) ([]BulkResult, error) {
+	if len(customerSeqs) == 0 {
+		return nil, nil
+	}
+
+	var results []BulkResult
+	moved := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		var fromName, toName string
+		if err := tx.QueryRow(`SELECT branchName FROM branches WHERE seq = ?`, branchSeq).Scan(&fromName); err != nil {
+			return err
+		}
+		if err := tx.QueryRow(`SELECT branchName FROM branches WHERE seq = ?`, toBranchSeq).Scan(&toName); err != nil {
+			return err
+		}
+
+		customers, err := lockBulkCustomersTx(tx, branchSeq, customerSeqs)
+		if err != nil {
+			return err
+		}
+
+		before := map[string]interface{}{"branch_seq": branchSeq, "branch_name": fromName}
+		for _, seq := range customerSeqs {
+			if _, ok := customers[seq]; !ok {
+				results = append(results, BulkResult{CustomerSeq: seq, Message: bulkNotFoundMessage})
+				continue
+			}
+
+			if _, err := tx.Exec(`UPDATE customers SET branch_seq = ? WHERE seq = ?`, toBranchSeq, seq); err != nil {
+				return err
+			}
+
+			result, err := tx.Exec(`
+				DELETE ct FROM customer_tags ct
+				JOIN customer_tag_defs t ON t.seq = ct.tag_seq
+				WHERE ct.customer_seq = ? AND t.branch_seq <> ?
+			`, seq, toBranchSeq)
+			if err != nil {
+				return err
+			}
+			removedTags, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			after := map[string]interface{}{"branch_seq": toBranchSeq, "branch_name": toName, "removed_tags": removedTags}
+			if err := recordAudit(tx, actor, AuditCustomerTransfer, "customer", seq, before, after); err != nil {
+				return err
+			}
+			moved++
+			results = append(results, BulkResult{CustomerSeq: seq, Success: true, Message: fmt.Sprintf("%s → %s", fromName, toName)})
+		}
+		return nil
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("BulkTransferCustomers error: %v", err)
+		}
+		return nil, err
+	}
+
+	log.Printf("[Customer] BulkTransferCustomers 완료 - BranchSeq: %d → %d, 요청: %d, 이동: %d", branchSeq, toBranchSeq, len(customerSeqs), moved)
+	return results, nil
+}
+
+// BulkSMSTarget - 일괄 문자 발송 대상 고객
+type BulkSMSTarget struct {
+	Seq         int
+	Name        string
+	PhoneNumber string
+}
+
+// GetBulkSMSTargets - 일괄 문자 발송 대상 고객 조회 (지점 고객만, 휴지통 고객 제외)
+// 파라미터: branchSeq (지점 seq), customerSeqs
+// 반환: 고객 seq → 발송 대상 (목록에 없는 seq는 발송 대상이 아님), 에러
+func GetBulkSMSTargets(branchSeq int, customerSeqs []int) (map[int]BulkSMSTarget, error) {
+	targets := map[int]BulkSMSTarget{}
+	if len(customerSeqs) == 0 {
+		return targets, nil
+	}
+
+	placeholders, seqArgs := inPlaceholders(customerSeqs)
+	args := append([]interface{}{branchSeq}, seqArgs...)
+	rows, err := DB.Query(`
+		SELECT seq, name, COALESCE(phone_number, '')
+		FROM customers
+		WHERE branch_seq = ? AND deleted_at IS NULL AND seq IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		log.Printf("GetBulkSMSTargets error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t BulkSMSTarget
+		if err := rows.Scan(&t.Seq, &t.Name, &t.PhoneNumber); err != nil {
+			log.Printf("GetBulkSMSTargets scan error: %v", err)
+			return nil, err
+		}
+		targets[t.Seq] = t
+	}
+	return targets, rows.Err()
+}
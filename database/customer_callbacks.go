@@ -0,0 +1,366 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// CallbackTask - 고객 콜백 예정
+type CallbackTask struct {
+	Seq             int
+	CustomerSeq     int
+	CustomerName    string
+	PhoneNumber     string
+	DueAt           string // 예정 일시 (YYYY-MM-DD HH:MM)
+	OwnerUserSeq    sql.NullInt64
+	OwnerUserID     string // 담당자 아이디 (담당자가 없으면 빈 문자열)
+	Note            string
+	CreatedBy       string // 등록한 사용자 아이디
+	CreatedDate     string
+	CompletedAt     string // 완료 일시 (예정이면 빈 문자열)
+	CompletedBy     string // 완료 처리한 사용자 아이디
+	CompletedCaller string // 완료 시 통화한 CALLER
+	Overdue         bool   // 완료되지 않았고 예정 일시가 지남
+}
+
+// CallbackOwner - 콜백 담당자 선택지 (고객 지점 또는 전체 지점 계정)
+type CallbackOwner struct {
+	Seq    int
+	UserID string
+}
+
+const callbackTaskColumns = `
+	cb.seq, cb.customer_seq, c.name, c.phone_number,
+	DATE_FORMAT(cb.due_at, '%Y-%m-%d %H:%i'), cb.owner_user_seq, COALESCE(ou.user_id, ''), cb.note,
+	COALESCE(cu.user_id, ''), DATE_FORMAT(cb.createdDate, '%Y-%m-%d %H:%i'),
+	COALESCE(DATE_FORMAT(cb.completed_at, '%Y-%m-%d %H:%i'), ''), COALESCE(du.user_id, ''), COALESCE(cb.completed_caller, ''),
+	(cb.completed_at IS NULL AND cb.due_at < NOW())`
+
+const callbackTaskJoins = `
+	FROM customer_callbacks cb
+	JOIN customers c ON c.seq = cb.customer_seq
+	LEFT JOIN user_info ou ON ou.seq = cb.owner_user_seq
+	LEFT JOIN user_info cu ON cu.seq = cb.created_by
+	LEFT JOIN user_info du ON du.seq = cb.completed_by`
+
+// scanCallbackTasks - 콜백 조회 결과를 목록으로 변환
+func scanCallbackTasks(rows *sql.Rows) ([]CallbackTask, error) {
+	defer rows.Close()
+
+	var tasks []CallbackTask
+	for rows.Next() {
+		var t CallbackTask
+		if err := rows.Scan(&t.Seq, &t.CustomerSeq, &t.CustomerName, &t.PhoneNumber,
+			&t.DueAt, &t.OwnerUserSeq, &t.OwnerUserID, &t.Note,
+			&t.CreatedBy, &t.CreatedDate,
+			&t.CompletedAt, &t.CompletedBy, &t.CompletedCaller,
+			&t.Overdue); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// actorUserSeq - 작업자 사용자 seq (API 토큰 등 사용자가 없으면 NULL)
+func actorUserSeq(actor AuditActor) interface{} {
+	if actor.UserSeq > 0 {
+		return actor.UserSeq
+	}
+	return nil
+}
+
+// GetCustomerCallbacks - 고객의 콜백 목록 (예정은 예정 일시순, 완료는 최근 완료순으로 뒤에 표시)
+// 파라미터: customerSeq (고객 seq)
+func GetCustomerCallbacks(customerSeq int) ([]CallbackTask, error) {
+	rows, err := DB.Query(`SELECT `+callbackTaskColumns+callbackTaskJoins+`
+		WHERE cb.customer_seq = ?
+		ORDER BY cb.completed_at IS NOT NULL, cb.completed_at DESC, cb.due_at, cb.seq
+		LIMIT 100
+	`, customerSeq)
+	if err != nil {
+		log.Printf("GetCustomerCallbacks error: %v", err)
+		return nil, err
+	}
+
+	tasks, err := scanCallbackTasks(rows)
+	if err != nil {
+		log.Printf("GetCustomerCallbacks scan error: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetDueCallbacks - 지점의 연락할 콜백 목록 (지난 콜백 + dueSoonMinutes 안에 예정된 콜백, 예정 일시순)
+// 파라미터: branchSeq (지점 seq), dueSoonMinutes (지금부터 포함할 예정 시간, 분), limit (최대 개수)
+func GetDueCallbacks(branchSeq, dueSoonMinutes, limit int) ([]CallbackTask, error) {
+	rows, err := DB.Query(`SELECT `+callbackTaskColumns+callbackTaskJoins+`
+		WHERE c.branch_seq = ? AND c.deleted_at IS NULL
+		  AND cb.completed_at IS NULL AND cb.due_at <= NOW() + INTERVAL ? MINUTE
+		ORDER BY cb.due_at, cb.seq
+		LIMIT ?
+	`, branchSeq, dueSoonMinutes, limit)
+	if err != nil {
+		log.Printf("GetDueCallbacks error: %v", err)
+		return nil, err
+	}
+
+	tasks, err := scanCallbackTasks(rows)
+	if err != nil {
+		log.Printf("GetDueCallbacks scan error: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountDueCallbacks - 지점의 연락할 콜백 수
+// 파라미터: branchSeq (지점 seq), dueSoonMinutes (지금부터 포함할 예정 시간, 분)
+// 반환: 연락할 콜백 수 (지난 콜백 포함), 예정 일시가 지난 콜백 수, 에러
+func CountDueCallbacks(branchSeq, dueSoonMinutes int) (int, int, error) {
+	var due, overdue int
+	err := DB.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(cb.due_at < NOW()), 0)
+		FROM customer_callbacks cb
+		JOIN customers c ON c.seq = cb.customer_seq
+		WHERE c.branch_seq = ? AND c.deleted_at IS NULL
+		  AND cb.completed_at IS NULL AND cb.due_at <= NOW() + INTERVAL ? MINUTE
+	`, branchSeq, dueSoonMinutes).Scan(&due, &overdue)
+	if err != nil {
+		log.Printf("CountDueCallbacks error: %v", err)
+		return 0, 0, err
+	}
+	return due, overdue, nil
+}
+
+// GetCallbackOwners - 고객의 콜백 담당자 선택지 (고객 지점 계정 + 전체 지점 계정, 활성 계정만)
+// 파라미터: customerSeq (고객 seq)
+func GetCallbackOwners(customerSeq int) ([]CallbackOwner, error) {
+	rows, err := DB.Query(`
+		SELECT u.seq, u.user_id
+		FROM user_info u
+		JOIN customers c ON c.seq = ?
+		WHERE u.is_active = 1 AND (u.branch_seq IS NULL OR u.branch_seq = c.branch_seq)
+		ORDER BY u.user_id
+	`, customerSeq)
+	if err != nil {
+		log.Printf("GetCallbackOwners error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []CallbackOwner
+	for rows.Next() {
+		var o CallbackOwner
+		if err := rows.Scan(&o.Seq, &o.UserID); err != nil {
+			log.Printf("GetCallbackOwners scan error: %v", err)
+			return nil, err
+		}
+		owners = append(owners, o)
+	}
+	return owners, rows.Err()
+}
+
+// CreateCallback - 고객 콜백 등록
+// 파라미터: actor (작업자), customerSeq (고객 seq), dueAt (예정 일시, YYYY-MM-DD HH:MM:SS), ownerUserSeq (담당자, nil이면 없음), note (메모)
+// 반환: 생성된 콜백 seq, 에러 (휴지통 고객이면 sql.ErrNoRows)
+func CreateCallback(actor AuditActor, customerSeq int, dueAt string, ownerUserSeq *int, note string) (int64, error) {
+	var seq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		if _, err := lockCustomerStatus(tx, customerSeq); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(`
+			INSERT INTO customer_callbacks (customer_seq, due_at, owner_user_seq, note, created_by, createdDate)
+			VALUES (?, ?, ?, ?, ?, NOW())
+		`, customerSeq, dueAt, ownerUserSeq, note, actorUserSeq(actor))
+		if err != nil {
+			return err
+		}
+
+		seq, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		after, err := auditSnapshot(tx, `
+			SELECT cb.seq AS callback_seq, DATE_FORMAT(cb.due_at, '%Y-%m-%d %H:%i') AS due_at,
+			       u.user_id AS owner, cb.note
+			FROM customer_callbacks cb
+			LEFT JOIN user_info u ON u.seq = cb.owner_user_seq
+			WHERE cb.seq = ?`, seq)
+		if err != nil {
+			return err
+		}
+		return recordAudit(tx, actor, AuditCustomerCallbackCreate, "customer", customerSeq, nil, after)
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("CreateCallback error: %v", err)
+		}
+		return 0, err
+	}
+
+	log.Printf("[Callback] CreateCallback 완료 - CustomerSeq: %d, Seq: %d, DueAt: %s", customerSeq, seq, dueAt)
+	return seq, nil
+}
+
+// lockOpenCallback - 완료되지 않은 고객 콜백을 잠그고 감사 로그용 값 조회
+// 반환: 콜백 값 (없거나 이미 완료됐으면 nil), 에러
+func lockOpenCallback(tx *sql.Tx, customerSeq, callbackSeq int) (map[string]interface{}, error) {
+	return auditSnapshot(tx, `
+		SELECT cb.seq AS callback_seq, DATE_FORMAT(cb.due_at, '%Y-%m-%d %H:%i') AS due_at,
+		       u.user_id AS owner, cb.note
+		FROM customer_callbacks cb
+		LEFT JOIN user_info u ON u.seq = cb.owner_user_seq
+		WHERE cb.seq = ? AND cb.customer_seq = ? AND cb.completed_at IS NULL
+		FOR UPDATE`, callbackSeq, customerSeq)
+}
+
+// CancelCallback - 완료되지 않은 고객 콜백 취소 (삭제)
+// 파라미터: actor (작업자), customerSeq (고객 seq), callbackSeq (콜백 seq)
+// 반환: 삭제된 행 수 (없거나 이미 완료됐으면 0), 에러
+func CancelCallback(actor AuditActor, customerSeq, callbackSeq int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := lockOpenCallback(tx, customerSeq, callbackSeq)
+		if err != nil || before == nil {
+			return err
+		}
+
+		result, err := tx.Exec(`DELETE FROM customer_callbacks WHERE seq = ?`, callbackSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerCallbackCancel, "customer", customerSeq, before, nil)
+	})
+	if err != nil {
+		log.Printf("CancelCallback error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// CancelCallbacksOnTerminalStatus - 고객이 종료 상태(예약확정, 전화상거절 등)로 바뀌면 남은 콜백 예정을 취소
+// OnCustomerStatusChange로 등록하며, 이벤트 처리 전에 상태가 다시 바뀌었으면 취소하지 않음
+// 취소는 상태를 바꾼 작업자로 감사 로그에 기록 (고객 타임라인에 콜백 취소로 표시)
+func CancelCallbacksOnTerminalStatus(event CustomerStatusEvent) {
+	canceled := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		status, err := lockCustomerStatus(tx, event.CustomerSeq)
+		if err == sql.ErrNoRows || status != event.ToStatus {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		defs, err := customerStatusDefsTx(tx)
+		if err != nil {
+			return err
+		}
+		if !defs[status].IsTerminal {
+			return nil
+		}
+
+		rows, err := tx.Query(`SELECT seq FROM customer_callbacks WHERE customer_seq = ? AND completed_at IS NULL`, event.CustomerSeq)
+		if err != nil {
+			return err
+		}
+		var callbackSeqs []int
+		for rows.Next() {
+			var seq int
+			if err := rows.Scan(&seq); err != nil {
+				rows.Close()
+				return err
+			}
+			callbackSeqs = append(callbackSeqs, seq)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, callbackSeq := range callbackSeqs {
+			before, err := lockOpenCallback(tx, event.CustomerSeq, callbackSeq)
+			if err != nil {
+				return err
+			}
+			if before == nil {
+				continue
+			}
+			if _, err := tx.Exec(`DELETE FROM customer_callbacks WHERE seq = ?`, callbackSeq); err != nil {
+				return err
+			}
+			if err := recordAudit(tx, event.Actor, AuditCustomerCallbackCancel, "customer", event.CustomerSeq, before, map[string]interface{}{
+				"reason": fmt.Sprintf("상태 변경: %s → %s", event.FromStatus, event.ToStatus),
+			}); err != nil {
+				return err
+			}
+			canceled++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("CancelCallbacksOnTerminalStatus error: %v", err)
+		return
+	}
+
+	if canceled > 0 {
+		log.Printf("[Callback] CancelCallbacksOnTerminalStatus 완료 - CustomerSeq: %d, Status: %s, 취소: %d", event.CustomerSeq, event.ToStatus, canceled)
+	}
+}
+
+// CompleteCallback - 고객 콜백 완료 (통화 처리와 함께 하나의 트랜잭션으로 처리)
+// ProcessCallWithCallerSelection과 같이 CALLER 선택 이력 추가 + 통화 횟수 증가 + 상태 변경을 기록
+// 파라미터: actor (작업자), customerSeq (고객 seq), callbackSeq (콜백 seq), branchSeq (통화한 지점), caller (통화한 CALLER)
+// 반환: 통화 처리 결과, 에러 (콜백이 없거나 이미 완료됐으면 sql.ErrNoRows)
+func CompleteCallback(actor AuditActor, customerSeq, callbackSeq, branchSeq int, caller string) (*CallProcessResult, error) {
+	var callResult *CallProcessResult
+	var event *CustomerStatusEvent
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := lockOpenCallback(tx, customerSeq, callbackSeq)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return sql.ErrNoRows
+		}
+
+		callResult, event, err = processCallTx(tx, actor, customerSeq, branchSeq, caller)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE customer_callbacks
+			SET completed_at = NOW(), completed_by = ?, completed_caller = ?
+			WHERE seq = ?
+		`, actorUserSeq(actor), caller, callbackSeq); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerCallbackComplete, "customer", customerSeq, before, map[string]interface{}{
+			"callback_seq": callbackSeq,
+			"caller":       caller,
+			"call_count":   callResult.CallCount,
+		})
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("CompleteCallback error: %v", err)
+		}
+		return nil, err
+	}
+
+	publishCustomerStatusEvent(event)
+	log.Printf("[Callback] CompleteCallback 완료 - CustomerSeq: %d, Seq: %d, Caller: %s, CallCount: %d", customerSeq, callbackSeq, caller, callResult.CallCount)
+	return callResult, nil
+}
@@ -0,0 +1,305 @@
+package database
+
+import (
+	"backoffice/config"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// 같은 전화번호 고객 등록 시 처리 방식 (CUSTOMER_DUPLICATE_POLICY)
+const (
+	DuplicatePolicyFlag   = "flag"   // 새 고객으로 등록하고 duplicate_of에 기존 고객 표시
+	DuplicatePolicyAttach = "attach" // 새로 등록하지 않고 기존 고객에 연결 (유입 정보는 감사 로그에 기록)
+	DuplicatePolicyReject = "reject" // 등록 거부
+)
+
+// DuplicateCustomerError - 중복 정책이 reject일 때 반환되는 오류
+type DuplicateCustomerError struct {
+	ExistingSeq int64 // 같은 전화번호의 기존 고객 seq
+}
+
+func (e *DuplicateCustomerError) Error() string {
+	return fmt.Sprintf("같은 전화번호의 고객이 이미 등록되어 있습니다 (seq=%d)", e.ExistingSeq)
+}
+
+// CustomerInsertResult - 고객 등록 결과
+type CustomerInsertResult struct {
+	Seq         int64 // 등록된 고객 seq (attach 정책이면 연결된 기존 고객 seq)
+	DuplicateOf int64 // 같은 전화번호의 기존 고객 seq (없으면 0)
+	Attached    bool  // 새로 등록하지 않고 기존 고객에 연결됨
+}
+
+// customerInsertFunc - 중복 확인 후 실제 INSERT 실행 (duplicateOf는 기존 고객 seq 또는 nil)
+type customerInsertFunc func(tx *sql.Tx, phoneNormalized string, duplicateOf interface{}) (sql.Result, error)
+
+// GetDuplicatePolicy - 설정된 중복 처리 방식 (알 수 없는 값이면 flag)
+func GetDuplicatePolicy() string {
+	switch policy := config.GetConfig().Customer.DuplicatePolicy; policy {
+	case DuplicatePolicyAttach, DuplicatePolicyReject:
+		return policy
+	default:
+		return DuplicatePolicyFlag
+	}
+}
+
+// registerCustomer - 중복 정책을 적용해 고객 등록 (InsertExternalCustomer/UpsertKakaoCustomer 공용)
+// 파라미터: branchSeq (지점 seq, nil이면 미배정), phoneNumber (입력 전화번호), incoming (attach 시 감사 로그에 남길 유입 정보), insert (INSERT 실행 함수)
+// 반환: 등록 결과, 에러 (reject 정책이면 *DuplicateCustomerError)
+func registerCustomer(branchSeq *int, phoneNumber string, incoming map[string]interface{}, insert customerInsertFunc) (*CustomerInsertResult, error) {
+	var result *CustomerInsertResult
+	err := Transaction(func(tx *sql.Tx) error {
+		var err error
+		result, err = registerCustomerTx(tx, branchSeq, phoneNumber, incoming, insert)
+		return err
+	})
+	if err != nil {
+		if _, ok := err.(*DuplicateCustomerError); !ok {
+			log.Printf("registerCustomer error: %v", err)
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// registerCustomerTx - 트랜잭션 안에서 중복 정책을 적용해 고객 등록 (일괄 가져오기 등 여러 건을 한 트랜잭션으로 처리할 때 사용)
+func registerCustomerTx(tx *sql.Tx, branchSeq *int, phoneNumber string, incoming map[string]interface{}, insert customerInsertFunc) (*CustomerInsertResult, error) {
+	phoneNormalized := utils.NormalizePhoneNumber(phoneNumber)
+	policy := GetDuplicatePolicy()
+	result := &CustomerInsertResult{}
+
+	existingSeq, err := findDuplicateCustomer(tx, phoneNormalized, branchSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingSeq > 0 {
+		result.DuplicateOf = existingSeq
+		log.Printf("[Customer] 중복 전화번호 감지 - 정책: %s, 기존 고객: %d", policy, existingSeq)
+
+		switch policy {
+		case DuplicatePolicyReject:
+			return nil, &DuplicateCustomerError{ExistingSeq: existingSeq}
+		case DuplicatePolicyAttach:
+			result.Seq = existingSeq
+			result.Attached = true
+			if err := recordAudit(tx, AuditActor{}, AuditCustomerDuplicateAttach, "customer", existingSeq, nil, incoming); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+
+	var duplicateOf interface{}
+	if existingSeq > 0 {
+		duplicateOf = existingSeq
+	}
+
+	res, err := insert(tx, phoneNormalized, duplicateOf)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Seq, err = res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// findDuplicateCustomer - 같은 정규화 전화번호의 기존 고객 조회 (행 잠금)
+// 같은 지점 고객 또는 한쪽이 미배정인 고객만 중복으로 보고, 같은 지점 → 최근 등록 순으로 선택
+// 반환: 기존 고객 seq (없으면 0), 에러
+func findDuplicateCustomer(tx *sql.Tx, phoneNormalized string, branchSeq *int) (int64, error) {
+	if phoneNormalized == "" {
+		return 0, nil
+	}
+
+	var branchArg interface{}
+	if branchSeq != nil {
+		branchArg = *branchSeq
+	}
+
+	query := `
+		SELECT seq FROM customers
+		WHERE phone_normalized = ?
+		  AND (? IS NULL OR branch_seq IS NULL OR branch_seq = ?)
+		  AND deleted_at IS NULL
+		ORDER BY (branch_seq <=> ?) DESC, createdDate DESC, seq DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+
+	var seq int64
+	err := tx.QueryRow(query, phoneNormalized, branchArg, branchArg, branchArg).Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		log.Printf("findDuplicateCustomer error: %v", err)
+		return 0, err
+	}
+	return seq, nil
+}
+
+// DuplicateCandidate - 같은 전화번호의 다른 고객 (병합 후보)
+type DuplicateCandidate struct {
+	Seq         int
+	Name        string
+	PhoneNumber string
+	BranchSeq   sql.NullInt64
+	BranchName  string
+	Status      string
+	CallCount   int
+	CreatedDate string
+}
+
+// GetDuplicateCandidates - 고객과 같은 정규화 전화번호를 가진 다른 고객 목록 (병합 후보)
+// 파라미터: customerSeq (기준 고객 seq)
+func GetDuplicateCandidates(customerSeq int) ([]DuplicateCandidate, error) {
+	query := `
+		SELECT c.seq, c.name, c.phone_number, c.branch_seq, COALESCE(b.branchName, ''),
+		       c.status, c.call_count, DATE_FORMAT(c.createdDate, '%Y-%m-%d %H:%i')
+		FROM customers c
+		INNER JOIN customers base ON base.seq = ?
+		LEFT JOIN branches b ON c.branch_seq = b.seq
+		WHERE c.phone_normalized = base.phone_normalized AND c.seq <> base.seq AND c.deleted_at IS NULL
+		ORDER BY c.createdDate ASC, c.seq ASC
+		LIMIT 50
+	`
+
+	rows, err := DB.Query(query, customerSeq)
+	if err != nil {
+		log.Printf("GetDuplicateCandidates error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []DuplicateCandidate
+	for rows.Next() {
+		var c DuplicateCandidate
+		if err := rows.Scan(&c.Seq, &c.Name, &c.PhoneNumber, &c.BranchSeq, &c.BranchName,
+			&c.Status, &c.CallCount, &c.CreatedDate); err != nil {
+			log.Printf("GetDuplicateCandidates scan error: %v", err)
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// MergeCustomers - 중복 고객을 남길 고객으로 병합 (감사 로그 기록)
+// 예약, CALLER 선택 이력, 콜백, 메모, 상태 변경 이력, 태그, 통화 횟수, 카카오 ID를 남길 고객으로 옮긴 뒤 병합된 고객을 휴지통으로 이동
+// 병합된 고객은 merged_into에 남길 고객을 기록하고 다른 휴지통 고객처럼 보관 기간이 지나면 영구 삭제됨
+// 파라미터: actor (작업자), survivorSeq (남길 고객 seq), mergedSeq (병합 후 휴지통으로 이동할 고객 seq)
+// 반환: 에러 (고객이 없으면 sql.ErrNoRows)
+func MergeCustomers(actor AuditActor, survivorSeq, mergedSeq int) error {
+	if survivorSeq == mergedSeq {
+		return fmt.Errorf("같은 고객은 병합할 수 없습니다")
+	}
+
+	err := Transaction(func(tx *sql.Tx) error {
+		// 두 고객을 seq 순서로 잠가 동시 병합 시 교착 방지
+		rows, err := tx.Query(`SELECT seq FROM customers WHERE seq IN (?, ?) AND deleted_at IS NULL ORDER BY seq FOR UPDATE`, survivorSeq, mergedSeq)
+		if err != nil {
+			return err
+		}
+		locked := 0
+		for rows.Next() {
+			locked++
+		}
+		rows.Close()
+		if locked != 2 {
+			return sql.ErrNoRows
+		}
+
+		before, err := auditSnapshot(tx, `
+			SELECT branch_seq, name, phone_number, comment, commercial_name, ad_source,
+			       kakao_id, call_count, status, createdDate
+			FROM customers WHERE seq = ?`, mergedSeq)
+		if err != nil {
+			return err
+		}
+
+		var survivorKakao, mergedKakao sql.NullInt64
+		var mergedCallCount int
+		if err := tx.QueryRow(`SELECT kakao_id FROM customers WHERE seq = ?`, survivorSeq).Scan(&survivorKakao); err != nil {
+			return err
+		}
+		if err := tx.QueryRow(`SELECT kakao_id, call_count FROM customers WHERE seq = ?`, mergedSeq).Scan(&mergedKakao, &mergedCallCount); err != nil {
+			return err
+		}
+
+		moves := []string{
+			`UPDATE reservation_info SET customer_id = ? WHERE customer_id = ?`,
+			`UPDATE caller_selection_history SET customer_id = ? WHERE customer_id = ?`,
+			`UPDATE customer_callbacks SET customer_seq = ? WHERE customer_seq = ?`,
+			`UPDATE customer_notes SET customer_seq = ? WHERE customer_seq = ?`,
+			`UPDATE customer_status_history SET customer_seq = ? WHERE customer_seq = ?`,
+			`UPDATE customers SET duplicate_of = ? WHERE duplicate_of = ?`,
+		}
+		for _, query := range moves {
+			if _, err := tx.Exec(query, survivorSeq, mergedSeq); err != nil {
+				return err
+			}
+		}
+
+		// 태그는 남는 고객 지점의 태그만 옮김 (병합된 고객의 연결은 휴지통 고객에 그대로 남음)
+		if _, err := tx.Exec(`
+			INSERT IGNORE INTO customer_tags (customer_seq, tag_seq, createdDate)
+			SELECT s.seq, ct.tag_seq, ct.createdDate
+			FROM customer_tags ct
+			JOIN customer_tag_defs t ON t.seq = ct.tag_seq
+			JOIN customers s ON s.seq = ? AND s.branch_seq = t.branch_seq
+			WHERE ct.customer_seq = ?
+		`, survivorSeq, mergedSeq); err != nil {
+			return err
+		}
+
+		// 카카오 ID는 UNIQUE이므로 병합된 고객에서 먼저 제거한 뒤 옮김
+		kakaoID := survivorKakao
+		if !kakaoID.Valid && mergedKakao.Valid {
+			kakaoID = mergedKakao
+			if _, err := tx.Exec(`UPDATE customers SET kakao_id = NULL WHERE seq = ?`, mergedSeq); err != nil {
+				return err
+			}
+		}
+
+		update := `
+			UPDATE customers
+			SET call_count = call_count + ?, kakao_id = ?,
+			    duplicate_of = NULLIF(duplicate_of, seq)
+			WHERE seq = ?
+		`
+		if _, err := tx.Exec(update, mergedCallCount, kakaoID, survivorSeq); err != nil {
+			return err
+		}
+
+		// 메모를 옮겼으므로 두 고객의 고정 메모 중 가장 최근 메모를 목록 코멘트로 표시
+		if err := syncCustomerCommentTx(tx, int64(survivorSeq)); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE customers SET deleted_at = NOW(), deleted_by = ?, merged_into = ?
+			WHERE seq = ?
+		`, actorUserSeq(actor), survivorSeq, mergedSeq); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerMerge, "customer", survivorSeq,
+			before, map[string]interface{}{"merged_seq": mergedSeq, "merged_into": survivorSeq, "trash": true})
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("MergeCustomers error: %v", err)
+		}
+		return err
+	}
+
+	log.Printf("[Customer] MergeCustomers 완료 - 남은 고객: %d, 병합된 고객: %d", survivorSeq, mergedSeq)
+	return nil
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"log"
+)
+
+// StreamCustomersByBranch - 지점 고객 목록 전체를 한 건씩 전달 (파일 내보내기용, 페이징 없음)
+// 고객 목록 화면과 같은 필터/검색 조건을 적용하며 결과를 메모리에 모으지 않고 fn으로 바로 전달
+// 파라미터: branchSeq (지점 seq), filter, searchType, searchKeyword, advanced (고객 목록과 동일), fn (고객 한 건 처리, 에러 반환 시 중단)
+// 반환: 전달한 고객 수, 에러
+func StreamCustomersByBranch(branchSeq int, filter, searchType, searchKeyword string, advanced CustomerSearchFilter, fn func(CustomerInfo) error) (int, error) {
+	if branchSeq == 0 {
+		log.Printf("StreamCustomersByBranch - branchSeq is 0")
+		return 0, nil
+	}
+
+	query := `
+		SELECT
+			seq,
+			name,
+			phone_number,
+			comment,
+			commercial_name,
+			ad_source,
+			call_count,
+			status,
+			DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i') as createdDate,
+			DATE_FORMAT(lastUpdateDate, '%Y-%m-%d %H:%i') as lastUpdateDate,
+			duplicate_of
+		FROM customers
+		WHERE branch_seq = ? AND deleted_at IS NULL
+	`
+	args := []interface{}{branchSeq}
+
+	// 공통 필터 조건 추가
+	whereClause, filterArgs := buildCustomerFilterConditions(branchSeq, filter, searchType, searchKeyword, advanced)
+	query += whereClause
+	args = append(args, filterArgs...)
+
+	query += ` ORDER BY createdDate DESC, seq DESC`
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("StreamCustomersByBranch - query error: %v", err)
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var customer CustomerInfo
+		err := rows.Scan(
+			&customer.Seq,
+			&customer.Name,
+			&customer.PhoneNumber,
+			&customer.Comment,
+			&customer.CommercialName,
+			&customer.AdSource,
+			&customer.CallCount,
+			&customer.Status,
+			&customer.CreatedDate,
+			&customer.LastUpdateDate,
+			&customer.DuplicateOf,
+		)
+		if err != nil {
+			log.Printf("StreamCustomersByBranch - scan error: %v", err)
+			return count, err
+		}
+
+		if err := fn(customer); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Printf("StreamCustomersByBranch - rows error: %v", err)
+		return count, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// duplicateLookupChunkSize - 중복 전화번호 조회 시 IN 절 최대 개수
+const duplicateLookupChunkSize = 500
+
+// CustomerImportRow - 일괄 가져오기 고객 한 건
+type CustomerImportRow struct {
+	Line           int // 파일의 행 번호 (오류 메시지용)
+	Name           string
+	PhoneNumber    string
+	Comment        string
+	CommercialName string
+	AdSource       string
+}
+
+// CustomerImportResult - 일괄 가져오기 결과
+type CustomerImportResult struct {
+	Total    int // 가져오기 시도 건수
+	Created  int // 새로 등록된 고객 (중복 표시 포함)
+	Flagged  int // 새로 등록되었지만 중복 의심으로 표시된 고객
+	Attached int // 기존 고객에 연결되어 새로 등록되지 않은 건수
+	Rejected int // 중복으로 등록 거부된 건수
+}
+
+// FindDuplicatePhones - 지점에 이미 등록된 정규화 전화번호 조회 (가져오기 사전 검증용)
+// 같은 지점 또는 미배정 고객만 중복으로 보며, 고객 등록 시 중복 판단 기준과 같음
+// 파라미터: branchSeq (지점 seq), phonesNormalized (정규화 전화번호 목록)
+// 반환: 정규화 전화번호 → 기존 고객 seq (가장 최근 등록 고객)
+func FindDuplicatePhones(branchSeq int, phonesNormalized []string) (map[string]int64, error) {
+	existing := map[string]int64{}
+
+	for start := 0; start < len(phonesNormalized); start += duplicateLookupChunkSize {
+		end := start + duplicateLookupChunkSize
+		if end > len(phonesNormalized) {
+			end = len(phonesNormalized)
+		}
+
+		placeholders, args := inPlaceholders(phonesNormalized[start:end])
+		query := `
+			SELECT phone_normalized, MAX(seq)
+			FROM customers
+			WHERE phone_normalized IN (` + placeholders + `)
+			  AND (branch_seq = ? OR branch_seq IS NULL)
+			  AND deleted_at IS NULL
+			GROUP BY phone_normalized
+		`
+		args = append(args, branchSeq)
+
+		rows, err := DB.Query(query, args...)
+		if err != nil {
+			log.Printf("FindDuplicatePhones error: %v", err)
+			return nil, err
+		}
+
+		for rows.Next() {
+			var phone string
+			var seq int64
+			if err := rows.Scan(&phone, &seq); err != nil {
+				rows.Close()
+				log.Printf("FindDuplicatePhones scan error: %v", err)
+				return nil, err
+			}
+			existing[phone] = seq
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			log.Printf("FindDuplicatePhones rows error: %v", err)
+			return nil, err
+		}
+	}
+
+	return existing, nil
+}
+
+// ImportCustomers - 고객 일괄 가져오기 (하나의 트랜잭션, 감사 로그 기록)
+// 각 행은 CreateCustomer와 같은 방식으로 등록되며 CUSTOMER_DUPLICATE_POLICY가 reject면 중복 행은 건너뜀
+// 파라미터: actor (작업자), branchSeq (지점 seq), fileName (업로드 파일명), rows (검증을 통과한 행)
+// 반환: 가져오기 결과, 에러 (에러 시 전체 롤백)
+func ImportCustomers(actor AuditActor, branchSeq int, fileName string, rows []CustomerImportRow) (*CustomerImportResult, error) {
+	result := &CustomerImportResult{Total: len(rows)}
+
+	err := Transaction(func(tx *sql.Tx) error {
+		for _, row := range rows {
+			res, err := createCustomerTx(tx, &branchSeq, row.Name, row.PhoneNumber, row.Comment, row.CommercialName, row.AdSource)
+			if _, ok := err.(*DuplicateCustomerError); ok {
+				result.Rejected++
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("%d행 등록 실패: %w", row.Line, err)
+			}
+
+			switch {
+			case res.Attached:
+				result.Attached++
+			case res.DuplicateOf > 0:
+				result.Created++
+				result.Flagged++
+			default:
+				result.Created++
+			}
+		}
+
+		return recordAudit(tx, actor, AuditCustomerImport, "branch", branchSeq, nil, map[string]interface{}{
+			"file_name": fileName,
+			"total":     result.Total,
+			"created":   result.Created,
+			"flagged":   result.Flagged,
+			"attached":  result.Attached,
+			"rejected":  result.Rejected,
+		})
+	})
+	if err != nil {
+		log.Printf("ImportCustomers error: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[Customer] ImportCustomers 완료 - BranchSeq: %d, File: %s, 등록: %d, 연결: %d, 거부: %d",
+		branchSeq, fileName, result.Created, result.Attached, result.Rejected)
+	return result, nil
+}
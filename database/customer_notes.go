@@ -0,0 +1,256 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+)
+
+// customerCommentMaxLength - customers.comment 길이 (고정 메모를 목록 코멘트로 표시할 때 이 길이로 자름)
+const customerCommentMaxLength = 200
+
+// CustomerNote - 고객 메모 (customer_notes)
+type CustomerNote struct {
+	Seq         int
+	CustomerSeq int
+	Content     string
+	Pinned      bool
+	UserID      string // 작성자 아이디 (이관/외부 유입 메모는 빈 문자열)
+	CreatedDate string
+	UpdatedDate string                 // 마지막 수정 일시 (수정하지 않았으면 빈 문자열)
+	Revisions   []CustomerNoteRevision // 수정 이력 (최근 수정순)
+}
+
+// CustomerNoteRevision - 고객 메모 수정 이력 (수정 전 내용)
+type CustomerNoteRevision struct {
+	Content     string
+	UserID      string // 수정한 사용자 아이디
+	CreatedDate string // 수정 일시
+}
+
+// GetCustomerNotes - 고객 메모 목록 (고정 메모 → 최근 작성순, 수정 이력 포함)
+// 파라미터: customerSeq (고객 seq)
+func GetCustomerNotes(customerSeq int) ([]CustomerNote, error) {
+	rows, err := DB.Query(`
+		SELECT seq, customer_seq, content, is_pinned, user_id,
+		       DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i'),
+		       COALESCE(DATE_FORMAT(updatedDate, '%Y-%m-%d %H:%i'), '')
+		FROM customer_notes
+		WHERE customer_seq = ?
+		ORDER BY is_pinned DESC, createdDate DESC, seq DESC
+	`, customerSeq)
+	if err != nil {
+		log.Printf("GetCustomerNotes error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []CustomerNote
+	index := map[int]int{}
+	for rows.Next() {
+		var n CustomerNote
+		if err := rows.Scan(&n.Seq, &n.CustomerSeq, &n.Content, &n.Pinned, &n.UserID, &n.CreatedDate, &n.UpdatedDate); err != nil {
+			log.Printf("GetCustomerNotes scan error: %v", err)
+			return nil, err
+		}
+		index[n.Seq] = len(notes)
+		notes = append(notes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	revisionRows, err := DB.Query(`
+		SELECT r.note_seq, r.content, r.user_id, DATE_FORMAT(r.createdDate, '%Y-%m-%d %H:%i')
+		FROM customer_note_revisions r
+		JOIN customer_notes n ON n.seq = r.note_seq
+		WHERE n.customer_seq = ?
+		ORDER BY r.seq DESC
+	`, customerSeq)
+	if err != nil {
+		log.Printf("GetCustomerNotes revisions error: %v", err)
+		return nil, err
+	}
+	defer revisionRows.Close()
+
+	for revisionRows.Next() {
+		var noteSeq int
+		var rev CustomerNoteRevision
+		if err := revisionRows.Scan(&noteSeq, &rev.Content, &rev.UserID, &rev.CreatedDate); err != nil {
+			log.Printf("GetCustomerNotes revisions scan error: %v", err)
+			return nil, err
+		}
+		if i, ok := index[noteSeq]; ok {
+			notes[i].Revisions = append(notes[i].Revisions, rev)
+		}
+	}
+	return notes, revisionRows.Err()
+}
+
+// insertCustomerNoteTx - 트랜잭션 안에서 고객 메모 추가 (고정 메모면 목록 코멘트 갱신)
+func insertCustomerNoteTx(tx *sql.Tx, actor AuditActor, customerSeq int64, content string, pinned bool) (int64, error) {
+	result, err := tx.Exec(`
+		INSERT INTO customer_notes (customer_seq, content, is_pinned, user_seq, user_id, createdDate)
+		VALUES (?, ?, ?, ?, ?, NOW())
+	`, customerSeq, content, pinned, actorUserSeq(actor), actor.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	noteSeq, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if pinned {
+		if err := syncCustomerCommentTx(tx, customerSeq); err != nil {
+			return 0, err
+		}
+	}
+	return noteSeq, nil
+}
+
+// syncCustomerCommentTx - 고정 메모 중 가장 최근 메모를 목록 코멘트(customers.comment)로 저장 (고정 메모가 없으면 NULL)
+func syncCustomerCommentTx(tx *sql.Tx, customerSeq int64) error {
+	_, err := tx.Exec(`
+		UPDATE customers
+		SET comment = (
+			SELECT LEFT(n.content, ?) FROM customer_notes n
+			WHERE n.customer_seq = ? AND n.is_pinned = 1
+			ORDER BY n.createdDate DESC, n.seq DESC
+			LIMIT 1
+		)
+		WHERE seq = ?
+	`, customerCommentMaxLength, customerSeq, customerSeq)
+	return err
+}
+
+// AddCustomerNote - 고객 메모 추가 (감사 로그 기록)
+// 파라미터: actor (작성자), customerSeq (고객 seq), content (메모 내용), pinned (고정 여부)
+// 반환: 추가된 메모 seq, 에러 (고객이 없거나 휴지통에 있으면 sql.ErrNoRows)
+func AddCustomerNote(actor AuditActor, customerSeq int, content string, pinned bool) (int64, error) {
+	var noteSeq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		if _, err := lockCustomerStatus(tx, customerSeq); err != nil {
+			return err
+		}
+
+		var err error
+		noteSeq, err = insertCustomerNoteTx(tx, actor, int64(customerSeq), content, pinned)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerNoteAdd, "customer", customerSeq, nil, map[string]interface{}{
+			"note_seq": noteSeq,
+			"content":  content,
+			"pinned":   pinned,
+		})
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("AddCustomerNote error: %v", err)
+		}
+		return 0, err
+	}
+
+	log.Printf("[CustomerNote] AddCustomerNote 완료 - CustomerSeq: %d, NoteSeq: %d, 고정: %t", customerSeq, noteSeq, pinned)
+	return noteSeq, nil
+}
+
+// lockCustomerNoteTx - 변경 전 고객 메모 조회 (행 잠금, 휴지통 고객의 메모는 제외)
+// 반환: 현재 내용, 고정 여부, 에러 (메모가 없으면 sql.ErrNoRows)
+func lockCustomerNoteTx(tx *sql.Tx, customerSeq, noteSeq int) (string, bool, error) {
+	var content string
+	var pinned bool
+	err := tx.QueryRow(`
+		SELECT n.content, n.is_pinned
+		FROM customer_notes n
+		JOIN customers c ON c.seq = n.customer_seq AND c.deleted_at IS NULL
+		WHERE n.seq = ? AND n.customer_seq = ?
+		FOR UPDATE
+	`, noteSeq, customerSeq).Scan(&content, &pinned)
+	return content, pinned, err
+}
+
+// UpdateCustomerNote - 고객 메모 수정 (수정 전 내용을 수정 이력에 보관, 감사 로그 기록)
+// 파라미터: actor (수정한 사용자), customerSeq (고객 seq), noteSeq (메모 seq), content (새 내용)
+// 반환: 수정된 행 수 (내용이 같으면 0), 에러 (메모가 없으면 sql.ErrNoRows)
+func UpdateCustomerNote(actor AuditActor, customerSeq, noteSeq int, content string) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, pinned, err := lockCustomerNoteTx(tx, customerSeq, noteSeq)
+		if err != nil || before == content {
+			return err
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO customer_note_revisions (note_seq, content, user_seq, user_id, createdDate)
+			VALUES (?, ?, ?, ?, NOW())
+		`, noteSeq, before, actorUserSeq(actor), actor.UserID); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(`UPDATE customer_notes SET content = ?, updatedDate = NOW() WHERE seq = ?`, content, noteSeq)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if pinned {
+			if err := syncCustomerCommentTx(tx, int64(customerSeq)); err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(tx, actor, AuditCustomerNoteUpdate, "customer", customerSeq,
+			map[string]interface{}{"note_seq": noteSeq, "content": before},
+			map[string]interface{}{"note_seq": noteSeq, "content": content})
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("UpdateCustomerNote error: %v", err)
+		}
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// SetCustomerNotePinned - 고객 메모 고정/고정 해제 (목록 코멘트 갱신, 감사 로그 기록)
+// 파라미터: actor (작업자), customerSeq (고객 seq), noteSeq (메모 seq), pinned (고정 여부)
+// 반환: 변경된 행 수 (이미 같은 상태면 0), 에러 (메모가 없으면 sql.ErrNoRows)
+func SetCustomerNotePinned(actor AuditActor, customerSeq, noteSeq int, pinned bool) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		content, before, err := lockCustomerNoteTx(tx, customerSeq, noteSeq)
+		if err != nil || before == pinned {
+			return err
+		}
+
+		result, err := tx.Exec(`UPDATE customer_notes SET is_pinned = ? WHERE seq = ?`, pinned, noteSeq)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if err := syncCustomerCommentTx(tx, int64(customerSeq)); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerNotePin, "customer", customerSeq,
+			map[string]interface{}{"note_seq": noteSeq, "pinned": before},
+			map[string]interface{}{"note_seq": noteSeq, "pinned": pinned, "content": content})
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("SetCustomerNotePinned error: %v", err)
+		}
+		return 0, err
+	}
+	return rowsAffected, nil
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"log"
+)
+
+// CustomerSearchFilter - 고객 목록 복합 검색 조건 (비어 있는 조건은 적용하지 않음)
+// 날짜는 YYYY-MM-DD, 기간의 끝 날짜는 해당 일자 전체를 포함
+type CustomerSearchFilter struct {
+	Statuses        []string // 상태 (여러 개 선택, 선택 시 처리중/전체 필터보다 우선)
+	AdSources       []string // 광고 출처 (여러 개 선택)
+	CommercialNames []string // 광고명 (여러 개 선택)
+	CreatedFrom     string   // 등록일 시작
+	CreatedTo       string   // 등록일 끝
+	ContactFrom     string   // 최근 연락일 시작
+	ContactTo       string   // 최근 연락일 끝
+	ReservationFrom string   // 상담 예약일 시작
+	ReservationTo   string   // 상담 예약일 끝
+	CallCountMin    *int     // 통화 횟수 최소
+	CallCountMax    *int     // 통화 횟수 최대
+	Caller          string   // 통화 CALLER (CALLER 선택 이력 기준)
+	TagSeqs         []int    // 태그 (여러 개 선택 시 하나라도 붙은 고객)
+}
+
+// buildAdvancedFilterConditions - 복합 검색 조건을 WHERE 조건으로 변환 (buildCustomerFilterConditions에서 사용)
+func buildAdvancedFilterConditions(branchSeq int, f CustomerSearchFilter) (string, []interface{}) {
+	whereClause := ""
+	args := []interface{}{}
+
+	if len(f.Statuses) > 0 {
+		placeholders, values := inPlaceholders(f.Statuses)
+		whereClause += ` AND status IN (` + placeholders + `)`
+		args = append(args, values...)
+	}
+	if len(f.AdSources) > 0 {
+		placeholders, values := inPlaceholders(f.AdSources)
+		whereClause += ` AND ad_source IN (` + placeholders + `)`
+		args = append(args, values...)
+	}
+	if len(f.CommercialNames) > 0 {
+		placeholders, values := inPlaceholders(f.CommercialNames)
+		whereClause += ` AND commercial_name IN (` + placeholders + `)`
+		args = append(args, values...)
+	}
+
+	if f.CreatedFrom != "" {
+		whereClause += ` AND createdDate >= ?`
+		args = append(args, f.CreatedFrom)
+	}
+	if f.CreatedTo != "" {
+		whereClause += ` AND createdDate < DATE_ADD(?, INTERVAL 1 DAY)`
+		args = append(args, f.CreatedTo)
+	}
+	if f.ContactFrom != "" {
+		whereClause += ` AND lastUpdateDate >= ?`
+		args = append(args, f.ContactFrom)
+	}
+	if f.ContactTo != "" {
+		whereClause += ` AND lastUpdateDate < DATE_ADD(?, INTERVAL 1 DAY)`
+		args = append(args, f.ContactTo)
+	}
+
+	if f.ReservationFrom != "" || f.ReservationTo != "" {
+		subquery := `SELECT customer_id FROM reservation_info WHERE branch_seq = ? AND customer_id IS NOT NULL`
+		args = append(args, branchSeq)
+		if f.ReservationFrom != "" {
+			subquery += ` AND interview_date >= ?`
+			args = append(args, f.ReservationFrom)
+		}
+		if f.ReservationTo != "" {
+			subquery += ` AND interview_date < DATE_ADD(?, INTERVAL 1 DAY)`
+			args = append(args, f.ReservationTo)
+		}
+		whereClause += ` AND seq IN (` + subquery + `)`
+	}
+
+	if f.CallCountMin != nil {
+		whereClause += ` AND call_count >= ?`
+		args = append(args, *f.CallCountMin)
+	}
+	if f.CallCountMax != nil {
+		whereClause += ` AND call_count <= ?`
+		args = append(args, *f.CallCountMax)
+	}
+
+	if f.Caller != "" {
+		whereClause += ` AND seq IN (SELECT customer_id FROM caller_selection_history WHERE branch_seq = ? AND caller = ?)`
+		args = append(args, branchSeq, f.Caller)
+	}
+
+	if len(f.TagSeqs) > 0 {
+		placeholders, values := inPlaceholders(f.TagSeqs)
+		whereClause += ` AND seq IN (SELECT customer_seq FROM customer_tags WHERE tag_seq IN (` + placeholders + `))`
+		args = append(args, values...)
+	}
+
+	return whereClause, args
+}
+
+// GetCustomerFilterOptions - 복합 검색 선택지 조회 (지점 고객의 광고 출처/광고명 목록)
+// 파라미터: branchSeq (지점 seq)
+// 반환: 광고 출처 목록, 광고명 목록, 에러
+func GetCustomerFilterOptions(branchSeq int) ([]string, []string, error) {
+	adSources, err := distinctCustomerValues(branchSeq, "ad_source")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commercialNames, err := distinctCustomerValues(branchSeq, "commercial_name")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return adSources, commercialNames, nil
+}
+
+// distinctCustomerValues - 지점 고객의 컬럼 값 목록 (빈 값 제외, 가나다순, 최대 200개)
+// column은 호출부에서 고정된 컬럼명만 전달
+func distinctCustomerValues(branchSeq int, column string) ([]string, error) {
+	query := `
+		SELECT DISTINCT ` + column + `
+		FROM customers
+		WHERE branch_seq = ? AND deleted_at IS NULL AND ` + column + ` IS NOT NULL AND ` + column + ` <> ''
+		ORDER BY ` + column + `
+		LIMIT 200
+	`
+
+	rows, err := DB.Query(query, branchSeq)
+	if err != nil {
+		log.Printf("distinctCustomerValues(%s) error: %v", column, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			log.Printf("distinctCustomerValues(%s) scan error: %v", column, err)
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
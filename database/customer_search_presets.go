@@ -0,0 +1,78 @@
+package database
+
+import (
+	"log"
+)
+
+// MaxCustomerSearchPresets - 사용자별 저장 가능한 검색 프리셋 수
+const MaxCustomerSearchPresets = 20
+
+// CustomerSearchPreset - 저장된 고객 검색 조건
+type CustomerSearchPreset struct {
+	Seq         int
+	Name        string
+	QueryString string // 고객 목록 검색 파라미터 (URL 쿼리 문자열)
+	CreatedDate string
+}
+
+// GetCustomerSearchPresets - 사용자의 검색 프리셋 목록 (이름순)
+// 파라미터: userSeq (사용자 seq)
+func GetCustomerSearchPresets(userSeq int) ([]CustomerSearchPreset, error) {
+	query := `
+		SELECT seq, name, query_string, DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i')
+		FROM customer_search_presets
+		WHERE user_seq = ?
+		ORDER BY name
+	`
+
+	rows, err := DB.Query(query, userSeq)
+	if err != nil {
+		log.Printf("GetCustomerSearchPresets error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presets []CustomerSearchPreset
+	for rows.Next() {
+		var p CustomerSearchPreset
+		if err := rows.Scan(&p.Seq, &p.Name, &p.QueryString, &p.CreatedDate); err != nil {
+			log.Printf("GetCustomerSearchPresets scan error: %v", err)
+			return nil, err
+		}
+		presets = append(presets, p)
+	}
+
+	return presets, rows.Err()
+}
+
+// SaveCustomerSearchPreset - 검색 프리셋 저장 (같은 이름이 있으면 조건을 덮어씀)
+// 파라미터: userSeq (사용자 seq), name (프리셋 이름), queryString (검색 파라미터)
+// 반환: 새로 추가되었는지 여부, 에러
+func SaveCustomerSearchPreset(userSeq int, name, queryString string) (bool, error) {
+	query := `
+		INSERT INTO customer_search_presets (user_seq, name, query_string)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE query_string = VALUES(query_string), createdDate = NOW()
+	`
+
+	result, err := DB.Exec(query, userSeq, name, queryString)
+	if err != nil {
+		log.Printf("SaveCustomerSearchPreset error: %v", err)
+		return false, err
+	}
+
+	// ON DUPLICATE KEY UPDATE: 추가 1, 수정 2
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected == 1, nil
+}
+
+// CountCustomerSearchPresets - 사용자의 검색 프리셋 수
+func CountCustomerSearchPresets(userSeq int) (int, error) {
+	return Count(`SELECT COUNT(*) FROM customer_search_presets WHERE user_seq = ?`, userSeq)
+}
+
+// DeleteCustomerSearchPreset - 검색 프리셋 삭제 (본인 프리셋만)
+// 반환: 삭제된 행 수, 에러
+func DeleteCustomerSearchPreset(userSeq, presetSeq int) (int64, error) {
+	return Update(`DELETE FROM customer_search_presets WHERE seq = ? AND user_seq = ?`, presetSeq, userSeq)
+}
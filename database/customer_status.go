@@ -0,0 +1,348 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// 고객 상태
+const (
+	CustomerStatusNew          = "신규"
+	CustomerStatusInProgress   = "진행중"
+	CustomerStatusReserved     = "예약확정"
+	CustomerStatusRejected     = "전화상거절"
+	CustomerStatusCallExceeded = "콜수초과"
+)
+
+// DefaultCallLimit - 지점 통화 횟수 제한 기본값 (branches.call_limit 기본값과 같음)
+const DefaultCallLimit = 5
+
+// customerStatusTransitions - 기본 상태 사이에 허용되는 상태 변경 (현재 상태 → 변경 가능한 상태)
+// 같은 상태로의 변경은 변경 없음으로 처리되며 여기에 없는 변경은 InvalidStatusTransitionError
+// 추가한 상태가 포함된 변경은 CanTransitionCustomerStatus의 진행/종료 상태 규칙을 따름
+var customerStatusTransitions = map[string][]string{
+	CustomerStatusNew:          {CustomerStatusInProgress, CustomerStatusReserved, CustomerStatusRejected, CustomerStatusCallExceeded},
+	CustomerStatusInProgress:   {CustomerStatusReserved, CustomerStatusRejected, CustomerStatusCallExceeded},
+	CustomerStatusCallExceeded: {CustomerStatusReserved, CustomerStatusRejected},
+	CustomerStatusRejected:     {CustomerStatusInProgress, CustomerStatusReserved},
+	CustomerStatusReserved:     {CustomerStatusInProgress, CustomerStatusRejected},
+}
+
+// CustomerStatusDef - 고객 상태 정의 (customer_statuses)
+type CustomerStatusDef struct {
+	Seq        int
+	Name       string
+	Color      string // #RRGGBB
+	IsActive   bool   // '처리중' 목록에 표시
+	IsTerminal bool   // 처리가 끝난 상태
+	IsSystem   bool   // 기본 상태 (삭제/이름 변경 불가)
+	SortOrder  int
+}
+
+// CanTransitionCustomerStatus - 상태 변경 허용 여부 (같은 상태면 true)
+// - '신규'로는 되돌리기 외에 변경할 수 없음
+// - 기본 상태끼리는 customerStatusTransitions를 따름
+// - 추가한 상태가 포함되면: 종료 상태에서는 진행 중(is_active) 상태로만, 그 외 상태에서는 어느 상태로든 변경 가능
+func CanTransitionCustomerStatus(from, to CustomerStatusDef) bool {
+	if from.Name == to.Name {
+		return true
+	}
+	if to.Name == CustomerStatusNew {
+		return false
+	}
+	if from.IsSystem && to.IsSystem {
+		for _, next := range customerStatusTransitions[from.Name] {
+			if next == to.Name {
+				return true
+			}
+		}
+		return false
+	}
+	if from.IsTerminal {
+		return to.IsActive
+	}
+	return true
+}
+
+// InvalidStatusTransitionError - 허용되지 않은 상태 변경
+type InvalidStatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("'%s' 상태에서 '%s' 상태로 변경할 수 없습니다", e.From, e.To)
+}
+
+// StatusRevertError - 상태 되돌리기를 할 수 없는 경우
+type StatusRevertError struct {
+	Message string
+}
+
+func (e *StatusRevertError) Error() string {
+	return e.Message
+}
+
+// CustomerStatusHistory - 고객 상태 변경 이력
+type CustomerStatusHistory struct {
+	Seq         int64
+	CustomerSeq int
+	FromStatus  string
+	ToStatus    string
+	Reason      string
+	RevertedSeq *int64 // 되돌리기로 생긴 이력이면 되돌린 이력 seq
+	UserID      string // 작업자 아이디 (외부 유입 등 작업자가 없으면 빈 문자열)
+	CreatedDate string
+}
+
+// CustomerStatusEvent - 고객 상태 변경 이벤트 (트랜잭션 커밋 후 전달)
+type CustomerStatusEvent struct {
+	CustomerSeq int
+	HistorySeq  int64
+	FromStatus  string
+	ToStatus    string
+	Reason      string
+	Reverted    bool // 되돌리기로 인한 변경
+	Actor       AuditActor
+	OccurredAt  time.Time
+}
+
+// CustomerStatusListener - 고객 상태 변경 이벤트 처리 함수
+type CustomerStatusListener func(CustomerStatusEvent)
+
+var (
+	statusListenersMu sync.RWMutex
+	statusListeners   []CustomerStatusListener
+)
+
+// OnCustomerStatusChange - 고객 상태 변경 이벤트 처리 함수 등록 (서버 시작 시 등록)
+// 처리 함수는 상태 변경이 커밋된 뒤 별도 고루틴에서 호출되며, 실패해도 상태 변경에 영향을 주지 않음
+func OnCustomerStatusChange(listener CustomerStatusListener) {
+	statusListenersMu.Lock()
+	defer statusListenersMu.Unlock()
+	statusListeners = append(statusListeners, listener)
+}
+
+// publishCustomerStatusEvent - 등록된 처리 함수에 상태 변경 이벤트 전달 (event가 nil이면 무시)
+func publishCustomerStatusEvent(event *CustomerStatusEvent) {
+	if event == nil {
+		return
+	}
+
+	statusListenersMu.RLock()
+	listeners := append([]CustomerStatusListener(nil), statusListeners...)
+	statusListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		go func(listener CustomerStatusListener) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Printf("[Customer] 상태 변경 이벤트 처리 중 panic - CustomerSeq: %d, %v", event.CustomerSeq, rec)
+				}
+			}()
+			listener(*event)
+		}(listener)
+	}
+}
+
+// changeCustomerStatusTx - 상태 변경 (허용 여부 확인 → 상태 업데이트 → 이력/감사 로그 기록)
+// 파라미터: from (lockCustomerStatus로 잠근 현재 상태), to (변경할 상태), reason (변경 사유)
+// 반환: 상태 변경 이벤트 (같은 상태면 nil, 커밋 후 publishCustomerStatusEvent로 전달), 에러
+func changeCustomerStatusTx(tx *sql.Tx, actor AuditActor, customerSeq int, from, to, reason string) (*CustomerStatusEvent, error) {
+	if from == to {
+		return nil, nil
+	}
+
+	defs, err := customerStatusDefsTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	toDef, ok := defs[to]
+	if !ok {
+		return nil, fmt.Errorf("등록되지 않은 고객 상태입니다: %s", to)
+	}
+	if !CanTransitionCustomerStatus(lookupCustomerStatusDef(defs, from), toDef) {
+		return nil, &InvalidStatusTransitionError{From: from, To: to}
+	}
+	return applyCustomerStatusTx(tx, actor, customerSeq, from, to, reason, nil)
+}
+
+// applyCustomerStatusTx - 상태 업데이트 후 이력과 감사 로그 기록 (허용 여부는 호출하는 쪽에서 확인)
+func applyCustomerStatusTx(tx *sql.Tx, actor AuditActor, customerSeq int, from, to, reason string, revertedSeq *int64) (*CustomerStatusEvent, error) {
+	if _, err := tx.Exec(`UPDATE customers SET status = ? WHERE seq = ?`, to, customerSeq); err != nil {
+		log.Printf("applyCustomerStatusTx - update error: %v", err)
+		return nil, err
+	}
+
+	var userSeq interface{}
+	if actor.UserSeq > 0 {
+		userSeq = actor.UserSeq
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO customer_status_history
+			(customer_seq, from_status, to_status, reason, reverted_seq, user_seq, user_id, createdDate)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW())
+	`, customerSeq, from, to, reason, revertedSeq, userSeq, actor.UserID)
+	if err != nil {
+		log.Printf("applyCustomerStatusTx - insert history error: %v", err)
+		return nil, err
+	}
+
+	historySeq, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	action := AuditCustomerStatusChange
+	after := map[string]interface{}{"status": to, "reason": reason}
+	if revertedSeq != nil {
+		action = AuditCustomerStatusRevert
+		after["reverted_seq"] = *revertedSeq
+	}
+	if err := recordAudit(tx, actor, action, "customer", customerSeq, map[string]interface{}{"status": from}, after); err != nil {
+		return nil, err
+	}
+
+	return &CustomerStatusEvent{
+		CustomerSeq: customerSeq,
+		HistorySeq:  historySeq,
+		FromStatus:  from,
+		ToStatus:    to,
+		Reason:      reason,
+		Reverted:    revertedSeq != nil,
+		Actor:       actor,
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+// statusAfterCall - 통화 후 상태 (통화 횟수 제한에 도달하면 '콜수초과', 신규면 '진행중', 그 외에는 유지)
+// 허용되지 않은 변경은 하지 않음 (예: 예약확정 고객은 통화 횟수가 초과해도 예약확정 유지)
+// 파라미터: callLimit (지점 통화 횟수 제한, 0이면 제한 없음)
+func statusAfterCall(defs map[string]CustomerStatusDef, current string, callCount, callLimit int) string {
+	currentDef := lookupCustomerStatusDef(defs, current)
+	if exceeded, ok := defs[CustomerStatusCallExceeded]; ok && callLimit > 0 && callCount >= callLimit &&
+		CanTransitionCustomerStatus(currentDef, exceeded) {
+		return CustomerStatusCallExceeded
+	}
+	if current == CustomerStatusNew {
+		return CustomerStatusInProgress
+	}
+	return current
+}
+
+// lookupCustomerStatusDef - 상태 정의 조회 (목록에 없는 상태는 진행/종료 구분 없는 추가 상태로 취급)
+func lookupCustomerStatusDef(defs map[string]CustomerStatusDef, name string) CustomerStatusDef {
+	if def, ok := defs[name]; ok {
+		return def
+	}
+	return CustomerStatusDef{Name: name}
+}
+
+// customerCallLimitTx - 고객 소속 지점의 통화 횟수 제한 (미배정 고객은 통화 처리한 지점 기준)
+func customerCallLimitTx(tx *sql.Tx, customerSeq, branchSeq int) (int, error) {
+	var limit int
+	err := tx.QueryRow(`
+		SELECT COALESCE(b.call_limit, ?)
+		FROM customers c
+		LEFT JOIN branches b ON b.seq = COALESCE(c.branch_seq, ?)
+		WHERE c.seq = ?
+	`, DefaultCallLimit, branchSeq, customerSeq).Scan(&limit)
+	if err != nil {
+		log.Printf("customerCallLimitTx error: %v", err)
+		return 0, err
+	}
+	return limit, nil
+}
+
+// GetCustomerStatusHistory - 고객 상태 변경 이력 (최신순)
+func GetCustomerStatusHistory(customerSeq int) ([]CustomerStatusHistory, error) {
+	query := `
+		SELECT seq, customer_seq, from_status, to_status, reason, reverted_seq, user_id,
+		       DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i')
+		FROM customer_status_history
+		WHERE customer_seq = ?
+		ORDER BY seq DESC
+	`
+	rows, err := DB.Query(query, customerSeq)
+	if err != nil {
+		log.Printf("GetCustomerStatusHistory error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []CustomerStatusHistory
+	for rows.Next() {
+		var h CustomerStatusHistory
+		var revertedSeq sql.NullInt64
+		if err := rows.Scan(&h.Seq, &h.CustomerSeq, &h.FromStatus, &h.ToStatus, &h.Reason, &revertedSeq, &h.UserID, &h.CreatedDate); err != nil {
+			log.Printf("GetCustomerStatusHistory scan error: %v", err)
+			return nil, err
+		}
+		if revertedSeq.Valid {
+			h.RevertedSeq = &revertedSeq.Int64
+		}
+		history = append(history, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("GetCustomerStatusHistory rows error: %v", err)
+		return nil, err
+	}
+	return history, nil
+}
+
+// RevertCustomerStatus - 실수로 바뀐 고객 상태를 이전 상태로 되돌리기
+// 가장 최근 상태 변경만 되돌릴 수 있으며, 되돌리기 자체는 다시 되돌릴 수 없음 (상태 변경 규칙과 무관하게 이전 상태로 복원)
+// 파라미터: actor (작업자), customerSeq (고객 seq), historySeq (되돌릴 이력 seq), reason (되돌리는 사유)
+// 반환: 되돌린 후 상태, 에러 (되돌릴 수 없으면 *StatusRevertError)
+func RevertCustomerStatus(actor AuditActor, customerSeq int, historySeq int64, reason string) (string, error) {
+	var event *CustomerStatusEvent
+	err := Transaction(func(tx *sql.Tx) error {
+		current, err := lockCustomerStatus(tx, customerSeq)
+		if err != nil {
+			return err
+		}
+
+		var latestSeq int64
+		var fromStatus, toStatus string
+		var revertedSeq sql.NullInt64
+		err = tx.QueryRow(`
+			SELECT seq, from_status, to_status, reverted_seq
+			FROM customer_status_history
+			WHERE customer_seq = ?
+			ORDER BY seq DESC
+			LIMIT 1
+		`, customerSeq).Scan(&latestSeq, &fromStatus, &toStatus, &revertedSeq)
+		if err == sql.ErrNoRows {
+			return &StatusRevertError{Message: "되돌릴 상태 변경 이력이 없습니다"}
+		}
+		if err != nil {
+			return err
+		}
+
+		if latestSeq != historySeq {
+			return &StatusRevertError{Message: "가장 최근 상태 변경만 되돌릴 수 있습니다"}
+		}
+		if revertedSeq.Valid {
+			return &StatusRevertError{Message: "이미 되돌리기로 변경된 상태입니다"}
+		}
+		if current != toStatus {
+			return &StatusRevertError{Message: "현재 상태가 이력과 달라 되돌릴 수 없습니다"}
+		}
+
+		event, err = applyCustomerStatusTx(tx, actor, customerSeq, toStatus, fromStatus, reason, &historySeq)
+		return err
+	})
+	if err != nil {
+		log.Printf("RevertCustomerStatus error: %v", err)
+		return "", err
+	}
+
+	publishCustomerStatusEvent(event)
+	log.Printf("[Customer] RevertCustomerStatus 완료 - CustomerSeq: %d, %s → %s", customerSeq, event.FromStatus, event.ToStatus)
+	return event.ToStatus, nil
+}
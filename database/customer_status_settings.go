@@ -0,0 +1,251 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// CustomerStatusInUseError - 고객이 사용 중인 상태를 삭제하려는 경우
+type CustomerStatusInUseError struct {
+	Name  string
+	Count int // 해당 상태의 고객 수
+}
+
+func (e *CustomerStatusInUseError) Error() string {
+	return fmt.Sprintf("'%s' 상태의 고객이 %d명 있어 삭제할 수 없습니다", e.Name, e.Count)
+}
+
+const customerStatusColumns = `seq, name, color, is_active, is_terminal, is_system, sort_order`
+
+// scanCustomerStatusDefs - customer_statuses 조회 결과를 상태 정의 목록으로 변환
+func scanCustomerStatusDefs(rows *sql.Rows) ([]CustomerStatusDef, error) {
+	defer rows.Close()
+
+	var defs []CustomerStatusDef
+	for rows.Next() {
+		var d CustomerStatusDef
+		if err := rows.Scan(&d.Seq, &d.Name, &d.Color, &d.IsActive, &d.IsTerminal, &d.IsSystem, &d.SortOrder); err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	return defs, rows.Err()
+}
+
+// GetCustomerStatusDefs - 고객 상태 목록 (표시 순서)
+func GetCustomerStatusDefs() ([]CustomerStatusDef, error) {
+	rows, err := DB.Query(`SELECT ` + customerStatusColumns + ` FROM customer_statuses ORDER BY sort_order, seq`)
+	if err != nil {
+		log.Printf("GetCustomerStatusDefs error: %v", err)
+		return nil, err
+	}
+
+	defs, err := scanCustomerStatusDefs(rows)
+	if err != nil {
+		log.Printf("GetCustomerStatusDefs scan error: %v", err)
+		return nil, err
+	}
+	return defs, nil
+}
+
+// customerStatusDefsTx - 트랜잭션 안에서 고객 상태 목록 조회 (상태 이름 → 정의)
+func customerStatusDefsTx(tx *sql.Tx) (map[string]CustomerStatusDef, error) {
+	rows, err := tx.Query(`SELECT ` + customerStatusColumns + ` FROM customer_statuses`)
+	if err != nil {
+		log.Printf("customerStatusDefsTx error: %v", err)
+		return nil, err
+	}
+
+	defs, err := scanCustomerStatusDefs(rows)
+	if err != nil {
+		log.Printf("customerStatusDefsTx scan error: %v", err)
+		return nil, err
+	}
+
+	byName := make(map[string]CustomerStatusDef, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+	return byName, nil
+}
+
+// CreateCustomerStatus - 고객 상태 추가
+// 파라미터: actor (작업자), def (Name, Color, IsActive, IsTerminal, SortOrder 사용)
+// 반환: 생성된 상태 seq, 에러
+func CreateCustomerStatus(actor AuditActor, def CustomerStatusDef) (int64, error) {
+	var seq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			INSERT INTO customer_statuses (name, color, is_active, is_terminal, is_system, sort_order, createdDate)
+			VALUES (?, ?, ?, ?, 0, ?, NOW())
+		`, def.Name, def.Color, def.IsActive, def.IsTerminal, def.SortOrder)
+		if err != nil {
+			return err
+		}
+
+		seq, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerStatusSave, "customer_status", seq, nil, customerStatusAuditData(def))
+	})
+	if err != nil {
+		log.Printf("CreateCustomerStatus error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[CustomerStatus] CreateCustomerStatus 완료 - Seq: %d, Name: %s", seq, def.Name)
+	return seq, nil
+}
+
+// UpdateCustomerStatus - 고객 상태 수정 (이름은 고객 데이터에 저장되므로 변경하지 않음)
+// 기본 상태는 색상과 표시 순서만 변경하고 진행/종료 구분은 유지
+// 반환: 수정된 행 수, 에러
+func UpdateCustomerStatus(actor AuditActor, def CustomerStatusDef) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT `+customerStatusColumns+` FROM customer_statuses WHERE seq = ? FOR UPDATE`, def.Seq)
+		if err != nil {
+			return err
+		}
+		current, err := scanCustomerStatusDefs(rows)
+		if err != nil {
+			return err
+		}
+		if len(current) == 0 {
+			return nil
+		}
+
+		before := current[0]
+		after := before
+		after.Color = def.Color
+		after.SortOrder = def.SortOrder
+		if !before.IsSystem {
+			after.IsActive = def.IsActive
+			after.IsTerminal = def.IsTerminal
+		}
+
+		result, err := tx.Exec(`
+			UPDATE customer_statuses
+			SET color = ?, is_active = ?, is_terminal = ?, sort_order = ?
+			WHERE seq = ?
+		`, after.Color, after.IsActive, after.IsTerminal, after.SortOrder, def.Seq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerStatusSave, "customer_status", def.Seq,
+			customerStatusAuditData(before), customerStatusAuditData(after))
+	})
+	if err != nil {
+		log.Printf("UpdateCustomerStatus error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// DeleteCustomerStatus - 추가한 고객 상태 삭제 (기본 상태와 고객이 사용 중인 상태는 삭제 불가)
+// 반환: 삭제된 행 수, 에러 (사용 중이면 *CustomerStatusInUseError)
+func DeleteCustomerStatus(actor AuditActor, statusSeq int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT `+customerStatusColumns+` FROM customer_statuses WHERE seq = ? AND is_system = 0 FOR UPDATE`, statusSeq)
+		if err != nil {
+			return err
+		}
+		current, err := scanCustomerStatusDefs(rows)
+		if err != nil {
+			return err
+		}
+		if len(current) == 0 {
+			return nil
+		}
+
+		// 휴지통 고객도 포함 (복원했을 때 상태가 목록에 남아 있어야 함)
+		var count int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM customers WHERE status = ?`, current[0].Name).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return &CustomerStatusInUseError{Name: current[0].Name, Count: count}
+		}
+
+		result, err := tx.Exec(`DELETE FROM customer_statuses WHERE seq = ?`, statusSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerStatusDelete, "customer_status", statusSeq, customerStatusAuditData(current[0]), nil)
+	})
+	if err != nil {
+		log.Printf("DeleteCustomerStatus error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// customerStatusAuditData - 감사 로그에 기록할 상태 정의 값
+func customerStatusAuditData(def CustomerStatusDef) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        def.Name,
+		"color":       def.Color,
+		"is_active":   def.IsActive,
+		"is_terminal": def.IsTerminal,
+		"sort_order":  def.SortOrder,
+	}
+}
+
+// GetBranchCallLimit - 지점 통화 횟수 제한 (0이면 제한 없음, 지점이 없으면 기본값)
+func GetBranchCallLimit(branchSeq int) (int, error) {
+	var limit int
+	err := DB.QueryRow(`SELECT call_limit FROM branches WHERE seq = ?`, branchSeq).Scan(&limit)
+	if err == sql.ErrNoRows {
+		return DefaultCallLimit, nil
+	}
+	if err != nil {
+		log.Printf("GetBranchCallLimit error: %v", err)
+		return 0, err
+	}
+	return limit, nil
+}
+
+// UpdateBranchCallLimit - 지점 통화 횟수 제한 변경 (이미 통화한 고객의 상태는 다음 통화 때 새 기준으로 판단)
+// 파라미터: actor (작업자), branchSeq (지점 seq), limit (0이면 제한 없음)
+func UpdateBranchCallLimit(actor AuditActor, branchSeq, limit int) error {
+	err := Transaction(func(tx *sql.Tx) error {
+		var before int
+		err := tx.QueryRow(`SELECT call_limit FROM branches WHERE seq = ? FOR UPDATE`, branchSeq).Scan(&before)
+		if err != nil {
+			return err
+		}
+		if before == limit {
+			return nil
+		}
+
+		if _, err := tx.Exec(`UPDATE branches SET call_limit = ? WHERE seq = ?`, limit, branchSeq); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditBranchCallLimitSave, "branch", branchSeq,
+			map[string]interface{}{"call_limit": before}, map[string]interface{}{"call_limit": limit})
+	})
+	if err != nil {
+		log.Printf("UpdateBranchCallLimit error: %v", err)
+		return err
+	}
+
+	log.Printf("[Branch] UpdateBranchCallLimit 완료 - BranchSeq: %d, CallLimit: %d", branchSeq, limit)
+	return nil
+}
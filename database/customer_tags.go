@@ -0,0 +1,282 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+)
+
+// CustomerTag - 지점 고객 태그 (customer_tag_defs)
+type CustomerTag struct {
+	Seq           int
+	BranchSeq     int
+	Name          string
+	Color         string
+	CustomerCount int // 태그가 붙은 고객 수 (휴지통 고객 제외, GetCustomerTags에서만 채움)
+}
+
+// GetCustomerTags - 지점 태그 목록 (이름순, 태그별 고객 수 포함)
+// 파라미터: branchSeq (지점 seq)
+func GetCustomerTags(branchSeq int) ([]CustomerTag, error) {
+	rows, err := DB.Query(`
+		SELECT t.seq, t.branch_seq, t.name, t.color, COUNT(c.seq)
+		FROM customer_tag_defs t
+		LEFT JOIN customer_tags ct ON ct.tag_seq = t.seq
+		LEFT JOIN customers c ON c.seq = ct.customer_seq AND c.deleted_at IS NULL
+		WHERE t.branch_seq = ?
+		GROUP BY t.seq, t.branch_seq, t.name, t.color
+		ORDER BY t.name
+	`, branchSeq)
+	if err != nil {
+		log.Printf("GetCustomerTags error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []CustomerTag
+	for rows.Next() {
+		var t CustomerTag
+		if err := rows.Scan(&t.Seq, &t.BranchSeq, &t.Name, &t.Color, &t.CustomerCount); err != nil {
+			log.Printf("GetCustomerTags scan error: %v", err)
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// GetTagsByCustomers - 고객별 태그 목록 (고객 목록 화면 표시용)
+// 파라미터: customerSeqs (고객 seq 목록)
+// 반환: 고객 seq → 태그 목록 (이름순), 에러
+func GetTagsByCustomers(customerSeqs []int) (map[int][]CustomerTag, error) {
+	result := map[int][]CustomerTag{}
+	if len(customerSeqs) == 0 {
+		return result, nil
+	}
+
+	placeholders, args := inPlaceholders(customerSeqs)
+	rows, err := DB.Query(`
+		SELECT ct.customer_seq, t.seq, t.branch_seq, t.name, t.color
+		FROM customer_tags ct
+		JOIN customer_tag_defs t ON t.seq = ct.tag_seq
+		WHERE ct.customer_seq IN (`+placeholders+`)
+		ORDER BY t.name
+	`, args...)
+	if err != nil {
+		log.Printf("GetTagsByCustomers error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var customerSeq int
+		var t CustomerTag
+		if err := rows.Scan(&customerSeq, &t.Seq, &t.BranchSeq, &t.Name, &t.Color); err != nil {
+			log.Printf("GetTagsByCustomers scan error: %v", err)
+			return nil, err
+		}
+		result[customerSeq] = append(result[customerSeq], t)
+	}
+	return result, rows.Err()
+}
+
+// CreateCustomerTag - 지점 태그 추가
+// 파라미터: actor (작업자), branchSeq (지점 seq), name (태그 이름), color (#RRGGBB)
+// 반환: 생성된 태그 seq, 에러 (같은 지점에 같은 이름이 있으면 UNIQUE 제약 에러)
+func CreateCustomerTag(actor AuditActor, branchSeq int, name, color string) (int64, error) {
+	var seq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			INSERT INTO customer_tag_defs (branch_seq, name, color, createdDate)
+			VALUES (?, ?, ?, NOW())
+		`, branchSeq, name, color)
+		if err != nil {
+			return err
+		}
+
+		seq, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerTagSave, "customer_tag", seq, nil, customerTagAuditData(branchSeq, name, color))
+	})
+	if err != nil {
+		log.Printf("CreateCustomerTag error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[CustomerTag] CreateCustomerTag 완료 - BranchSeq: %d, Seq: %d, Name: %s", branchSeq, seq, name)
+	return seq, nil
+}
+
+// UpdateCustomerTag - 지점 태그 이름/색상 수정 (고객 연결은 seq 기준이므로 그대로 유지)
+// 파라미터: actor (작업자), branchSeq (지점 seq, 다른 지점 태그는 수정하지 않음), tagSeq, name, color
+// 반환: 수정된 행 수, 에러
+func UpdateCustomerTag(actor AuditActor, branchSeq, tagSeq int, name, color string) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `SELECT branch_seq, name, color FROM customer_tag_defs WHERE seq = ? AND branch_seq = ? FOR UPDATE`, tagSeq, branchSeq)
+		if err != nil || before == nil {
+			return err
+		}
+
+		result, err := tx.Exec(`UPDATE customer_tag_defs SET name = ?, color = ? WHERE seq = ?`, name, color, tagSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerTagSave, "customer_tag", tagSeq, before, customerTagAuditData(branchSeq, name, color))
+	})
+	if err != nil {
+		log.Printf("UpdateCustomerTag error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// DeleteCustomerTag - 지점 태그 삭제 (고객에 붙은 태그도 함께 삭제됨)
+// 파라미터: actor (작업자), branchSeq (지점 seq, 다른 지점 태그는 삭제하지 않음), tagSeq
+// 반환: 삭제된 행 수, 에러
+func DeleteCustomerTag(actor AuditActor, branchSeq, tagSeq int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `
+			SELECT t.branch_seq, t.name, t.color,
+			       (SELECT COUNT(*) FROM customer_tags ct WHERE ct.tag_seq = t.seq) AS customer_count
+			FROM customer_tag_defs t WHERE t.seq = ? AND t.branch_seq = ? FOR UPDATE`, tagSeq, branchSeq)
+		if err != nil || before == nil {
+			return err
+		}
+
+		result, err := tx.Exec(`DELETE FROM customer_tag_defs WHERE seq = ?`, tagSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerTagDelete, "customer_tag", tagSeq, before, nil)
+	})
+	if err != nil {
+		log.Printf("DeleteCustomerTag error: %v", err)
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// customerTagAuditData - 감사 로그에 기록할 태그 값
+func customerTagAuditData(branchSeq int, name, color string) map[string]interface{} {
+	return map[string]interface{}{
+		"branch_seq": branchSeq,
+		"name":       name,
+		"color":      color,
+	}
+}
+
+// TagCustomers - 선택한 고객에 태그 일괄 추가 (이미 태그가 붙은 고객은 건너뜀)
+// 파라미터: actor (작업자), branchSeq (지점 seq, 지점 고객과 지점 태그만 처리), tagSeq, customerSeqs
+// 반환: 태그를 추가한 고객 수, 에러 (지점에 태그가 없으면 sql.ErrNoRows)
+func TagCustomers(actor AuditActor, branchSeq, tagSeq int, customerSeqs []int) (int, error) {
+	return changeCustomerTags(actor, branchSeq, tagSeq, customerSeqs, true)
+}
+
+// UntagCustomers - 선택한 고객에서 태그 일괄 제거 (태그가 없는 고객은 건너뜀)
+// 파라미터: actor (작업자), branchSeq (지점 seq, 지점 고객과 지점 태그만 처리), tagSeq, customerSeqs
+// 반환: 태그를 제거한 고객 수, 에러 (지점에 태그가 없으면 sql.ErrNoRows)
+func UntagCustomers(actor AuditActor, branchSeq, tagSeq int, customerSeqs []int) (int, error) {
+	return changeCustomerTags(actor, branchSeq, tagSeq, customerSeqs, false)
+}
+
+// changeCustomerTags - 고객 태그 일괄 추가/제거 (변경된 고객마다 감사 로그 기록)
+func changeCustomerTags(actor AuditActor, branchSeq, tagSeq int, customerSeqs []int, add bool) (int, error) {
+	if len(customerSeqs) == 0 {
+		return 0, nil
+	}
+
+	changed := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		var tagName string
+		if err := tx.QueryRow(`SELECT name FROM customer_tag_defs WHERE seq = ? AND branch_seq = ?`, tagSeq, branchSeq).Scan(&tagName); err != nil {
+			return err
+		}
+
+		// 추가는 태그가 없는 고객, 제거는 태그가 있는 고객만 대상
+		tagged := `EXISTS`
+		if add {
+			tagged = `NOT EXISTS`
+		}
+		placeholders, seqArgs := inPlaceholders(customerSeqs)
+		args := append([]interface{}{branchSeq, tagSeq}, seqArgs...)
+		rows, err := tx.Query(`
+			SELECT c.seq FROM customers c
+			WHERE c.branch_seq = ? AND c.deleted_at IS NULL
+			  AND `+tagged+` (SELECT 1 FROM customer_tags ct WHERE ct.customer_seq = c.seq AND ct.tag_seq = ?)
+			  AND c.seq IN (`+placeholders+`)
+			FOR UPDATE
+		`, args...)
+		if err != nil {
+			return err
+		}
+		var targets []int64
+		for rows.Next() {
+			var seq int64
+			if err := rows.Scan(&seq); err != nil {
+				rows.Close()
+				return err
+			}
+			targets = append(targets, seq)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		tagData := map[string]interface{}{"tag_seq": tagSeq, "tag": tagName}
+		for _, seq := range targets {
+			var result sql.Result
+			if add {
+				result, err = tx.Exec(`INSERT IGNORE INTO customer_tags (customer_seq, tag_seq, createdDate) VALUES (?, ?, NOW())`, seq, tagSeq)
+			} else {
+				result, err = tx.Exec(`DELETE FROM customer_tags WHERE customer_seq = ? AND tag_seq = ?`, seq, tagSeq)
+			}
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				continue
+			}
+
+			if add {
+				err = recordAudit(tx, actor, AuditCustomerTagAdd, "customer", seq, nil, tagData)
+			} else {
+				err = recordAudit(tx, actor, AuditCustomerTagRemove, "customer", seq, tagData, nil)
+			}
+			if err != nil {
+				return err
+			}
+			changed++
+		}
+		return nil
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("changeCustomerTags error: %v", err)
+		}
+		return 0, err
+	}
+
+	log.Printf("[CustomerTag] changeCustomerTags 완료 - BranchSeq: %d, TagSeq: %d, 추가: %t, 요청: %d, 변경: %d", branchSeq, tagSeq, add, len(customerSeqs), changed)
+	return changed, nil
+}
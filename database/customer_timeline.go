@@ -0,0 +1,170 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+)
+
+// 고객 타임라인 이벤트 종류 (감사 로그 이벤트는 audit_log.action 값을 그대로 사용)
+const (
+	TimelineCustomerCreated = "customer.created" // 고객 등록
+	TimelineCall            = "call"             // CALLER 선택 (통화)
+	TimelineReservation     = "reservation"      // 예약 등록
+)
+
+// timelineAuditActions - 고객 타임라인에 표시할 감사 로그 작업 종류
+var timelineAuditActions = []string{
+	AuditCustomerUpdateName,
+	AuditCustomerUpdateComment,
+	AuditCustomerStatusChange,
+	AuditCustomerStatusRevert,
+	AuditCustomerDuplicateAttach,
+	AuditCustomerMerge,
+	AuditCustomerAssign,
+	AuditCustomerTransfer,
+	AuditCustomerTagAdd,
+	AuditCustomerTagRemove,
+	AuditCustomerCallbackCreate,
+	AuditCustomerCallbackComplete,
+	AuditCustomerCallbackCancel,
+	AuditCustomerNoteAdd,
+	AuditCustomerNoteUpdate,
+	AuditCustomerNotePin,
+	AuditCustomerPhoneReveal,
+	AuditCustomerDelete,
+	AuditCustomerRestore,
+	AuditSMSSend,
+}
+
+// timelineLimit - 타임라인 최대 이벤트 수
+const timelineLimit = 500
+
+// CustomerDetail - 고객 상세 정보 구조체
+type CustomerDetail struct {
+	CustomerInfo
+	BranchSeq  sql.NullInt64
+	BranchName string
+}
+
+// CustomerTimelineEvent - 고객 타임라인 이벤트
+type CustomerTimelineEvent struct {
+	Type       string // Timeline* 상수 또는 감사 로그 작업 종류
+	OccurredAt string // YYYY-MM-DD HH:MM (예약 등록은 일자만 기록되어 00:00)
+	Actor      string // 작업자 아이디 (통화는 CALLER)
+	Caller     string // 통화/예약 CALLER
+	Extra      string // 고객 등록: 광고 출처, 예약: 상담 일시
+	BeforeData string // 감사 로그 변경 전 값 (JSON)
+	AfterData  string // 감사 로그 변경 후 값 (JSON)
+}
+
+// GetCustomerDetail - 고객 상세 조회
+// 파라미터: customerSeq (고객 seq)
+// 반환: 고객 상세 정보, 에러 (없으면 sql.ErrNoRows)
+func GetCustomerDetail(customerSeq int) (*CustomerDetail, error) {
+	query := `
+		SELECT c.seq, c.name, c.phone_number, c.comment, c.commercial_name, c.ad_source,
+		       c.call_count, c.status,
+		       DATE_FORMAT(c.createdDate, '%Y-%m-%d %H:%i'),
+		       DATE_FORMAT(c.lastUpdateDate, '%Y-%m-%d %H:%i'),
+		       c.duplicate_of, c.branch_seq, COALESCE(b.branchName, '')
+		FROM customers c
+		LEFT JOIN branches b ON c.branch_seq = b.seq
+		WHERE c.seq = ? AND c.deleted_at IS NULL
+	`
+
+	var d CustomerDetail
+	err := DB.QueryRow(query, customerSeq).Scan(
+		&d.Seq, &d.Name, &d.PhoneNumber, &d.Comment, &d.CommercialName, &d.AdSource,
+		&d.CallCount, &d.Status, &d.CreatedDate, &d.LastUpdateDate,
+		&d.DuplicateOf, &d.BranchSeq, &d.BranchName,
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("GetCustomerDetail error: %v", err)
+		}
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// GetCustomerTimeline - 고객 활동 타임라인 조회 (최신순)
+// 고객 등록, CALLER 선택 이력, 예약, 감사 로그(이름/코멘트/상태 변경, SMS 발송)를 하나로 합쳐 조회
+// 파라미터: customerSeq (고객 seq)
+func GetCustomerTimeline(customerSeq int) ([]CustomerTimelineEvent, error) {
+	actionPlaceholders, args := inPlaceholders(timelineAuditActions)
+
+	query := `
+		SELECT event_type, DATE_FORMAT(occurred_at, '%Y-%m-%d %H:%i'), actor, caller, extra,
+		       COALESCE(before_data, ''), COALESCE(after_data, '')
+		FROM (
+			SELECT ? AS event_type, c.createdDate AS occurred_at, 0 AS sort_order,
+			       '' AS actor, '' AS caller, COALESCE(c.ad_source, '') AS extra,
+			       NULL AS before_data, NULL AS after_data
+			FROM customers c
+			WHERE c.seq = ? AND c.deleted_at IS NULL
+
+			UNION ALL
+
+			SELECT ?, h.selected_date, h.seq,
+			       h.caller, h.caller, '',
+			       NULL, NULL
+			FROM caller_selection_history h
+			WHERE h.customer_id = ?
+
+			UNION ALL
+
+			SELECT ?, CAST(r.createdDate AS DATETIME), r.seq,
+			       COALESCE(u.user_id, ''), r.caller, DATE_FORMAT(r.interview_date, '%Y-%m-%d %H:%i'),
+			       NULL, NULL
+			FROM reservation_info r
+			LEFT JOIN user_info u ON r.user_seq = u.seq
+			WHERE r.customer_id = ?
+
+			UNION ALL
+
+			SELECT a.action, a.createdDate, a.seq,
+			       COALESCE(a.user_id, ''), '', '',
+			       a.before_data, a.after_data
+			FROM audit_log a
+			WHERE a.entity_type = 'customer' AND a.entity_id = ? AND a.action IN (` + actionPlaceholders + `)
+		) t
+		ORDER BY occurred_at DESC, sort_order DESC
+		LIMIT ?
+	`
+
+	seq := strconv.Itoa(customerSeq)
+	queryArgs := []interface{}{
+		TimelineCustomerCreated, customerSeq,
+		TimelineCall, customerSeq,
+		TimelineReservation, customerSeq,
+		seq,
+	}
+	queryArgs = append(queryArgs, args...)
+	queryArgs = append(queryArgs, timelineLimit)
+
+	rows, err := DB.Query(query, queryArgs...)
+	if err != nil {
+		log.Printf("GetCustomerTimeline error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []CustomerTimelineEvent
+	for rows.Next() {
+		var e CustomerTimelineEvent
+		if err := rows.Scan(&e.Type, &e.OccurredAt, &e.Actor, &e.Caller, &e.Extra, &e.BeforeData, &e.AfterData); err != nil {
+			log.Printf("GetCustomerTimeline scan error: %v", err)
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Printf("GetCustomerTimeline rows error: %v", err)
+		return nil, err
+	}
+
+	return events, nil
+}
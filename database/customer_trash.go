@@ -0,0 +1,330 @@
+package database
+
+import (
+	"backoffice/utils"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+)
+
+// trashPurgeBatchSize - 보관 기간이 지난 고객 영구 삭제 시 한 트랜잭션에서 처리하는 고객 수
+const trashPurgeBatchSize = 500
+
+// DeletedCustomer - 휴지통 고객
+type DeletedCustomer struct {
+	Seq         int
+	Name        string
+	PhoneNumber string
+	AdSource    *string
+	Status      string
+	CallCount   int
+	CreatedDate string
+	DeletedAt   string
+	DeletedBy   string // 삭제한 사용자 아이디 (사용자가 없으면 빈 문자열)
+	PurgeDate   string // 영구 삭제 예정일 (보관 기간 기준)
+	MergedInto  *int   // 병합되어 휴지통으로 이동한 경우 남긴 고객 seq
+}
+
+// PurgedKakaoCustomer - 영구 삭제된 카카오 가입 고객 (커밋 후 연결 해제 처리 함수에 전달)
+type PurgedKakaoCustomer struct {
+	CustomerSeq int64
+	KakaoID     int64
+}
+
+// CustomerPurgeListener - 카카오 가입 고객 영구 삭제 처리 함수
+type CustomerPurgeListener func(PurgedKakaoCustomer)
+
+var (
+	purgeListenersMu sync.RWMutex
+	purgeListeners   []CustomerPurgeListener
+)
+
+// OnKakaoCustomerPurged - 카카오 가입 고객 영구 삭제 처리 함수 등록 (서버 시작 시 등록)
+// 휴지통 삭제는 되돌릴 수 있으므로 카카오 연결 해제는 행이 실제로 삭제되어 커밋된 뒤에만 호출됨
+func OnKakaoCustomerPurged(listener CustomerPurgeListener) {
+	purgeListenersMu.Lock()
+	defer purgeListenersMu.Unlock()
+	purgeListeners = append(purgeListeners, listener)
+}
+
+// publishKakaoCustomersPurged - 등록된 처리 함수에 영구 삭제된 카카오 가입 고객 전달
+func publishKakaoCustomersPurged(purged []PurgedKakaoCustomer) {
+	if len(purged) == 0 {
+		return
+	}
+
+	purgeListenersMu.RLock()
+	listeners := append([]CustomerPurgeListener(nil), purgeListeners...)
+	purgeListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		go func(listener CustomerPurgeListener) {
+			for _, customer := range purged {
+				func() {
+					defer func() {
+						if rec := recover(); rec != nil {
+							log.Printf("[Customer] 영구 삭제 후처리 중 panic - CustomerSeq: %d, %v", customer.CustomerSeq, rec)
+						}
+					}()
+					listener(customer)
+				}()
+			}
+		}(listener)
+	}
+}
+
+// trashScopeCondition - 휴지통 조회 범위 조건 (branchSeq가 nil이면 미배정 고객)
+func trashScopeCondition(branchSeq *int) (string, []interface{}) {
+	if branchSeq == nil {
+		return `c.branch_seq IS NULL`, nil
+	}
+	return `c.branch_seq = ?`, []interface{}{*branchSeq}
+}
+
+// GetDeletedCustomersCount - 휴지통 고객 수
+// 파라미터: branchSeq (지점 seq, nil이면 미배정 고객)
+func GetDeletedCustomersCount(branchSeq *int) (int, error) {
+	scope, args := trashScopeCondition(branchSeq)
+	count, err := Count(`SELECT COUNT(*) FROM customers c WHERE c.deleted_at IS NOT NULL AND `+scope, args...)
+	if err != nil {
+		log.Printf("GetDeletedCustomersCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetDeletedCustomers - 휴지통 고객 목록 (최근 삭제순, 페이징 적용)
+// 파라미터: branchSeq (지점 seq, nil이면 미배정 고객), retentionDays (보관 기간, 영구 삭제 예정일 계산용), page, itemsPerPage
+func GetDeletedCustomers(branchSeq *int, retentionDays, page, itemsPerPage int) ([]DeletedCustomer, error) {
+	scope, scopeArgs := trashScopeCondition(branchSeq)
+	query := `
+		SELECT c.seq, c.name, c.phone_number, c.ad_source, c.status, c.call_count,
+		       DATE_FORMAT(c.createdDate, '%Y-%m-%d %H:%i'),
+		       DATE_FORMAT(c.deleted_at, '%Y-%m-%d %H:%i'),
+		       COALESCE(u.user_id, ''),
+		       DATE_FORMAT(DATE_ADD(c.deleted_at, INTERVAL ? DAY), '%Y-%m-%d'),
+		       c.merged_into
+		FROM customers c
+		LEFT JOIN user_info u ON u.seq = c.deleted_by
+		WHERE c.deleted_at IS NOT NULL AND ` + scope + `
+		ORDER BY c.deleted_at DESC, c.seq DESC
+		LIMIT ? OFFSET ?
+	`
+	args := append([]interface{}{retentionDays}, scopeArgs...)
+	args = append(args, itemsPerPage, (page-1)*itemsPerPage)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("GetDeletedCustomers error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []DeletedCustomer
+	for rows.Next() {
+		var c DeletedCustomer
+		if err := rows.Scan(&c.Seq, &c.Name, &c.PhoneNumber, &c.AdSource, &c.Status, &c.CallCount,
+			&c.CreatedDate, &c.DeletedAt, &c.DeletedBy, &c.PurgeDate, &c.MergedInto); err != nil {
+			log.Printf("GetDeletedCustomers scan error: %v", err)
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+	return customers, rows.Err()
+}
+
+// RestoreCustomer - 휴지통 고객 복원
+// 파라미터: actor (작업자), customerSeq (고객 seq), branchSeq (휴지통 지점, nil이면 미배정 고객)
+// 반환: 복원된 행 수 (휴지통에 없거나 다른 지점 고객이면 0), 에러
+func RestoreCustomer(actor AuditActor, customerSeq int, branchSeq *int) (int64, error) {
+	scope, scopeArgs := trashScopeCondition(branchSeq)
+
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `
+			SELECT c.deleted_at, c.deleted_by, c.merged_into
+			FROM customers c WHERE c.seq = ? AND c.deleted_at IS NOT NULL AND `+scope+` FOR UPDATE`,
+			append([]interface{}{customerSeq}, scopeArgs...)...)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+
+		result, err := tx.Exec(`UPDATE customers SET deleted_at = NULL, deleted_by = NULL, merged_into = NULL WHERE seq = ?`, customerSeq)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditCustomerRestore, "customer", customerSeq, before, map[string]interface{}{"trash": false})
+	})
+	if err != nil {
+		log.Printf("RestoreCustomer error: %v", err)
+		return 0, err
+	}
+
+	if rowsAffected > 0 {
+		log.Printf("[Customer] RestoreCustomer 완료 - CustomerSeq: %d", customerSeq)
+	}
+	return rowsAffected, nil
+}
+
+// PurgeCustomer - 휴지통 고객 영구 삭제 (보관 기간과 관계없이 즉시)
+// 파라미터: actor (작업자), customerSeq (고객 seq), branchSeq (휴지통 지점, nil이면 미배정 고객)
+// 반환: 삭제된 행 수 (휴지통에 없거나 다른 지점 고객이면 0), 에러
+func PurgeCustomer(actor AuditActor, customerSeq int, branchSeq *int) (int64, error) {
+	scope, scopeArgs := trashScopeCondition(branchSeq)
+
+	var rowsAffected, kakaoID int64
+	err := Transaction(func(tx *sql.Tx) error {
+		var seq int64
+		err := tx.QueryRow(`SELECT c.seq FROM customers c WHERE c.seq = ? AND c.deleted_at IS NOT NULL AND `+scope+` FOR UPDATE`,
+			append([]interface{}{customerSeq}, scopeArgs...)...).Scan(&seq)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, kakaoID, err = purgeCustomerTx(tx, actor, seq)
+		return err
+	})
+	if err != nil {
+		log.Printf("PurgeCustomer error: %v", err)
+		return 0, err
+	}
+
+	if rowsAffected > 0 {
+		log.Printf("[Customer] PurgeCustomer 완료 - CustomerSeq: %d", customerSeq)
+		if kakaoID != 0 {
+			publishKakaoCustomersPurged([]PurgedKakaoCustomer{{CustomerSeq: int64(customerSeq), KakaoID: kakaoID}})
+		}
+	}
+	return rowsAffected, nil
+}
+
+// purgeCustomerTx - 휴지통 고객 한 명 영구 삭제 (삭제 전 고객 식별 정보를 감사 로그에 기록)
+// 영구 삭제 후에도 감사 로그에 개인정보가 남지 않도록 이름/전화번호는 마스킹하고 메모 등은 기록하지 않음
+// reservation_info의 FK는 ON DELETE SET NULL로 설정되어 있어 예약의 customer_id는 NULL로 변경됨
+// 반환: 삭제된 행 수, 카카오 회원번호 (카카오 가입 고객이 아니면 0), 에러
+func purgeCustomerTx(tx *sql.Tx, actor AuditActor, customerSeq int64) (int64, int64, error) {
+	before, err := auditSnapshot(tx, `
+		SELECT branch_seq, deleted_at, deleted_by, merged_into
+		FROM customers WHERE seq = ?`, customerSeq)
+	if err != nil || before == nil {
+		return 0, 0, err
+	}
+
+	var name, phoneNumber string
+	var kakaoID int64
+	if err := tx.QueryRow(`SELECT name, phone_number, COALESCE(kakao_id, 0) FROM customers WHERE seq = ?`, customerSeq).
+		Scan(&name, &phoneNumber, &kakaoID); err != nil {
+		return 0, 0, err
+	}
+	before["name"] = utils.MaskName(name)
+	before["phone_number"] = utils.MaskPhoneNumber(phoneNumber)
+
+	result, err := tx.Exec(`DELETE FROM customers WHERE seq = ? AND deleted_at IS NOT NULL`, customerSeq)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	if rowsAffected == 0 {
+		kakaoID = 0
+	}
+
+	return rowsAffected, kakaoID, recordAudit(tx, actor, AuditCustomerPurge, "customer", customerSeq, before, nil)
+}
+
+// PurgeDeletedCustomers - 보관 기간이 지난 휴지통 고객 영구 삭제 (전 지점)
+// 파라미터: retentionDays (보관 기간, 0 이하이면 삭제하지 않음)
+// 반환: 삭제된 고객 수, 에러
+func PurgeDeletedCustomers(retentionDays int) (int, error) {
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+
+	total := 0
+	for {
+		purged := 0
+		var kakaoCustomers []PurgedKakaoCustomer
+		err := Transaction(func(tx *sql.Tx) error {
+			rows, err := tx.Query(`
+				SELECT seq FROM customers
+				WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - INTERVAL ? DAY
+				ORDER BY deleted_at, seq
+				LIMIT ?
+				FOR UPDATE
+			`, retentionDays, trashPurgeBatchSize)
+			if err != nil {
+				return err
+			}
+			var seqs []int64
+			for rows.Next() {
+				var seq int64
+				if err := rows.Scan(&seq); err != nil {
+					rows.Close()
+					return err
+				}
+				seqs = append(seqs, seq)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			for _, seq := range seqs {
+				n, kakaoID, err := purgeCustomerTx(tx, AuditActor{}, seq)
+				if err != nil {
+					return err
+				}
+				purged += int(n)
+				if kakaoID != 0 {
+					kakaoCustomers = append(kakaoCustomers, PurgedKakaoCustomer{CustomerSeq: seq, KakaoID: kakaoID})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("PurgeDeletedCustomers error: %v", err)
+			return total, err
+		}
+
+		total += purged
+		publishKakaoCustomersPurged(kakaoCustomers)
+		if purged < trashPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// StartCustomerTrashPurge - 주기적으로 보관 기간이 지난 휴지통 고객을 영구 삭제하는 백그라운드 작업 시작
+// 파라미터: interval (정리 주기), retentionDays (보관 기간, 0 이하이면 작업을 시작하지 않음)
+func StartCustomerTrashPurge(interval time.Duration, retentionDays int) {
+	if retentionDays <= 0 {
+		log.Printf("고객 휴지통 자동 영구 삭제 사용 안 함 (CUSTOMER_TRASH_RETENTION_DAYS=%d)", retentionDays)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if count, err := PurgeDeletedCustomers(retentionDays); err == nil && count > 0 {
+				log.Printf("휴지통 고객 영구 삭제 완료: %d명 (보관 기간 %d일)", count, retentionDays)
+			}
+		}
+	}()
+}
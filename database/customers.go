@@ -1,20 +1,41 @@
 package database
 
 import (
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
 	"log"
 )
 
 // CreateCustomer - 고객 추가 (통합 함수)
+// 같은 전화번호의 기존 고객이 있으면 CUSTOMER_DUPLICATE_POLICY에 따라 중복 표시/기존 고객 연결/등록 거부
 // 파라미터: branchSeq (지점 seq, nil이면 미배정), name (고객명), phoneNumber (전화번호), comment (메모), commercialName (광고명, 빈 문자열이면 "-"), adSource (광고 출처, 빈 문자열이면 "walk_in")
-// 반환: 생성된 ID, 에러
-func CreateCustomer(branchSeq *int, name, phoneNumber, comment, commercialName, adSource string) (int64, error) {
+// 반환: 등록 결과, 에러 (reject 정책이면 *DuplicateCustomerError)
+func CreateCustomer(branchSeq *int, name, phoneNumber, comment, commercialName, adSource string) (*CustomerInsertResult, error) {
 	// 로깅
 	if branchSeq != nil {
-		log.Printf("[Customer] CreateCustomer 호출 - BranchSeq: %d, Name: %s, Phone: %s, AdSource: %s\n", *branchSeq, name, phoneNumber, adSource)
+		log.Printf("[Customer] CreateCustomer 호출 - BranchSeq: %d, Name: %s, Phone: %s, AdSource: %s\n", *branchSeq, utils.MaskName(name), utils.MaskPhoneNumber(phoneNumber), adSource)
 	} else {
-		log.Printf("[Customer] CreateCustomer 호출 - BranchSeq: NULL (미배정), Name: %s, Phone: %s, AdSource: %s\n", name, phoneNumber, adSource)
+		log.Printf("[Customer] CreateCustomer 호출 - BranchSeq: NULL (미배정), Name: %s, Phone: %s, AdSource: %s\n", utils.MaskName(name), utils.MaskPhoneNumber(phoneNumber), adSource)
 	}
 
+	var result *CustomerInsertResult
+	err := Transaction(func(tx *sql.Tx) error {
+		var err error
+		result, err = createCustomerTx(tx, branchSeq, name, phoneNumber, comment, commercialName, adSource)
+		return err
+	})
+	if err != nil {
+		log.Printf("CreateCustomer - insert error: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[Customer] CreateCustomer 완료 - ID: %d\n", result.Seq)
+	return result, nil
+}
+
+// createCustomerTx - 트랜잭션 안에서 고객 추가 (CreateCustomer와 일괄 가져오기 공용)
+func createCustomerTx(tx *sql.Tx, branchSeq *int, name, phoneNumber, comment, commercialName, adSource string) (*CustomerInsertResult, error) {
 	// 기본값 처리
 	if commercialName == "" {
 		commercialName = "-"
@@ -26,8 +47,8 @@ func CreateCustomer(branchSeq *int, name, phoneNumber, comment, commercialName,
 	// 고객 INSERT
 	query := `
 		INSERT INTO customers 
-			(branch_seq, name, phone_number, comment, commercial_name, ad_source, createdDate, call_count, status)
-		VALUES (?, ?, ?, ?, ?, ?, NOW(), 0, '신규')
+			(branch_seq, name, phone_number, phone_normalized, comment, commercial_name, ad_source, createdDate, call_count, status, duplicate_of)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), 0, '신규', ?)
 	`
 
 	var commentVal interface{}
@@ -44,27 +65,37 @@ func CreateCustomer(branchSeq *int, name, phoneNumber, comment, commercialName,
 		branchSeqArg = *branchSeq
 	}
 
-	result, err := DB.Exec(query, branchSeqArg, name, phoneNumber, commentVal, commercialName, adSource)
-	if err != nil {
-		log.Printf("CreateCustomer - insert error: %v", err)
-		return 0, err
+	incoming := map[string]interface{}{
+		"name":            name,
+		"phone_number":    phoneNumber,
+		"comment":         commentVal,
+		"commercial_name": commercialName,
+		"ad_source":       adSource,
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		log.Printf("CreateCustomer - get last insert id error: %v", err)
-		return 0, err
+	result, err := registerCustomerTx(tx, branchSeq, phoneNumber, incoming, func(tx *sql.Tx, phoneNormalized string, duplicateOf interface{}) (sql.Result, error) {
+		return tx.Exec(query, branchSeqArg, name, phoneNumber, phoneNormalized, commentVal, commercialName, adSource, duplicateOf)
+	})
+	if err != nil || result.Attached || comment == "" {
+		return result, err
 	}
 
-	log.Printf("[Customer] CreateCustomer 완료 - ID: %d\n", id)
-	return id, nil
+	// 등록 시 입력한 코멘트는 고객의 첫 메모(고정)로 보관
+	if _, err := insertCustomerNoteTx(tx, AuditActor{}, result.Seq, comment, true); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // InsertCustomer - 고객 추가 (워크인용 래퍼 함수 - 하위 호환성 유지)
 // 파라미터: branchSeq (지점 seq), name (고객명), phoneNumber (전화번호), comment (메모)
-// 반환: 생성된 ID, 에러
+// 반환: 생성된 ID (attach 정책이면 기존 고객 ID), 에러
 func InsertCustomer(branchSeq int, name, phoneNumber, comment string) (int64, error) {
-	return CreateCustomer(&branchSeq, name, phoneNumber, comment, "", "")
+	result, err := CreateCustomer(&branchSeq, name, phoneNumber, comment, "", "")
+	if err != nil {
+		return 0, err
+	}
+	return result.Seq, nil
 }
 
 // CustomerInfo - 고객 정보 구조체
@@ -76,22 +107,23 @@ type CustomerInfo struct {
 	CommercialName *string
 	AdSource       *string
 	CallCount      int
-	Status         string // 고객 상태: 신규, 예약확정, 전화상거절, 진행중
+	Status         string // 고객 상태 (customer_statuses.name)
 	CreatedDate    string
 	LastUpdateDate *string
+	DuplicateOf    *int // 중복 의심 기존 고객 seq (없으면 nil)
 }
 
 // buildCustomerFilterConditions - 고객 조회 시 WHERE 조건절 생성 (공통 함수)
-// 파라미터: branchSeq, filter, searchType, searchKeyword
+// 파라미터: branchSeq, filter, searchType, searchKeyword, advanced (복합 검색 조건)
 // 반환: WHERE 조건 문자열, args 배열
-func buildCustomerFilterConditions(branchSeq int, filter, searchType, searchKeyword string) (string, []interface{}) {
+func buildCustomerFilterConditions(branchSeq int, filter, searchType, searchKeyword string, advanced CustomerSearchFilter) (string, []interface{}) {
 	whereClause := ""
 	args := []interface{}{}
 
-	// 필터에 따라 조건 추가
-	if filter == "new" {
-		// '처리중' 필터: status가 '신규' 또는 '진행중'인 고객만 표시
-		whereClause += ` AND status IN ('신규', '진행중')`
+	// 필터에 따라 조건 추가 (복합 검색에서 상태를 선택하면 상태 조건이 우선)
+	if filter == "new" && len(advanced.Statuses) == 0 {
+		// '처리중' 필터: 처리중 목록에 표시하도록 설정된 상태(customer_statuses.is_active)의 고객만 표시
+		whereClause += ` AND status IN (SELECT name FROM customer_statuses WHERE is_active = 1)`
 	}
 	// filter == "all"인 경우: 모든 상태의 고객 표시 (조건 추가 없음)
 
@@ -115,13 +147,18 @@ func buildCustomerFilterConditions(branchSeq int, filter, searchType, searchKeyw
 		}
 	}
 
+	// 복합 검색 조건 추가
+	advancedClause, advancedArgs := buildAdvancedFilterConditions(branchSeq, advanced)
+	whereClause += advancedClause
+	args = append(args, advancedArgs...)
+
 	return whereClause, args
 }
 
 // GetCustomersCountByBranch - 지점별 고객 수 조회
-// 파라미터: branchSeq (지점 seq), filter ("new": call_count < 5, "all": 전체), searchType (검색 타입), searchKeyword (검색어)
+// 파라미터: branchSeq (지점 seq), filter ("new": call_count < 5, "all": 전체), searchType (검색 타입), searchKeyword (검색어), advanced (복합 검색 조건)
 // 반환: 고객 수, 에러
-func GetCustomersCountByBranch(branchSeq int, filter, searchType, searchKeyword string) (int, error) {
+func GetCustomersCountByBranch(branchSeq int, filter, searchType, searchKeyword string, advanced CustomerSearchFilter) (int, error) {
 
 	// 지점 seq가 0이면 0 반환
 	if branchSeq == 0 {
@@ -130,11 +167,11 @@ func GetCustomersCountByBranch(branchSeq int, filter, searchType, searchKeyword
 	}
 
 	// 고객 수 조회
-	query := `SELECT COUNT(*) FROM customers WHERE branch_seq = ?`
+	query := `SELECT COUNT(*) FROM customers WHERE branch_seq = ? AND deleted_at IS NULL`
 	args := []interface{}{branchSeq}
 
 	// 공통 필터 조건 추가
-	whereClause, filterArgs := buildCustomerFilterConditions(branchSeq, filter, searchType, searchKeyword)
+	whereClause, filterArgs := buildCustomerFilterConditions(branchSeq, filter, searchType, searchKeyword, advanced)
 	query += whereClause
 	args = append(args, filterArgs...)
 
@@ -149,9 +186,9 @@ func GetCustomersCountByBranch(branchSeq int, filter, searchType, searchKeyword
 }
 
 // GetCustomersByBranch - 지점별 고객 목록 조회 (페이징 적용)
-// 파라미터: branchSeq (지점 seq), filter ("new": call_count < 5, "all": 전체), searchType (검색 타입), searchKeyword (검색어), page (페이지 번호), itemsPerPage (페이지당 항목 수)
+// 파라미터: branchSeq (지점 seq), filter ("new": call_count < 5, "all": 전체), searchType (검색 타입), searchKeyword (검색어), advanced (복합 검색 조건), page (페이지 번호), itemsPerPage (페이지당 항목 수)
 // 반환: 고객 목록, 에러
-func GetCustomersByBranch(branchSeq int, filter, searchType, searchKeyword string, page, itemsPerPage int) ([]CustomerInfo, error) {
+func GetCustomersByBranch(branchSeq int, filter, searchType, searchKeyword string, advanced CustomerSearchFilter, page, itemsPerPage int) ([]CustomerInfo, error) {
 
 	// 지점 seq가 0이면 빈 배열 반환
 	if branchSeq == 0 {
@@ -171,14 +208,15 @@ func GetCustomersByBranch(branchSeq int, filter, searchType, searchKeyword strin
 			call_count,
 			status,
 			DATE_FORMAT(createdDate, '%Y-%m-%d %H:%i') as createdDate,
-			DATE_FORMAT(lastUpdateDate, '%Y-%m-%d %H:%i') as lastUpdateDate
+			DATE_FORMAT(lastUpdateDate, '%Y-%m-%d %H:%i') as lastUpdateDate,
+			duplicate_of
 		FROM customers
-		WHERE branch_seq = ?
+		WHERE branch_seq = ? AND deleted_at IS NULL
 	`
 	args := []interface{}{branchSeq}
 
 	// 공통 필터 조건 추가
-	whereClause, filterArgs := buildCustomerFilterConditions(branchSeq, filter, searchType, searchKeyword)
+	whereClause, filterArgs := buildCustomerFilterConditions(branchSeq, filter, searchType, searchKeyword, advanced)
 	query += whereClause
 	args = append(args, filterArgs...)
 
@@ -210,6 +248,7 @@ func GetCustomersByBranch(branchSeq int, filter, searchType, searchKeyword strin
 			&customer.Status,
 			&customer.CreatedDate,
 			&customer.LastUpdateDate,
+			&customer.DuplicateOf,
 		)
 		if err != nil {
 			log.Printf("GetCustomersByBranch - scan error: %v", err)
@@ -227,28 +266,40 @@ func GetCustomersByBranch(branchSeq int, filter, searchType, searchKeyword strin
 	return customers, nil
 }
 
-// UpdateCustomerComment - 고객 코멘트 업데이트
-// 파라미터: customerSeq - 고객 seq, comment - 업데이트할 코멘트
-// 반환: 에러
-func UpdateCustomerComment(customerSeq int, comment string) error {
-	query := `UPDATE customers SET comment = ? WHERE seq = ?`
-
-	_, err := DB.Exec(query, comment, customerSeq)
+// GetCustomerBranchSeq - 고객의 소속 지점 seq 조회
+// 반환: 지점 seq (미배정 고객은 Valid=false), 에러 (고객이 없거나 휴지통에 있으면 sql.ErrNoRows)
+func GetCustomerBranchSeq(customerSeq int) (sql.NullInt64, error) {
+	var branchSeq sql.NullInt64
+	err := DB.QueryRow(`SELECT branch_seq FROM customers WHERE seq = ? AND deleted_at IS NULL`, customerSeq).Scan(&branchSeq)
 	if err != nil {
-		log.Printf("UpdateCustomerComment - update error: %v", err)
-		return err
+		if err != sql.ErrNoRows {
+			log.Printf("GetCustomerBranchSeq - query error: %v", err)
+		}
+		return sql.NullInt64{}, err
 	}
-
-	return nil
+	return branchSeq, nil
 }
 
-// UpdateCustomerName - 고객 이름 업데이트
-// 파라미터: customerSeq - 고객 seq, name - 새 이름
+// UpdateCustomerName - 고객 이름 업데이트 (감사 로그 기록)
+// 파라미터: actor - 작업자, customerSeq - 고객 seq, name - 새 이름
 // 반환: 에러
-func UpdateCustomerName(customerSeq int, name string) error {
-	query := `UPDATE customers SET name = ? WHERE seq = ?`
+func UpdateCustomerName(actor AuditActor, customerSeq int, name string) error {
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `SELECT name FROM customers WHERE seq = ? AND deleted_at IS NULL FOR UPDATE`, customerSeq)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return nil
+		}
+
+		if _, err := tx.Exec(`UPDATE customers SET name = ? WHERE seq = ?`, name, customerSeq); err != nil {
+			return err
+		}
 
-	_, err := DB.Exec(query, name, customerSeq)
+		return recordAudit(tx, actor, AuditCustomerUpdateName, "customer", customerSeq,
+			before, map[string]interface{}{"name": name})
+	})
 	if err != nil {
 		log.Printf("UpdateCustomerName - update error: %v", err)
 		return err
@@ -262,7 +313,7 @@ func UpdateCustomerName(customerSeq int, name string) error {
 // 반환: 금일 생성된 고객 수, 에러
 func GetTodayTotalCustomers(branchSeq int) (int, error) {
 
-	query := `SELECT COUNT(*) FROM customers WHERE DATE(createdDate) = CURDATE()`
+	query := `SELECT COUNT(*) FROM customers WHERE DATE(createdDate) = CURDATE() AND deleted_at IS NULL`
 	args := []interface{}{}
 
 	if branchSeq > 0 {
@@ -297,7 +348,7 @@ func GetTodayCustomersByAdSource(branchSeq int) ([]AdSourceStats, error) {
 			COALESCE(ad_source, '미지정') as ad_source,
 			COUNT(*) as count
 		FROM customers 
-		WHERE DATE(createdDate) = CURDATE()
+		WHERE DATE(createdDate) = CURDATE() AND deleted_at IS NULL
 	`
 	args := []interface{}{}
 
@@ -345,6 +396,7 @@ func GetTodayWalkInCustomers(branchSeq int) (int, error) {
 		FROM customers 
 		WHERE DATE(createdDate) = CURDATE() 
 		AND ad_source = 'walk_in'
+		AND deleted_at IS NULL
 	`
 	args := []interface{}{}
 
@@ -363,17 +415,57 @@ func GetTodayWalkInCustomers(branchSeq int) (int, error) {
 	return count, nil
 }
 
+// CallProcessResult - 통화 처리 결과
+type CallProcessResult struct {
+	CallCount      int
+	LastUpdateDate string
+	Status         string // 통화 처리 후 상태
+	StatusColor    string // 처리 후 상태 표시 색상
+	StatusActive   bool   // 처리 후 상태가 '처리중' 목록에 표시되는 상태인지
+	CallLimit      int    // 지점 통화 횟수 제한 (0이면 제한 없음)
+}
+
 // ProcessCallWithCallerSelection - CALLER 선택 이력 추가 + 통화 횟수 증가 (트랜잭션)
-// caller 선택과 call_count 증가를 하나의 트랜잭션으로 처리
-func ProcessCallWithCallerSelection(customerID, branchSeq int, caller string) (int, string, error) {
+// caller 선택과 call_count 증가를 하나의 트랜잭션으로 처리 (상태가 바뀌면 이력/감사 로그 기록)
+// 통화 횟수가 지점 통화 횟수 제한(branches.call_limit)에 도달하면 '콜수초과'로 변경
+func ProcessCallWithCallerSelection(actor AuditActor, customerID, branchSeq int, caller string) (*CallProcessResult, error) {
 	// 트랜잭션 시작
 	tx, err := DB.Begin()
 	if err != nil {
 		log.Printf("ProcessCallWithCallerSelection - transaction begin error: %v", err)
-		return 0, "", err
+		return nil, err
 	}
 	defer tx.Rollback()
 
+	result, event, err := processCallTx(tx, actor, customerID, branchSeq, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	// 트랜잭션 커밋
+	if err = tx.Commit(); err != nil {
+		log.Printf("ProcessCallWithCallerSelection - transaction commit error: %v", err)
+		return nil, err
+	}
+
+	publishCustomerStatusEvent(event)
+	if result.Status == CustomerStatusCallExceeded && event != nil {
+		log.Printf("[Customer] 콜 횟수 %d회 초과 - 상태를 '콜수초과'로 변경 - CustomerSeq: %d\n", result.CallLimit, customerID)
+	}
+
+	return result, nil
+}
+
+// processCallTx - 트랜잭션 안에서 통화 처리 (ProcessCallWithCallerSelection, 콜백 완료에서 사용)
+// 상태 변경 이벤트는 커밋 후 publishCustomerStatusEvent로 발행해야 함
+// 반환: 통화 처리 결과, 상태 변경 이벤트 (상태가 바뀌지 않았으면 nil), 에러
+func processCallTx(tx *sql.Tx, actor AuditActor, customerID, branchSeq int, caller string) (*CallProcessResult, *CustomerStatusEvent, error) {
+	beforeStatus, err := lockCustomerStatus(tx, customerID)
+	if err != nil {
+		log.Printf("ProcessCallWithCallerSelection - select status error: %v", err)
+		return nil, nil, err
+	}
+
 	// 1. CALLER 선택 이력 저장
 	historyQuery := `
 		INSERT INTO caller_selection_history 
@@ -383,36 +475,29 @@ func ProcessCallWithCallerSelection(customerID, branchSeq int, caller string) (i
 	_, err = tx.Exec(historyQuery, customerID, caller, branchSeq)
 	if err != nil {
 		log.Printf("ProcessCallWithCallerSelection - insert history error: %v", err)
-		return 0, "", err
+		return nil, nil, err
 	}
 
-	// 2. 통화 횟수 증가 및 상태 업데이트
+	// 2. 통화 횟수 증가
 	// - call_count 증가
 	// - lastUpdateDate 업데이트
-	// - call_count >= 5이면 상태를 '콜수초과'로 변경
-	// - call_count < 5이고 현재 상태가 특수 상태가 아니면 '진행중'으로 변경
 	updateQuery := `
 		UPDATE customers 
 		SET 
 			call_count = call_count + 1,
-			lastUpdateDate = NOW(),
-			status = CASE 
-				WHEN call_count + 1 >= 5 THEN '콜수초과'
-				WHEN status = '신규' THEN '진행중'
-				ELSE status
-			END
+			lastUpdateDate = NOW()
 		WHERE seq = ?
 	`
 	result, err := tx.Exec(updateQuery, customerID)
 	if err != nil {
 		log.Printf("ProcessCallWithCallerSelection - update customer error: %v", err)
-		return 0, "", err
+		return nil, nil, err
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
 		log.Printf("ProcessCallWithCallerSelection - no rows affected for customer: %d", customerID)
-		return 0, "", err
+		return nil, nil, sql.ErrNoRows
 	}
 
 	// 3. 업데이트된 call_count와 lastUpdateDate 조회
@@ -422,42 +507,53 @@ func ProcessCallWithCallerSelection(customerID, branchSeq int, caller string) (i
 	err = tx.QueryRow(selectQuery, customerID).Scan(&callCount, &lastUpdateDate)
 	if err != nil {
 		log.Printf("ProcessCallWithCallerSelection - select error: %v", err)
-		return 0, "", err
+		return nil, nil, err
 	}
 
-	// 트랜잭션 커밋
-	if err = tx.Commit(); err != nil {
-		log.Printf("ProcessCallWithCallerSelection - transaction commit error: %v", err)
-		return 0, "", err
+	// 4. 상태 변경 (통화 횟수 제한에 도달하면 '콜수초과', 신규면 '진행중')
+	callLimit, err := customerCallLimitTx(tx, customerID, branchSeq)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	if callCount >= 5 {
-		log.Printf("[Customer] 콜 횟수 5회 초과 - 상태를 '콜수초과'로 변경 - CustomerSeq: %d\n", customerID)
+	defs, err := customerStatusDefsTx(tx)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return callCount, lastUpdateDate, nil
+	afterStatus := statusAfterCall(defs, beforeStatus, callCount, callLimit)
+	reason := fmt.Sprintf("CALLER %s 통화 (%d회)", caller, callCount)
+	event, err := changeCustomerStatusTx(tx, actor, customerID, beforeStatus, afterStatus, reason)
+	if err != nil {
+		log.Printf("ProcessCallWithCallerSelection - status change error: %v", err)
+		return nil, nil, err
+	}
+
+	return &CallProcessResult{
+		CallCount:      callCount,
+		LastUpdateDate: lastUpdateDate,
+		Status:         afterStatus,
+		StatusColor:    lookupCustomerStatusDef(defs, afterStatus).Color,
+		StatusActive:   lookupCustomerStatusDef(defs, afterStatus).IsActive,
+		CallLimit:      callLimit,
+	}, event, nil
 }
 
-// DeleteCustomer - 고객 삭제
-// 파라미터: customerSeq (고객 seq)
+// DeleteCustomer - 고객 삭제 (휴지통으로 이동, 삭제 전 고객 정보를 감사 로그에 기록)
+// 파라미터: actor (작업자), customerSeq (고객 seq)
 // 반환: 에러
-// 참고: reservation_info의 FK는 ON DELETE SET NULL로 설정되어 있어
+// 참고: 휴지통의 고객은 보관 기간이 지나면 PurgeDeletedCustomers로 영구 삭제되며,
 //
-//	고객 삭제 시 자동으로 customer_id가 NULL로 변경됨
-func DeleteCustomer(customerSeq int) error {
+//	영구 삭제 시 reservation_info의 FK(ON DELETE SET NULL)에 따라 customer_id가 NULL로 변경됨
+func DeleteCustomer(actor AuditActor, customerSeq int) error {
 	log.Printf("[Customer] DeleteCustomer 호출 - CustomerSeq: %d\n", customerSeq)
 
-	query := `DELETE FROM customers WHERE seq = ?`
-
-	result, err := DB.Exec(query, customerSeq)
-	if err != nil {
-		log.Printf("DeleteCustomer - delete error: %v", err)
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		var err error
+		rowsAffected, err = deleteCustomerTx(tx, actor, customerSeq)
 		return err
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	})
 	if err != nil {
-		log.Printf("DeleteCustomer - get rows affected error: %v", err)
+		log.Printf("DeleteCustomer - soft delete error: %v", err)
 		return err
 	}
 
@@ -466,13 +562,42 @@ func DeleteCustomer(customerSeq int) error {
 		return nil
 	}
 
-	log.Printf("[Customer] DeleteCustomer 완료 - Rows affected: %d\n", rowsAffected)
+	log.Printf("[Customer] DeleteCustomer 완료 (휴지통 이동) - Rows affected: %d\n", rowsAffected)
 	return nil
 }
 
+// deleteCustomerTx - 트랜잭션 안에서 고객을 휴지통으로 이동하고 감사 로그 기록
+// 반환: 이동된 행 수 (고객이 없거나 이미 휴지통에 있으면 0), 에러
+func deleteCustomerTx(tx *sql.Tx, actor AuditActor, customerSeq int) (int64, error) {
+	before, err := auditSnapshot(tx, `
+		SELECT branch_seq, name, phone_number, comment, commercial_name, ad_source,
+		       call_count, status, createdDate
+		FROM customers WHERE seq = ? AND deleted_at IS NULL FOR UPDATE`, customerSeq)
+	if err != nil || before == nil {
+		return 0, err
+	}
+
+	var deletedBy interface{}
+	if actor.UserSeq > 0 {
+		deletedBy = actor.UserSeq
+	}
+
+	result, err := tx.Exec(`UPDATE customers SET deleted_at = NOW(), deleted_by = ? WHERE seq = ?`, deletedBy, customerSeq)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, recordAudit(tx, actor, AuditCustomerDelete, "customer", customerSeq, before, map[string]interface{}{"trash": true})
+}
+
 // MarkCustomerAsNoPhoneInterview - 고객을 '전화상안함' 상태로 변경하고 CALLER 이력 저장
-// CALLER 선택과 상태 변경, call_count 업데이트를 하나의 트랜잭션으로 처리
-func MarkCustomerAsNoPhoneInterview(customerID, branchSeq int, caller string) error {
+// CALLER 선택과 상태 변경, call_count 업데이트를 하나의 트랜잭션으로 처리 (상태 변경 감사 로그 기록)
+func MarkCustomerAsNoPhoneInterview(actor AuditActor, customerID, branchSeq int, caller string) error {
 	log.Printf("[Customer] MarkCustomerAsNoPhoneInterview 호출 - CustomerID: %d, BranchSeq: %d, Caller: %s\n", customerID, branchSeq, caller)
 
 	// 트랜잭션 시작
@@ -482,11 +607,17 @@ func MarkCustomerAsNoPhoneInterview(customerID, branchSeq int, caller string) er
 		return err
 	}
 	defer tx.Rollback()
+
+	beforeStatus, err := lockCustomerStatus(tx, customerID)
+	if err != nil {
+		log.Printf("MarkCustomerAsNoPhoneInterview - select status error: %v", err)
+		return err
+	}
+
 	// 2. 고객 상태를 '전화상안함' 상태로 변경
 	updateQuery := `
 		UPDATE customers 
 		SET 
-			status = '전화상거절',
 			lastUpdateDate = NOW()
 		WHERE seq = ?
 	`
@@ -502,12 +633,28 @@ func MarkCustomerAsNoPhoneInterview(customerID, branchSeq int, caller string) er
 		return err
 	}
 
+	event, err := changeCustomerStatusTx(tx, actor, customerID, beforeStatus, CustomerStatusRejected, fmt.Sprintf("CALLER %s 전화상안함 처리", caller))
+	if err != nil {
+		log.Printf("MarkCustomerAsNoPhoneInterview - status change error: %v", err)
+		return err
+	}
+
 	// 트랜잭션 커밋
 	if err = tx.Commit(); err != nil {
 		log.Printf("MarkCustomerAsNoPhoneInterview - transaction commit error: %v", err)
 		return err
 	}
 
+	publishCustomerStatusEvent(event)
+
 	log.Printf("[Customer] MarkCustomerAsNoPhoneInterview 완료 - CustomerSeq: %d, Status: 전화상안함\n", customerID)
 	return nil
 }
+
+// lockCustomerStatus - 상태 변경 전 현재 고객 상태 조회 (행 잠금)
+// 반환: 현재 상태, 에러 (고객이 없거나 휴지통에 있으면 sql.ErrNoRows)
+func lockCustomerStatus(tx *sql.Tx, customerSeq int) (string, error) {
+	var status string
+	err := tx.QueryRow(`SELECT status FROM customers WHERE seq = ? AND deleted_at IS NULL FOR UPDATE`, customerSeq).Scan(&status)
+	return status, err
+}
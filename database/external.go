@@ -1,13 +1,16 @@
 package database
 
 import (
+	"backoffice/utils"
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
 )
 
 // InsertExternalCustomer 외부 API를 통한 고객 등록
-func InsertExternalCustomer(branchSeq int, name, phone, adPlatform, adName string) (int64, error) {
+// 같은 전화번호의 기존 고객이 있으면 CUSTOMER_DUPLICATE_POLICY에 따라 처리 (reject면 *DuplicateCustomerError)
+func InsertExternalCustomer(branchSeq int, name, phone, adPlatform, adName string) (*CustomerInsertResult, error) {
 	// 전화번호에서 숫자만 추출
 	cleanPhone := strings.Map(func(r rune) rune {
 		if r >= '0' && r <= '9' {
@@ -18,25 +21,31 @@ func InsertExternalCustomer(branchSeq int, name, phone, adPlatform, adName strin
 
 	// 고객 등록
 	query := `
-		INSERT INTO customers (branch_seq, name, phone_number, commercial_name, ad_source, call_count, status)
-		VALUES (?, ?, ?, ?, ?, 0, '신규')
+		INSERT INTO customers (branch_seq, name, phone_number, phone_normalized, commercial_name, ad_source, call_count, status, duplicate_of)
+		VALUES (?, ?, ?, ?, ?, ?, 0, '신규', ?)
 	`
 
-	result, err := DB.Exec(query, branchSeq, name, cleanPhone, adName, adPlatform)
-	if err != nil {
-		log.Printf("InsertExternalCustomer - 고객 등록 실패: %v", err)
-		return 0, fmt.Errorf("고객 등록에 실패했습니다")
+	incoming := map[string]interface{}{
+		"name":            name,
+		"phone_number":    cleanPhone,
+		"commercial_name": adName,
+		"ad_source":       adPlatform,
 	}
 
-	customerSeq, err := result.LastInsertId()
+	result, err := registerCustomer(&branchSeq, cleanPhone, incoming, func(tx *sql.Tx, phoneNormalized string, duplicateOf interface{}) (sql.Result, error) {
+		return tx.Exec(query, branchSeq, name, cleanPhone, phoneNormalized, adName, adPlatform, duplicateOf)
+	})
 	if err != nil {
-		log.Printf("InsertExternalCustomer - LastInsertId 실패: %v", err)
-		return 0, fmt.Errorf("고객 등록 확인에 실패했습니다")
+		if _, ok := err.(*DuplicateCustomerError); ok {
+			return nil, err
+		}
+		log.Printf("InsertExternalCustomer - 고객 등록 실패: %v", err)
+		return nil, fmt.Errorf("고객 등록에 실패했습니다")
 	}
 
-	log.Printf("InsertExternalCustomer - 고객 등록 성공: seq=%d, branch_seq=%d, name=%s, phone=%s, adPlatform=%s, adName=%s",
-		customerSeq, branchSeq, name, cleanPhone, adPlatform, adName)
-	return customerSeq, nil
+	log.Printf("InsertExternalCustomer - 고객 등록 성공: seq=%d, branch_seq=%d, name=%s, phone=%s, adPlatform=%s, adName=%s, duplicate_of=%d, attached=%t",
+		result.Seq, branchSeq, utils.MaskName(name), utils.MaskPhoneNumber(cleanPhone), adPlatform, adName, result.DuplicateOf, result.Attached)
+	return result, nil
 }
 
 // RegisterExternalCustomer 외부 API를 통한 고객 등록
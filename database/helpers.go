@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // QueryHelper - 쿼리 헬퍼 함수들
@@ -114,3 +115,13 @@ func Count(query string, args ...interface{}) (int, error) {
 	err := DB.QueryRow(query, args...).Scan(&count)
 	return count, err
 }
+
+// inPlaceholders - IN 절용 플레이스홀더와 인자 생성
+// 사용 예: placeholders, args := inPlaceholders([]string{"a", "b"}) // "?, ?", ["a", "b"]
+func inPlaceholders[T any](values []T) (string, []interface{}) {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"backoffice/utils"
 	"database/sql"
 	"fmt"
 	"log"
@@ -380,7 +381,7 @@ func GetSMSSenderNumbers(branchSeq int) ([]string, error) {
 			continue
 		}
 		numbers = append(numbers, number)
-		log.Printf("[DB] GetSMSSenderNumbers - 발신번호 추가: %s", number)
+		log.Printf("[DB] GetSMSSenderNumbers - 발신번호 추가: %s", utils.MaskPhoneNumber(number))
 	}
 
 	log.Printf("[DB] GetSMSSenderNumbers 완료 - %d개 조회", len(numbers))
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// 로그인 시도 결과 (login_attempts.result)
+const (
+	LoginResultSuccess  = "success"  // 로그인 성공
+	LoginResultFailure  = "failure"  // 아이디/비밀번호 불일치
+	LoginResultDisabled = "disabled" // 비활성 계정 로그인 시도
+	LoginResultLocked   = "locked"   // 잠금 상태에서 시도 (실패 횟수에 포함하지 않음)
+	LoginResultUnlock   = "unlock"   // 관리자 잠금 해제 (이전 실패 횟수 초기화)
+)
+
+// LoginResultDisplayNames - 로그인 결과 화면 표시 이름
+var LoginResultDisplayNames = map[string]string{
+	LoginResultSuccess:  "성공",
+	LoginResultFailure:  "실패",
+	LoginResultDisabled: "비활성 계정",
+	LoginResultLocked:   "잠금 중 시도",
+	LoginResultUnlock:   "잠금 해제",
+}
+
+// LoginAttempt - 로그인 시도 이력 구조체
+type LoginAttempt struct {
+	Seq         int
+	UserID      string
+	IPAddress   string
+	UserAgent   string
+	Result      string
+	CreatedBy   string
+	CreatedDate string
+}
+
+// LoginFailureStats - 잠금 판단용 실패 집계
+// 시각 비교는 DB 서버 시간(NOW()) 기준으로 처리해 애플리케이션/DB 시간대 차이 영향을 받지 않음
+type LoginFailureStats struct {
+	Count                   int // 집계 기간 내 실패 횟수 (마지막 성공/잠금 해제 이후)
+	SecondsSinceLastFailure int // 마지막 실패 후 경과 시간 (초, Count가 0이면 0)
+}
+
+// RecordLoginAttempt - 로그인 시도 기록
+// 파라미터: userID (입력한 아이디), ipAddress (클라이언트 IP), userAgent (브라우저 정보), result (시도 결과)
+func RecordLoginAttempt(userID, ipAddress, userAgent, result string) error {
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	query := `INSERT INTO login_attempts (user_id, ip_address, user_agent, result, createdDate)
+	          VALUES (?, ?, ?, ?, NOW())`
+	if _, err := DB.Exec(query, userID, ipAddress, userAgent, result); err != nil {
+		log.Printf("RecordLoginAttempt error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetUserLoginFailureStats - 계정별 실패 집계
+// 최근 windowMinutes 분 동안, 마지막 로그인 성공 또는 잠금 해제 이후의 실패만 집계
+func GetUserLoginFailureStats(userID string, windowMinutes int) (LoginFailureStats, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(TIMESTAMPDIFF(SECOND, MAX(createdDate), NOW()), 0)
+		FROM login_attempts
+		WHERE user_id = ? AND result = ?
+		  AND createdDate > GREATEST(NOW() - INTERVAL ? MINUTE, COALESCE(
+		      (SELECT MAX(createdDate) FROM login_attempts WHERE user_id = ? AND result IN (?, ?)), '1970-01-01'))
+	`
+	return scanLoginFailureStats("GetUserLoginFailureStats", query,
+		userID, LoginResultFailure, windowMinutes, userID, LoginResultSuccess, LoginResultUnlock)
+}
+
+// GetIPLoginFailureStats - IP별 실패 집계
+// 공격자가 자신의 계정으로 성공해 초기화하지 못하도록 로그인 성공은 무시하고 잠금 해제만 반영
+func GetIPLoginFailureStats(ipAddress string, windowMinutes int) (LoginFailureStats, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(TIMESTAMPDIFF(SECOND, MAX(createdDate), NOW()), 0)
+		FROM login_attempts
+		WHERE ip_address = ? AND result = ?
+		  AND createdDate > GREATEST(NOW() - INTERVAL ? MINUTE, COALESCE(
+		      (SELECT MAX(createdDate) FROM login_attempts WHERE ip_address = ? AND result = ?), '1970-01-01'))
+	`
+	return scanLoginFailureStats("GetIPLoginFailureStats", query,
+		ipAddress, LoginResultFailure, windowMinutes, ipAddress, LoginResultUnlock)
+}
+
+// scanLoginFailureStats - 실패 집계 쿼리 실행 공통 처리
+func scanLoginFailureStats(funcName, query string, args ...interface{}) (LoginFailureStats, error) {
+	var stats LoginFailureStats
+	if err := DB.QueryRow(query, args...).Scan(&stats.Count, &stats.SecondsSinceLastFailure); err != nil {
+		log.Printf("%s error: %v", funcName, err)
+		return stats, err
+	}
+	return stats, nil
+}
+
+// UnlockLogin - 관리자 잠금 해제 (unlock 기록 이전의 실패는 집계에서 제외됨)
+// 파라미터: userID (해제할 아이디, 빈 문자열이면 IP만), ipAddress (해제할 IP, 빈 문자열이면 계정만), unlockedBy (처리한 관리자 아이디)
+func UnlockLogin(userID, ipAddress, unlockedBy string) error {
+	query := `INSERT INTO login_attempts (user_id, ip_address, result, created_by, createdDate)
+	          VALUES (?, ?, ?, ?, NOW())`
+	if _, err := DB.Exec(query, userID, ipAddress, LoginResultUnlock, unlockedBy); err != nil {
+		log.Printf("UnlockLogin error: %v", err)
+		return err
+	}
+
+	log.Printf("UnlockLogin success - UserID: %s, IP: %s, By: %s", userID, ipAddress, unlockedBy)
+	return nil
+}
+
+// buildLoginAttemptFilterConditions - 로그인 이력 검색 조건 생성
+func buildLoginAttemptFilterConditions(userID, ipAddress, result string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if userID != "" {
+		conditions = append(conditions, "user_id LIKE ?")
+		args = append(args, "%"+userID+"%")
+	}
+
+	if ipAddress != "" {
+		conditions = append(conditions, "ip_address LIKE ?")
+		args = append(args, ipAddress+"%")
+	}
+
+	if result != "" {
+		conditions = append(conditions, "result = ?")
+		args = append(args, result)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return whereClause, args
+}
+
+// GetLoginAttemptsCount - 로그인 이력 건수 조회
+// 파라미터: userID (아이디 검색어), ipAddress (IP 접두어), result (결과 필터, 빈 문자열이면 전체)
+func GetLoginAttemptsCount(userID, ipAddress, result string) (int, error) {
+	whereClause, args := buildLoginAttemptFilterConditions(userID, ipAddress, result)
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM login_attempts %s`, whereClause)
+
+	var count int
+	if err := DB.QueryRow(query, args...).Scan(&count); err != nil {
+		log.Printf("GetLoginAttemptsCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetLoginAttempts - 로그인 이력 목록 조회 (최신순, 페이징)
+// 파라미터: userID (아이디 검색어), ipAddress (IP 접두어), result (결과 필터), page (페이지 번호), itemsPerPage (페이지당 항목 수)
+func GetLoginAttempts(userID, ipAddress, result string, page, itemsPerPage int) ([]LoginAttempt, error) {
+	whereClause, args := buildLoginAttemptFilterConditions(userID, ipAddress, result)
+	offset := (page - 1) * itemsPerPage
+
+	query := fmt.Sprintf(`
+		SELECT seq, user_id, ip_address, COALESCE(user_agent, ''), result, COALESCE(created_by, ''),
+		       DATE_FORMAT(createdDate, '%%Y-%%m-%%d %%H:%%i:%%s')
+		FROM login_attempts
+		%s
+		ORDER BY createdDate DESC, seq DESC
+		LIMIT ? OFFSET ?
+	`, whereClause)
+
+	args = append(args, itemsPerPage, offset)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("GetLoginAttempts error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []LoginAttempt
+	for rows.Next() {
+		var a LoginAttempt
+		if err := rows.Scan(&a.Seq, &a.UserID, &a.IPAddress, &a.UserAgent, &a.Result, &a.CreatedBy, &a.CreatedDate); err != nil {
+			log.Printf("GetLoginAttempts scan error: %v", err)
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+
+	return attempts, nil
+}
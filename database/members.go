@@ -1,6 +1,7 @@
 package database
 
 import (
+	"backoffice/utils"
 	"database/sql"
 	"fmt"
 	"log"
@@ -25,7 +26,7 @@ func GetCustomerByKakaoID(kakaoID int64) (*KakaoCustomer, error) {
 	query := `SELECT c.seq, c.branch_seq, COALESCE(b.branchName, ''), c.name, c.phone_number, c.kakao_id, c.createdDate
 	          FROM customers c
 	          LEFT JOIN branches b ON c.branch_seq = b.seq
-	          WHERE c.kakao_id = ?`
+	          WHERE c.kakao_id = ? AND c.deleted_at IS NULL`
 
 	err := DB.QueryRow(query, kakaoID).Scan(
 		&customer.Seq,
@@ -53,7 +54,7 @@ func GetCustomerBySeqForMypage(seq int) (*KakaoCustomer, error) {
 	query := `SELECT c.seq, c.branch_seq, COALESCE(b.branchName, ''), c.name, c.phone_number, COALESCE(c.kakao_id, 0), c.createdDate
 	          FROM customers c
 	          LEFT JOIN branches b ON c.branch_seq = b.seq
-	          WHERE c.seq = ?`
+	          WHERE c.seq = ? AND c.deleted_at IS NULL`
 
 	err := DB.QueryRow(query, seq).Scan(
 		&customer.Seq,
@@ -92,32 +93,55 @@ func UpsertKakaoCustomer(branchSeq int, kakaoID int64, name, phoneNumber string)
 	existing, err := GetCustomerByKakaoID(kakaoID)
 	if err == nil && existing != nil {
 		// 이미 존재 → 이름/전화번호 업데이트
-		updateQuery := `UPDATE customers SET name = ?, phone_number = ?, lastUpdateDate = NOW() WHERE kakao_id = ?`
-		_, err = DB.Exec(updateQuery, name, cleanPhone, kakaoID)
+		updateQuery := `UPDATE customers SET name = ?, phone_number = ?, phone_normalized = ?, lastUpdateDate = NOW() WHERE kakao_id = ? AND deleted_at IS NULL`
+		_, err = DB.Exec(updateQuery, name, cleanPhone, utils.NormalizePhoneNumber(cleanPhone), kakaoID)
 		if err != nil {
 			log.Printf("카카오 고객 업데이트 실패: %v", err)
 			return 0, false, err
 		}
-		log.Printf("카카오 고객 업데이트 - seq: %d, 이름: %s", existing.Seq, name)
+		log.Printf("카카오 고객 업데이트 - seq: %d, 이름: %s", existing.Seq, utils.MaskName(name))
 		return existing.Seq, false, nil
 	}
 
-	// 신규 등록
-	insertQuery := `INSERT INTO customers (branch_seq, name, phone_number, ad_source, kakao_id, call_count, status)
-	                VALUES (?, ?, ?, '카카오', ?, 0, '신규')`
-	result, err := DB.Exec(insertQuery, branchSeq, name, cleanPhone, kakaoID)
+	// 휴지통에 있는 고객의 카카오 ID 연결 해제 (kakao_id UNIQUE 제약 때문에 신규 등록이 실패하지 않도록)
+	if _, err := DB.Exec(`UPDATE customers SET kakao_id = NULL WHERE kakao_id = ? AND deleted_at IS NOT NULL`, kakaoID); err != nil {
+		log.Printf("휴지통 고객 카카오 ID 해제 실패: %v", err)
+		return 0, false, err
+	}
+
+	// 신규 등록 (같은 전화번호 고객이 있으면 CUSTOMER_DUPLICATE_POLICY 적용)
+	insertQuery := `INSERT INTO customers (branch_seq, name, phone_number, phone_normalized, ad_source, kakao_id, call_count, status, duplicate_of)
+	                VALUES (?, ?, ?, ?, '카카오', ?, 0, '신규', ?)`
+	incoming := map[string]interface{}{
+		"name":         name,
+		"phone_number": cleanPhone,
+		"ad_source":    "카카오",
+		"kakao_id":     kakaoID,
+	}
+	result, err := registerCustomer(&branchSeq, cleanPhone, incoming, func(tx *sql.Tx, phoneNormalized string, duplicateOf interface{}) (sql.Result, error) {
+		return tx.Exec(insertQuery, branchSeq, name, cleanPhone, phoneNormalized, kakaoID, duplicateOf)
+	})
 	if err != nil {
 		log.Printf("카카오 고객 등록 실패: %v", err)
+		if _, ok := err.(*DuplicateCustomerError); ok {
+			return 0, false, err
+		}
 		return 0, false, fmt.Errorf("고객 등록에 실패했습니다: %w", err)
 	}
 
-	customerSeq, err := result.LastInsertId()
-	if err != nil {
-		return 0, false, err
+	// 기존 고객에 연결된 경우 카카오 ID가 비어 있으면 연결 (다음 로그인부터 같은 고객으로 조회)
+	if result.Attached {
+		linkQuery := `UPDATE customers SET kakao_id = ?, lastUpdateDate = NOW() WHERE seq = ? AND kakao_id IS NULL`
+		if _, err := DB.Exec(linkQuery, kakaoID, result.Seq); err != nil {
+			log.Printf("카카오 ID 연결 실패 - seq: %d, error: %v", result.Seq, err)
+			return 0, false, err
+		}
+		log.Printf("카카오 고객 기존 고객에 연결 - seq: %d, 이름: %s, kakao_id: %d", result.Seq, utils.MaskName(name), kakaoID)
+		return int(result.Seq), false, nil
 	}
 
-	log.Printf("카카오 고객 신규 등록 - seq: %d, 이름: %s, kakao_id: %d", customerSeq, name, kakaoID)
-	return int(customerSeq), true, nil
+	log.Printf("카카오 고객 신규 등록 - seq: %d, 이름: %s, kakao_id: %d", result.Seq, utils.MaskName(name), kakaoID)
+	return int(result.Seq), true, nil
 }
 
 // DeleteCustomerBySeq - 고객 삭제 (회원탈퇴)
@@ -318,8 +318,8 @@ func DeleteMessageTemplate(branchSeq, id int) error {
 	return nil
 }
 
-// SetDefaultMessageTemplate 기본 템플릿 설정
-func SetDefaultMessageTemplate(branchSeq, id int) error {
+// SetDefaultMessageTemplate 기본 템플릿 설정 (이전 기본 템플릿을 감사 로그에 기록)
+func SetDefaultMessageTemplate(actor AuditActor, branchSeq, id int) error {
 	log.Printf("[MessageTemplate] SetDefaultMessageTemplate 호출 - BranchSeq: %d, ID: %d\n", branchSeq, id)
 
 	// 해당 템플릿이 해당 지점 소유인지 확인하고 활성화 상태 체크
@@ -357,6 +357,14 @@ func SetDefaultMessageTemplate(branchSeq, id int) error {
 		}
 	}()
 
+	// 이전 기본 템플릿 조회 (감사 로그용)
+	var before map[string]interface{}
+	before, txErr = auditSnapshot(tx, `SELECT seq AS template_seq, template_name FROM message_templates WHERE branch_seq = ? AND is_default = 1 LIMIT 1`, branchSeq)
+	if txErr != nil {
+		log.Printf("SetDefaultMessageTemplate - before snapshot error: %v", txErr)
+		return txErr
+	}
+
 	// 4단계: 해당 지점의 모든 템플릿 is_default를 0으로 설정
 	updateAllQuery := `UPDATE message_templates SET is_default = 0 WHERE branch_seq = ?`
 	_, txErr = tx.Exec(updateAllQuery, branchSeq)
@@ -373,6 +381,12 @@ func SetDefaultMessageTemplate(branchSeq, id int) error {
 		return txErr
 	}
 
+	txErr = recordAudit(tx, actor, AuditTemplateSetDefault, "message_template", id,
+		before, map[string]interface{}{"branch_seq": branchSeq, "template_seq": id})
+	if txErr != nil {
+		return txErr
+	}
+
 	// 6단계: 트랜잭션 커밋
 	txErr = tx.Commit()
 	if txErr != nil {
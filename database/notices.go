@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
@@ -173,22 +174,37 @@ func IncrementNoticeViewCount(id int) error {
 	return err
 }
 
-// InsertNotice - 공지사항/이벤트 등록
-func InsertNotice(branchSeq int, title, content, category string, isPinned bool, eventStartDate, eventEndDate, createdBy string) (int64, error) {
+// noticeAuditColumns - 공지사항 감사 로그에 기록할 컬럼
+const noticeAuditColumns = `SELECT branch_seq, title, content, category, is_pinned, is_active, event_start_date, event_end_date
+	FROM notices WHERE seq = ?`
+
+// InsertNotice - 공지사항/이벤트 등록 (감사 로그 기록)
+func InsertNotice(actor AuditActor, branchSeq int, title, content, category string, isPinned bool, eventStartDate, eventEndDate, createdBy string) (int64, error) {
 	query := `
 		INSERT INTO notices (branch_seq, title, content, category, is_pinned, event_start_date, event_end_date, created_by, createdDate)
 		VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), NOW())
 	`
 
-	result, err := DB.Exec(query, branchSeq, title, content, category, isPinned, eventStartDate, eventEndDate, createdBy)
-	if err != nil {
-		log.Printf("InsertNotice error: %v", err)
-		return 0, err
-	}
+	var id int64
+	err := Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, branchSeq, title, content, category, isPinned, eventStartDate, eventEndDate, createdBy)
+		if err != nil {
+			return err
+		}
+
+		id, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
 
-	id, err := result.LastInsertId()
+		after, err := auditSnapshot(tx, noticeAuditColumns, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(tx, actor, AuditNoticeCreate, "notice", id, nil, after)
+	})
 	if err != nil {
-		log.Printf("InsertNotice get last insert id error: %v", err)
+		log.Printf("InsertNotice error: %v", err)
 		return 0, err
 	}
 
@@ -196,8 +212,8 @@ func InsertNotice(branchSeq int, title, content, category string, isPinned bool,
 	return id, nil
 }
 
-// UpdateNotice - 공지사항/이벤트 수정
-func UpdateNotice(id int, title, content, category string, isPinned bool, eventStartDate, eventEndDate string) (int64, error) {
+// UpdateNotice - 공지사항/이벤트 수정 (수정 전/후 값을 감사 로그에 기록)
+func UpdateNotice(actor AuditActor, id int, title, content, category string, isPinned bool, eventStartDate, eventEndDate string) (int64, error) {
 	query := `
 		UPDATE notices 
 		SET title = ?, content = ?, category = ?, is_pinned = ?, 
@@ -205,15 +221,31 @@ func UpdateNotice(id int, title, content, category string, isPinned bool, eventS
 		WHERE seq = ?
 	`
 
-	result, err := DB.Exec(query, title, content, category, isPinned, eventStartDate, eventEndDate, id)
-	if err != nil {
-		log.Printf("UpdateNotice error: %v", err)
-		return 0, err
-	}
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, noticeAuditColumns+" FOR UPDATE", id)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
+		result, err := tx.Exec(query, title, content, category, isPinned, eventStartDate, eventEndDate, id)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil || before == nil {
+			return err
+		}
+
+		after, err := auditSnapshot(tx, noticeAuditColumns, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(tx, actor, AuditNoticeUpdate, "notice", id, before, after)
+	})
 	if err != nil {
-		log.Printf("UpdateNotice get rows affected error: %v", err)
+		log.Printf("UpdateNotice error: %v", err)
 		return 0, err
 	}
 
@@ -221,19 +253,29 @@ func UpdateNotice(id int, title, content, category string, isPinned bool, eventS
 	return rowsAffected, nil
 }
 
-// DeleteNotice - 공지사항/이벤트 삭제 (소프트 삭제)
-func DeleteNotice(id int) (int64, error) {
-	query := `UPDATE notices SET is_active = 0 WHERE seq = ?`
+// DeleteNotice - 공지사항/이벤트 삭제 (소프트 삭제, 감사 로그 기록)
+func DeleteNotice(actor AuditActor, id int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, noticeAuditColumns+" FOR UPDATE", id)
+		if err != nil {
+			return err
+		}
 
-	result, err := DB.Exec(query, id)
-	if err != nil {
-		log.Printf("DeleteNotice error: %v", err)
-		return 0, err
-	}
+		result, err := tx.Exec(`UPDATE notices SET is_active = 0 WHERE seq = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil || rowsAffected == 0 {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
+		return recordAudit(tx, actor, AuditNoticeDelete, "notice", id, before, map[string]interface{}{"is_active": 0})
+	})
 	if err != nil {
-		log.Printf("DeleteNotice get rows affected error: %v", err)
+		log.Printf("DeleteNotice error: %v", err)
 		return 0, err
 	}
 
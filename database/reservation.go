@@ -1,14 +1,15 @@
 package database
 
 import (
+	"fmt"
 	"log"
 	"time"
 )
 
-// CreateReservation - 예약 정보 생성
-// 파라미터: branchSeq - 지점 seq, customerSeq - 고객 seq, userSeq - 사용자 seq, caller - 호출자 구분, interviewDate - 상담 일시
+// CreateReservation - 예약 정보 생성 (고객 상태가 바뀌면 감사 로그 기록)
+// 파라미터: actor - 작업자, branchSeq - 지점 seq, customerSeq - 고객 seq, userSeq - 사용자 seq, caller - 호출자 구분, interviewDate - 상담 일시
 // 반환: 생성된 예약 ID, 에러
-func CreateReservation(branchSeq, customerSeq, userSeq int, caller string, interviewDate time.Time) (int64, error) {
+func CreateReservation(actor AuditActor, branchSeq, customerSeq, userSeq int, caller string, interviewDate time.Time) (int64, error) {
 	log.Printf("[Reservation] CreateReservation 호출 - BranchSeq: %d, CustomerSeq: %d, UserSeq: %d, Caller: %s, InterviewDate: %v (Location: %s)\n",
 		branchSeq, customerSeq, userSeq, caller, interviewDate, interviewDate.Location())
 
@@ -21,6 +22,12 @@ func CreateReservation(branchSeq, customerSeq, userSeq int, caller string, inter
 
 	defer tx.Rollback()
 
+	beforeStatus, err := lockCustomerStatus(tx, customerSeq)
+	if err != nil {
+		log.Printf("CreateReservation - select status error: %v", err)
+		return 0, err
+	}
+
 	// 한국 시간대로 포맷팅 (time.Time을 직접 전달하면 UTC로 변환되므로)
 	interviewDateStr := interviewDate.Format("2006-01-02 15:04:05")
 	log.Printf("[Reservation] DB 저장용 날짜 문자열: %s\n", interviewDateStr)
@@ -44,9 +51,8 @@ func CreateReservation(branchSeq, customerSeq, userSeq int, caller string, inter
 		return 0, err
 	}
 
-	// 2. 고객 상태를 '예약확정'으로 업데이트
-	updateQuery := `UPDATE customers SET status = '예약확정' WHERE seq = ?`
-	_, err = tx.Exec(updateQuery, customerSeq)
+	// 2. 고객 상태를 '예약확정'으로 변경
+	event, err := changeCustomerStatusTx(tx, actor, customerSeq, beforeStatus, CustomerStatusReserved, fmt.Sprintf("상담 예약 등록 (%s)", interviewDateStr))
 	if err != nil {
 		log.Printf("CreateReservation - failed to update customer status: %v", err)
 		return 0, err
@@ -58,6 +64,7 @@ func CreateReservation(branchSeq, customerSeq, userSeq int, caller string, inter
 		return 0, err
 	}
 
+	publishCustomerStatusEvent(event)
 	log.Printf("[Reservation] CreateReservation 완료 - ID: %d, 고객 상태: 예약확정\n", id)
 	return id, nil
 }
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"backoffice/utils"
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"encoding/gob"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// activityUpdateInterval - 마지막 활동 시각 갱신 최소 간격 (초)
+// 요청마다 UPDATE 하지 않도록 이 시간이 지난 경우에만 갱신
+const activityUpdateInterval = 60
+
+// defaultSessionLifetime - MaxAge가 0(브라우저 세션 쿠키)일 때 DB 보관 기간 (초)
+const defaultSessionLifetime = 86400
+
+// SessionStore - MySQL 기반 서버 세션 스토어 (gorilla sessions.Store 구현)
+// 쿠키에는 서명된 세션 ID만 저장하고 세션 값은 user_sessions 테이블에 보관
+type SessionStore struct {
+	Codecs      []securecookie.Codec
+	Options     *sessions.Options
+	IdleTimeout time.Duration // 마지막 활동 후 이 시간이 지나면 세션 만료 (0이면 사용 안 함)
+}
+
+// NewSessionStore - 서버 세션 스토어 생성
+// 파라미터: secret (세션 ID 쿠키 서명 키), options (쿠키 옵션), idleTimeout (유휴 만료 시간)
+func NewSessionStore(secret []byte, options *sessions.Options, idleTimeout time.Duration) sessions.Store {
+	store := &SessionStore{
+		Codecs:      securecookie.CodecsFromPairs(secret),
+		Options:     options,
+		IdleTimeout: idleTimeout,
+	}
+
+	// 서명 타임스탬프 유효기간을 쿠키 MaxAge와 맞춤
+	if options.MaxAge > 0 {
+		for _, codec := range store.Codecs {
+			if sc, ok := codec.(*securecookie.SecureCookie); ok {
+				sc.MaxAge(options.MaxAge)
+			}
+		}
+	}
+
+	return store
+}
+
+// Get - 요청 단위로 캐시된 세션 반환
+func (s *SessionStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New - 쿠키의 세션 ID로 DB에서 세션을 불러오고, 없거나 만료되었으면 새 세션 반환
+func (s *SessionStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	opts := *s.Options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var sessionID string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &sessionID, s.Codecs...); err != nil {
+		// 변조되었거나 이전 쿠키 스토어 형식의 쿠키는 새 세션으로 처리
+		return session, nil
+	}
+
+	found, err := s.load(session, sessionID)
+	if err != nil {
+		return session, err
+	}
+	if found {
+		session.ID = sessionID
+		session.IsNew = false
+	}
+
+	return session, nil
+}
+
+// load - DB에서 세션 값 조회 (만료 또는 유휴 시간 초과 시 삭제 후 false)
+func (s *SessionStore) load(session *sessions.Session, sessionID string) (bool, error) {
+	query := `
+		SELECT data, TIMESTAMPDIFF(SECOND, last_activity, NOW())
+		FROM user_sessions
+		WHERE session_id = ? AND session_name = ? AND expires_at > NOW()
+	`
+
+	var data []byte
+	var idleSeconds int
+	err := DB.QueryRow(query, sessionID, session.Name()).Scan(&data, &idleSeconds)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("SessionStore load error: %v", err)
+		return false, err
+	}
+
+	if s.IdleTimeout > 0 && time.Duration(idleSeconds)*time.Second > s.IdleTimeout {
+		log.Printf("세션 유휴 시간 초과 - 세션: %s, 유휴: %d초", session.Name(), idleSeconds)
+		DeleteSession(sessionID)
+		return false, nil
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&session.Values); err != nil {
+		log.Printf("SessionStore decode error: %v", err)
+		DeleteSession(sessionID)
+		return false, nil
+	}
+
+	if idleSeconds >= activityUpdateInterval {
+		if _, err := DB.Exec(`UPDATE user_sessions SET last_activity = NOW() WHERE session_id = ?`, sessionID); err != nil {
+			log.Printf("SessionStore touch error: %v", err)
+		}
+	}
+
+	return true, nil
+}
+
+// Save - 세션 값을 DB에 저장하고 세션 ID 쿠키 발급
+// MaxAge < 0 이면 DB 세션과 쿠키를 모두 삭제
+func (s *SessionStore) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := DeleteSession(session.ID); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		session.ID = newSessionID()
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session.Values); err != nil {
+		log.Printf("SessionStore encode error: %v", err)
+		return err
+	}
+
+	lifetime := session.Options.MaxAge
+	if lifetime == 0 {
+		lifetime = defaultSessionLifetime
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	query := `
+		INSERT INTO user_sessions
+			(session_id, session_name, user_seq, data, ip_address, user_agent, createdDate, last_activity, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW(), NOW() + INTERVAL ? SECOND)
+		ON DUPLICATE KEY UPDATE
+			user_seq = VALUES(user_seq), data = VALUES(data),
+			last_activity = NOW(), expires_at = VALUES(expires_at)
+	`
+	_, err := DB.Exec(query, session.ID, session.Name(), sessionUserSeq(session.Values),
+		buf.Bytes(), utils.GetClientIP(r), userAgent, lifetime)
+	if err != nil {
+		log.Printf("SessionStore save error: %v", err)
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.Codecs...)
+	if err != nil {
+		log.Printf("SessionStore cookie encode error: %v", err)
+		return err
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// newSessionID - 추측 불가능한 세션 ID 생성 (32바이트 난수, base32)
+func newSessionID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return strings.TrimRight(base32.StdEncoding.EncodeToString(b), "=")
+}
+
+// sessionUserSeq - 로그인이 완료된 세션의 사용자 seq 추출 (그 외에는 nil)
+func sessionUserSeq(values map[interface{}]interface{}) interface{} {
+	if authenticated, ok := values["authenticated"].(bool); !ok || !authenticated {
+		return nil
+	}
+	if userSeq, ok := values["user_seq"].(int); ok {
+		return userSeq
+	}
+	return nil
+}
@@ -120,13 +120,14 @@ func GetSMSConfig(branchSeq int) (*SMSConfig, error) {
 	return config, nil
 }
 
-// SaveSMSConfig SMS 설정 저장 (INSERT 또는 UPDATE)
-func SaveSMSConfig(branchSeq int, accountID, password string, senderPhones []string, isActive bool, remainingCountSMS, remainingCountLMS *int) error {
+// SaveSMSConfig SMS 설정 저장 (INSERT 또는 UPDATE, 감사 로그 기록)
+// 감사 로그에는 비밀번호 대신 변경 여부만 남김
+func SaveSMSConfig(actor AuditActor, branchSeq int, accountID, password string, senderPhones []string, isActive bool, remainingCountSMS, remainingCountLMS *int) error {
 	log.Println("=== SMS 설정 저장 ===")
 	log.Printf("지점 seq: %d", branchSeq)
 	log.Printf("계정 ID: %s", accountID)
 	log.Printf("비밀번호: %s", utils.MaskPassword(password))
-	log.Printf("발신번호: %v", senderPhones)
+	log.Printf("발신번호 수: %d", len(senderPhones))
 	log.Printf("활성화: %v", isActive)
 	if remainingCountSMS != nil {
 		log.Printf("SMS 잔여건수: %d", *remainingCountSMS)
@@ -155,6 +156,22 @@ func SaveSMSConfig(branchSeq int, accountID, password string, senderPhones []str
 		}
 	}()
 
+	// 변경 전 설정 조회 (감사 로그용)
+	var before map[string]interface{}
+	before, err = auditSnapshot(tx, `
+		SELECT m.is_active, c.mymunja_id, c.callback_number, c.remaining_count_sms, c.remaining_count_lms,
+		       c.mymunja_password = ? AS password_unchanged
+		FROM `+"`branch-third-party-mapping`"+` m
+		LEFT JOIN mymunja_config_info c ON c.mapping_id = m.mapping_seq
+		WHERE m.branch_id = ? AND m.third_party_id = ?
+		FOR UPDATE`, password, branchSeq, serviceSeq)
+	if err != nil {
+		log.Printf("SaveSMSConfig - before snapshot error: %v", err)
+		return err
+	}
+	passwordChanged := before == nil || before["password_unchanged"] != "1"
+	delete(before, "password_unchanged")
+
 	// 3단계: branch-third-party-mapping UPSERT (UNIQUE KEY: branch_id, third_party_id)
 	upsertMappingQuery := `
 		INSERT INTO ` + "`branch-third-party-mapping`" + ` 
@@ -262,6 +279,22 @@ func SaveSMSConfig(branchSeq int, accountID, password string, senderPhones []str
 	}
 	log.Printf("SaveSMSConfig - config upserted for mapping: %d", mappingSeq)
 
+	after := map[string]interface{}{
+		"is_active":        isActive,
+		"mymunja_id":       accountID,
+		"callback_number":  callbackNumber,
+		"password_changed": passwordChanged,
+	}
+	if remainingCountSMS != nil {
+		after["remaining_count_sms"] = *remainingCountSMS
+	}
+	if remainingCountLMS != nil {
+		after["remaining_count_lms"] = *remainingCountLMS
+	}
+	if err = recordAudit(tx, actor, AuditSMSConfigSave, "sms_config", branchSeq, before, after); err != nil {
+		return err
+	}
+
 	// 트랜잭션 커밋
 	if err = tx.Commit(); err != nil {
 		log.Printf("SaveSMSConfig - commit error: %v", err)
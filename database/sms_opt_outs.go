@@ -0,0 +1,268 @@
+package database
+
+import (
+	"backoffice/utils"
+	"database/sql"
+	"log"
+)
+
+// 수신거부 등록 경로
+const (
+	SMSOptOutSourceManual = "manual" // 직원 등록
+	SMSOptOutSource080    = "080"    // 080 수신거부 목록 가져오기
+)
+
+// SMSOptOutSourceNames - 수신거부 등록 경로 화면 표시 이름
+var SMSOptOutSourceNames = map[string]string{
+	SMSOptOutSourceManual: "직원 등록",
+	SMSOptOutSource080:    "080 수신거부",
+}
+
+// SMSOptOut - 지점 문자 수신거부 번호
+type SMSOptOut struct {
+	Seq          int
+	BranchSeq    int
+	PhoneNumber  string // 숫자만 남긴 번호
+	Reason       string
+	Source       string // SMSOptOutSourceManual, SMSOptOutSource080
+	OptedOutDate string // 수신거부 일자 (YYYY-MM-DD)
+	UserID       string // 등록한 사용자 아이디 (없으면 빈 문자열)
+	CreatedDate  string
+}
+
+// SMSOptOutEntry - 수신거부 등록/가져오기 입력
+type SMSOptOutEntry struct {
+	PhoneNumber  string
+	Reason       string
+	OptedOutDate string // YYYY-MM-DD (빈 문자열이면 오늘)
+}
+
+// smsOptOutSearchCondition - 수신거부 목록 조회 조건 (phone이 있으면 숫자 부분 일치)
+func smsOptOutSearchCondition(branchSeq int, phone string) (string, []interface{}) {
+	condition := `o.branch_seq = ?`
+	args := []interface{}{branchSeq}
+	if digits := utils.NormalizePhoneNumber(phone); digits != "" {
+		condition += ` AND o.phone_number LIKE ?`
+		args = append(args, "%"+digits+"%")
+	}
+	return condition, args
+}
+
+// GetSMSOptOutsCount - 지점 수신거부 번호 수
+// 파라미터: branchSeq (지점 seq), phone (번호 검색어, 빈 문자열이면 전체)
+func GetSMSOptOutsCount(branchSeq int, phone string) (int, error) {
+	condition, args := smsOptOutSearchCondition(branchSeq, phone)
+	count, err := Count(`SELECT COUNT(*) FROM sms_opt_outs o WHERE `+condition, args...)
+	if err != nil {
+		log.Printf("GetSMSOptOutsCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetSMSOptOuts - 지점 수신거부 번호 목록 (최근 등록순, 페이징 적용)
+// 파라미터: branchSeq (지점 seq), phone (번호 검색어, 빈 문자열이면 전체), page, itemsPerPage
+func GetSMSOptOuts(branchSeq int, phone string, page, itemsPerPage int) ([]SMSOptOut, error) {
+	condition, args := smsOptOutSearchCondition(branchSeq, phone)
+	args = append(args, itemsPerPage, (page-1)*itemsPerPage)
+
+	rows, err := DB.Query(`
+		SELECT o.seq, o.branch_seq, o.phone_number, o.reason, o.source,
+		       DATE_FORMAT(o.opted_out_date, '%Y-%m-%d'), o.user_id,
+		       DATE_FORMAT(o.createdDate, '%Y-%m-%d %H:%i')
+		FROM sms_opt_outs o
+		WHERE `+condition+`
+		ORDER BY o.createdDate DESC, o.seq DESC
+		LIMIT ? OFFSET ?
+	`, args...)
+	if err != nil {
+		log.Printf("GetSMSOptOuts error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var optOuts []SMSOptOut
+	for rows.Next() {
+		var o SMSOptOut
+		if err := rows.Scan(&o.Seq, &o.BranchSeq, &o.PhoneNumber, &o.Reason, &o.Source, &o.OptedOutDate, &o.UserID, &o.CreatedDate); err != nil {
+			log.Printf("GetSMSOptOuts scan error: %v", err)
+			return nil, err
+		}
+		optOuts = append(optOuts, o)
+	}
+	return optOuts, rows.Err()
+}
+
+// IsSMSOptedOut - 수신거부 번호인지 확인 (sms.Send 발송 전 확인)
+// 파라미터: branchSeq (지점 seq, 0이면 전체 지점 수신거부 목록 확인), phone (번호, 형식 무관)
+func IsSMSOptedOut(branchSeq int, phone string) (bool, error) {
+	digits := utils.NormalizePhoneNumber(phone)
+	if digits == "" {
+		return false, nil
+	}
+
+	query := `SELECT COUNT(*) FROM sms_opt_outs WHERE phone_number = ?`
+	args := []interface{}{digits}
+	if branchSeq > 0 {
+		query += ` AND branch_seq = ?`
+		args = append(args, branchSeq)
+	}
+
+	count, err := Count(query, args...)
+	if err != nil {
+		log.Printf("IsSMSOptedOut error: %v", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetSMSOptedOutPhones - 번호 목록 중 지점 수신거부 번호 (일괄 발송 대상 제외용)
+// 파라미터: branchSeq (지점 seq), phones (번호 목록, 형식 무관)
+// 반환: 수신거부 번호 집합 (키는 utils.NormalizePhoneNumber 결과), 에러
+func GetSMSOptedOutPhones(branchSeq int, phones []string) (map[string]bool, error) {
+	optedOut := map[string]bool{}
+	var digits []string
+	for _, phone := range phones {
+		if d := utils.NormalizePhoneNumber(phone); d != "" {
+			digits = append(digits, d)
+		}
+	}
+	if len(digits) == 0 {
+		return optedOut, nil
+	}
+
+	placeholders, phoneArgs := inPlaceholders(digits)
+	args := append([]interface{}{branchSeq}, phoneArgs...)
+	rows, err := DB.Query(`
+		SELECT phone_number FROM sms_opt_outs
+		WHERE branch_seq = ? AND phone_number IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		log.Printf("GetSMSOptedOutPhones error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			log.Printf("GetSMSOptedOutPhones scan error: %v", err)
+			return nil, err
+		}
+		optedOut[phone] = true
+	}
+	return optedOut, rows.Err()
+}
+
+// insertSMSOptOutTx - 트랜잭션 안에서 수신거부 번호 등록 (이미 등록된 번호는 그대로 두고 0 반환)
+func insertSMSOptOutTx(tx *sql.Tx, actor AuditActor, branchSeq int, entry SMSOptOutEntry, source string) (int64, error) {
+	result, err := tx.Exec(`
+		INSERT IGNORE INTO sms_opt_outs (branch_seq, phone_number, reason, source, opted_out_date, user_seq, user_id, createdDate)
+		VALUES (?, ?, ?, ?, COALESCE(NULLIF(?, ''), CURDATE()), ?, ?, NOW())
+	`, branchSeq, utils.NormalizePhoneNumber(entry.PhoneNumber), entry.Reason, source, entry.OptedOutDate, actorUserSeq(actor), actor.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// AddSMSOptOut - 지점 수신거부 번호 등록 (감사 로그 기록)
+// 파라미터: actor (작업자), branchSeq (지점 seq), entry (번호/사유/수신거부 일자), source (등록 경로)
+// 반환: 등록된 seq (이미 등록된 번호면 0), 에러
+func AddSMSOptOut(actor AuditActor, branchSeq int, entry SMSOptOutEntry, source string) (int64, error) {
+	var seq int64
+	err := Transaction(func(tx *sql.Tx) error {
+		var err error
+		seq, err = insertSMSOptOutTx(tx, actor, branchSeq, entry, source)
+		if err != nil || seq == 0 {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditSMSOptOutAdd, "sms_opt_out", seq, nil, map[string]interface{}{
+			"branch_seq":     branchSeq,
+			"phone_number":   utils.NormalizePhoneNumber(entry.PhoneNumber),
+			"reason":         entry.Reason,
+			"source":         source,
+			"opted_out_date": entry.OptedOutDate,
+		})
+	})
+	if err != nil {
+		log.Printf("AddSMSOptOut error: %v", err)
+		return 0, err
+	}
+
+	if seq > 0 {
+		log.Printf("[SMSOptOut] AddSMSOptOut 완료 - BranchSeq: %d, Seq: %d, Source: %s", branchSeq, seq, source)
+	}
+	return seq, nil
+}
+
+// ImportSMSOptOuts - 수신거부 목록 일괄 등록 (하나의 트랜잭션, 감사 로그는 건수로 한 번 기록)
+// 파라미터: actor (작업자), branchSeq (지점 seq), entries (번호 목록), source (등록 경로)
+// 반환: 새로 등록된 번호 수 (이미 등록된 번호는 제외), 에러
+func ImportSMSOptOuts(actor AuditActor, branchSeq int, entries []SMSOptOutEntry, source string) (int, error) {
+	added := 0
+	err := Transaction(func(tx *sql.Tx) error {
+		for _, entry := range entries {
+			seq, err := insertSMSOptOutTx(tx, actor, branchSeq, entry, source)
+			if err != nil {
+				return err
+			}
+			if seq > 0 {
+				added++
+			}
+		}
+
+		return recordAudit(tx, actor, AuditSMSOptOutImport, "branch", branchSeq, nil, map[string]interface{}{
+			"source":  source,
+			"total":   len(entries),
+			"added":   added,
+			"skipped": len(entries) - added,
+		})
+	})
+	if err != nil {
+		log.Printf("ImportSMSOptOuts error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[SMSOptOut] ImportSMSOptOuts 완료 - BranchSeq: %d, Source: %s, 요청: %d, 등록: %d", branchSeq, source, len(entries), added)
+	return added, nil
+}
+
+// DeleteSMSOptOut - 지점 수신거부 번호 해제 (감사 로그 기록)
+// 파라미터: actor (작업자), branchSeq (지점 seq, 다른 지점 번호는 해제하지 않음), seq
+// 반환: 삭제된 행 수, 에러
+func DeleteSMSOptOut(actor AuditActor, branchSeq, seq int) (int64, error) {
+	var rowsAffected int64
+	err := Transaction(func(tx *sql.Tx) error {
+		before, err := auditSnapshot(tx, `
+			SELECT branch_seq, phone_number, reason, source, DATE_FORMAT(opted_out_date, '%Y-%m-%d') AS opted_out_date
+			FROM sms_opt_outs WHERE seq = ? AND branch_seq = ? FOR UPDATE
+		`, seq, branchSeq)
+		if err != nil || before == nil {
+			return err
+		}
+
+		result, err := tx.Exec(`DELETE FROM sms_opt_outs WHERE seq = ?`, seq)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, AuditSMSOptOutDelete, "sms_opt_out", seq, before, nil)
+	})
+	if err != nil {
+		log.Printf("DeleteSMSOptOut error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("[SMSOptOut] DeleteSMSOptOut 완료 - BranchSeq: %d, Seq: %d", branchSeq, seq)
+	return rowsAffected, nil
+}
@@ -50,7 +50,7 @@ func GetCallerStats(branchSeq int, period string) ([]CallerStats, error) {
 	}
 
 	query += `
-		LEFT JOIN customers c ON r.customer_id = c.seq
+		LEFT JOIN customers c ON r.customer_id = c.seq AND c.deleted_at IS NULL
 	`
 
 	stats := []CallerStats{}
@@ -150,6 +150,7 @@ func GetDailyCustomerStats(branchSeq int, days int) ([]DailyCustomerStats, error
 			SELECT DATE(createdDate) as date, COUNT(*) as count
 			FROM customers
 			WHERE DATE(createdDate) >= DATE_SUB(CURDATE(), INTERVAL ? DAY)
+			  AND deleted_at IS NULL
 	`
 	args := []interface{}{days, days - 1}
 
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+)
+
+// UserTOTP - 사용자 2단계 인증 설정
+type UserTOTP struct {
+	Secret   string // 등록 진행 중이거나 활성화된 비밀키 (없으면 빈 문자열)
+	Enabled  bool
+	LastStep int64 // 마지막 사용 step (재사용 방지)
+}
+
+// GetUserTOTP - 사용자 2단계 인증 설정 조회
+func GetUserTOTP(userSeq int) (*UserTOTP, error) {
+	var t UserTOTP
+	var secret sql.NullString
+	query := `SELECT totp_secret, totp_enabled, totp_last_step FROM user_info WHERE seq = ?`
+	if err := DB.QueryRow(query, userSeq).Scan(&secret, &t.Enabled, &t.LastStep); err != nil {
+		log.Printf("GetUserTOTP error: %v", err)
+		return nil, err
+	}
+	t.Secret = secret.String
+	return &t, nil
+}
+
+// SetPendingTOTPSecret - 등록 대기 비밀키 저장 (인증 코드 확인 전까지 totp_enabled=0)
+// 이미 2단계 인증이 활성화된 계정은 변경하지 않음
+func SetPendingTOTPSecret(userSeq int, secret string) error {
+	query := `UPDATE user_info SET totp_secret = ?, totp_last_step = 0 WHERE seq = ? AND totp_enabled = 0`
+	if _, err := DB.Exec(query, secret, userSeq); err != nil {
+		log.Printf("SetPendingTOTPSecret error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// EnableTOTP - 2단계 인증 활성화 및 복구 코드 발급 (트랜잭션)
+// 파라미터: userSeq (사용자 seq), step (등록 확인에 사용한 코드 step), codeHashes (복구 코드 해시 목록)
+func EnableTOTP(userSeq int, step int64, codeHashes []string) error {
+	err := Transaction(func(tx *sql.Tx) error {
+		query := `UPDATE user_info SET totp_enabled = 1, totp_last_step = ?, lastUpdateDate = CURDATE() WHERE seq = ?`
+		if _, err := tx.Exec(query, step, userSeq); err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userSeq, codeHashes)
+	})
+	if err != nil {
+		log.Printf("EnableTOTP error: %v", err)
+		return err
+	}
+
+	log.Printf("EnableTOTP success - UserSeq: %d", userSeq)
+	return nil
+}
+
+// DisableTOTP - 2단계 인증 해제 (비밀키와 복구 코드 삭제)
+func DisableTOTP(userSeq int) error {
+	err := Transaction(func(tx *sql.Tx) error {
+		query := `UPDATE user_info
+		          SET totp_secret = NULL, totp_enabled = 0, totp_last_step = 0, lastUpdateDate = CURDATE()
+		          WHERE seq = ?`
+		if _, err := tx.Exec(query, userSeq); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_seq = ?`, userSeq)
+		return err
+	})
+	if err != nil {
+		log.Printf("DisableTOTP error: %v", err)
+		return err
+	}
+
+	log.Printf("DisableTOTP success - UserSeq: %d", userSeq)
+	return nil
+}
+
+// MarkTOTPStepUsed - 사용한 코드 step 저장
+// 동시에 같은 코드로 두 번 로그인하는 것을 막기 위해 저장된 step보다 클 때만 갱신
+// 반환: 갱신 여부 (false면 이미 사용된 코드)
+func MarkTOTPStepUsed(userSeq int, step int64) (bool, error) {
+	query := `UPDATE user_info SET totp_last_step = ? WHERE seq = ? AND totp_last_step < ?`
+	rowsAffected, err := Update(query, step, userSeq, step)
+	if err != nil {
+		log.Printf("MarkTOTPStepUsed error: %v", err)
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes - 복구 코드 재발급 (기존 코드는 모두 폐기)
+func ReplaceRecoveryCodes(userSeq int, codeHashes []string) error {
+	err := Transaction(func(tx *sql.Tx) error {
+		return replaceRecoveryCodes(tx, userSeq, codeHashes)
+	})
+	if err != nil {
+		log.Printf("ReplaceRecoveryCodes error: %v", err)
+	}
+	return err
+}
+
+// replaceRecoveryCodes - 트랜잭션 내에서 복구 코드 교체
+func replaceRecoveryCodes(tx *sql.Tx, userSeq int, codeHashes []string) error {
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_seq = ?`, userSeq); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		query := `INSERT INTO user_recovery_codes (user_seq, code_hash, createdDate) VALUES (?, ?, NOW())`
+		if _, err := tx.Exec(query, userSeq, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode - 복구 코드 사용 처리 (1회용)
+// 반환: 사용 성공 여부 (없는 코드이거나 이미 사용된 코드면 false)
+func UseRecoveryCode(userSeq int, codeHash string) (bool, error) {
+	query := `UPDATE user_recovery_codes SET used_at = NOW()
+	          WHERE user_seq = ? AND code_hash = ? AND used_at IS NULL
+	          LIMIT 1`
+	rowsAffected, err := Update(query, userSeq, codeHash)
+	if err != nil {
+		log.Printf("UseRecoveryCode error: %v", err)
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// CountUnusedRecoveryCodes - 남은 복구 코드 수
+func CountUnusedRecoveryCodes(userSeq int) (int, error) {
+	count, err := Count(`SELECT COUNT(*) FROM user_recovery_codes WHERE user_seq = ? AND used_at IS NULL`, userSeq)
+	if err != nil {
+		log.Printf("CountUnusedRecoveryCodes error: %v", err)
+	}
+	return count, err
+}
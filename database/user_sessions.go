@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// ActiveSession - 활성 로그인 세션 구조체
+type ActiveSession struct {
+	SessionID    string
+	UserSeq      int
+	UserID       string
+	IPAddress    string
+	UserAgent    string
+	CreatedDate  string
+	LastActivity string
+	ExpiresAt    string
+}
+
+// activeSessionConditions - 활성 로그인 세션 조회 조건 생성
+// 만료되지 않았고 유휴 시간이 지나지 않은 로그인 세션(user-session)만 포함
+func activeSessionConditions(searchUserID string, idleSeconds int) (string, []interface{}) {
+	where := `WHERE s.session_name = 'user-session' AND s.user_seq IS NOT NULL AND s.expires_at > NOW()`
+	var args []interface{}
+
+	if idleSeconds > 0 {
+		where += ` AND s.last_activity > NOW() - INTERVAL ? SECOND`
+		args = append(args, idleSeconds)
+	}
+
+	if searchUserID != "" {
+		where += ` AND u.user_id LIKE ?`
+		args = append(args, "%"+searchUserID+"%")
+	}
+
+	return where, args
+}
+
+// GetActiveSessionsCount - 활성 로그인 세션 수 조회
+// 파라미터: searchUserID (아이디 검색어), idleSeconds (유휴 만료 시간, 0이면 사용 안 함)
+func GetActiveSessionsCount(searchUserID string, idleSeconds int) (int, error) {
+	where, args := activeSessionConditions(searchUserID, idleSeconds)
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM user_sessions s
+		INNER JOIN user_info u ON s.user_seq = u.seq
+		%s
+	`, where)
+
+	var count int
+	if err := DB.QueryRow(query, args...).Scan(&count); err != nil {
+		log.Printf("GetActiveSessionsCount error: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetActiveSessions - 활성 로그인 세션 목록 조회 (최근 활동순, 페이징)
+// 파라미터: searchUserID (아이디 검색어), idleSeconds (유휴 만료 시간), page (페이지 번호), itemsPerPage (페이지당 항목 수)
+func GetActiveSessions(searchUserID string, idleSeconds, page, itemsPerPage int) ([]ActiveSession, error) {
+	where, args := activeSessionConditions(searchUserID, idleSeconds)
+	offset := (page - 1) * itemsPerPage
+
+	query := fmt.Sprintf(`
+		SELECT s.session_id, s.user_seq, u.user_id, s.ip_address, COALESCE(s.user_agent, ''),
+		       DATE_FORMAT(s.createdDate, '%%Y-%%m-%%d %%H:%%i:%%s'),
+		       DATE_FORMAT(s.last_activity, '%%Y-%%m-%%d %%H:%%i:%%s'),
+		       DATE_FORMAT(s.expires_at, '%%Y-%%m-%%d %%H:%%i:%%s')
+		FROM user_sessions s
+		INNER JOIN user_info u ON s.user_seq = u.seq
+		%s
+		ORDER BY s.last_activity DESC
+		LIMIT ? OFFSET ?
+	`, where)
+
+	args = append(args, itemsPerPage, offset)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("GetActiveSessions error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []ActiveSession
+	for rows.Next() {
+		var s ActiveSession
+		if err := rows.Scan(&s.SessionID, &s.UserSeq, &s.UserID, &s.IPAddress, &s.UserAgent,
+			&s.CreatedDate, &s.LastActivity, &s.ExpiresAt); err != nil {
+			log.Printf("GetActiveSessions scan error: %v", err)
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	return list, nil
+}
+
+// DeleteSession - 세션 삭제 (해당 세션은 다음 요청부터 로그아웃 상태)
+// 파라미터: sessionID (세션 ID)
+func DeleteSession(sessionID string) error {
+	if _, err := DB.Exec(`DELETE FROM user_sessions WHERE session_id = ?`, sessionID); err != nil {
+		log.Printf("DeleteSession error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteUserSessions - 사용자의 모든 로그인 세션 삭제 (강제 로그아웃)
+// 파라미터: userSeq (사용자 seq), exceptSessionID (유지할 세션 ID, 빈 문자열이면 전체 삭제)
+// 반환: 삭제된 세션 수
+func DeleteUserSessions(userSeq int, exceptSessionID string) (int64, error) {
+	result, err := DB.Exec(`DELETE FROM user_sessions WHERE user_seq = ? AND session_id <> ?`, userSeq, exceptSessionID)
+	if err != nil {
+		log.Printf("DeleteUserSessions error: %v", err)
+		return 0, err
+	}
+
+	count, _ := result.RowsAffected()
+	if count > 0 {
+		log.Printf("DeleteUserSessions success - UserSeq: %d, Count: %d", userSeq, count)
+	}
+	return count, nil
+}
+
+// PurgeExpiredSessions - 만료되었거나 유휴 시간이 지난 세션 정리
+// 파라미터: idleSeconds (유휴 만료 시간, 0이면 만료 일시만 기준)
+func PurgeExpiredSessions(idleSeconds int) (int64, error) {
+	query := `DELETE FROM user_sessions WHERE expires_at <= NOW()`
+	var args []interface{}
+	if idleSeconds > 0 {
+		query += ` OR last_activity <= NOW() - INTERVAL ? SECOND`
+		args = append(args, idleSeconds)
+	}
+
+	result, err := DB.Exec(query, args...)
+	if err != nil {
+		log.Printf("PurgeExpiredSessions error: %v", err)
+		return 0, err
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// StartSessionCleanup - 주기적으로 만료 세션을 정리하는 백그라운드 작업 시작
+// 파라미터: interval (정리 주기), idleSeconds (유휴 만료 시간)
+func StartSessionCleanup(interval time.Duration, idleSeconds int) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if count, err := PurgeExpiredSessions(idleSeconds); err == nil && count > 0 {
+				log.Printf("만료 세션 정리 완료: %d건", count)
+			}
+		}
+	}()
+}
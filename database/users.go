@@ -0,0 +1,302 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// 사용자 역할 (user_info.role)
+const (
+	RoleSuperAdmin    = "super_admin"    // 최고 관리자: 모든 지점/설정 관리
+	RoleBranchManager = "branch_manager" // 지점 관리자: 지점 운영 설정 관리
+	RoleCaller        = "caller"         // 상담원: 고객 목록 업무만 가능
+)
+
+// RoleDisplayNames - 역할별 화면 표시 이름
+var RoleDisplayNames = map[string]string{
+	RoleSuperAdmin:    "최고 관리자",
+	RoleBranchManager: "지점 관리자",
+	RoleCaller:        "상담원",
+}
+
+// IsValidRole - 정의된 역할인지 확인
+func IsValidRole(role string) bool {
+	_, ok := RoleDisplayNames[role]
+	return ok
+}
+
+// UserAccount - 사용자 관리 화면용 계정 정보 (지점명 포함)
+type UserAccount struct {
+	Seq                int
+	BranchSeq          sql.NullInt64
+	BranchName         string // 전체 지점 계정이면 빈 문자열
+	UserID             string
+	Role               string
+	IsActive           bool
+	MustChangePassword bool
+	TOTPEnabled        bool
+	CreatedDate        string
+	LastUpdateDate     string
+}
+
+// GetUserBySeq - seq로 사용자 조회
+// 반환: 사용자 정보, 에러 (없으면 sql.ErrNoRows)
+func GetUserBySeq(userSeq int) (*User, error) {
+	var user User
+	query := `SELECT seq, branch_seq, user_id, user_password, role, is_active, must_change_password, totp_enabled
+	          FROM user_info
+	          WHERE seq = ?`
+
+	err := DB.QueryRow(query, userSeq).Scan(
+		&user.Seq,
+		&user.BranchSeq,
+		&user.UserID,
+		&user.UserPassword,
+		&user.Role,
+		&user.IsActive,
+		&user.MustChangePassword,
+		&user.TOTPEnabled,
+	)
+	if err != nil {
+		log.Printf("GetUserBySeq - query error: %v", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// buildUserFilterConditions - 사용자 목록 검색 조건 생성
+// 파라미터: searchKeyword (아이디 검색어), role (역할 필터, 빈 문자열이면 전체)
+// 반환: WHERE 절, 바인딩 인자
+func buildUserFilterConditions(searchKeyword, role string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if searchKeyword != "" {
+		conditions = append(conditions, "u.user_id LIKE ?")
+		args = append(args, "%"+searchKeyword+"%")
+	}
+
+	if role != "" {
+		conditions = append(conditions, "u.role = ?")
+		args = append(args, role)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return whereClause, args
+}
+
+// GetUserAccountsCount - 사용자 계정 전체 건수 조회
+// 파라미터: searchKeyword (아이디 검색어), role (역할 필터)
+// 반환: 건수, 에러
+func GetUserAccountsCount(searchKeyword, role string) (int, error) {
+	whereClause, args := buildUserFilterConditions(searchKeyword, role)
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM user_info u %s`, whereClause)
+
+	var count int
+	if err := DB.QueryRow(query, args...).Scan(&count); err != nil {
+		log.Printf("GetUserAccountsCount error: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUserAccounts - 사용자 계정 목록 조회 (페이징)
+// 파라미터: searchKeyword (아이디 검색어), role (역할 필터), page (페이지 번호), itemsPerPage (페이지당 항목 수)
+// 반환: 계정 목록, 에러
+func GetUserAccounts(searchKeyword, role string, page, itemsPerPage int) ([]UserAccount, error) {
+	whereClause, args := buildUserFilterConditions(searchKeyword, role)
+	offset := (page - 1) * itemsPerPage
+
+	query := fmt.Sprintf(`
+		SELECT u.seq, u.branch_seq, COALESCE(b.branchName, ''), u.user_id, u.role,
+		       u.is_active, u.must_change_password, u.totp_enabled,
+		       DATE_FORMAT(u.createdDate, '%%Y-%%m-%%d'), DATE_FORMAT(u.lastUpdateDate, '%%Y-%%m-%%d')
+		FROM user_info u
+		LEFT JOIN branches b ON u.branch_seq = b.seq
+		%s
+		ORDER BY u.seq ASC
+		LIMIT ? OFFSET ?
+	`, whereClause)
+
+	args = append(args, itemsPerPage, offset)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Printf("GetUserAccounts error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []UserAccount
+	for rows.Next() {
+		var a UserAccount
+		if err := rows.Scan(
+			&a.Seq, &a.BranchSeq, &a.BranchName, &a.UserID, &a.Role,
+			&a.IsActive, &a.MustChangePassword, &a.TOTPEnabled,
+			&a.CreatedDate, &a.LastUpdateDate,
+		); err != nil {
+			log.Printf("GetUserAccounts scan error: %v", err)
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+
+	return accounts, nil
+}
+
+// GetUserAccountBySeq - 사용자 계정 상세 조회
+// 파라미터: userSeq (사용자 seq)
+// 반환: 계정 정보, 에러 (없으면 sql.ErrNoRows)
+func GetUserAccountBySeq(userSeq int) (*UserAccount, error) {
+	query := `
+		SELECT u.seq, u.branch_seq, COALESCE(b.branchName, ''), u.user_id, u.role,
+		       u.is_active, u.must_change_password, u.totp_enabled,
+		       DATE_FORMAT(u.createdDate, '%Y-%m-%d'), DATE_FORMAT(u.lastUpdateDate, '%Y-%m-%d')
+		FROM user_info u
+		LEFT JOIN branches b ON u.branch_seq = b.seq
+		WHERE u.seq = ?
+	`
+
+	var a UserAccount
+	err := DB.QueryRow(query, userSeq).Scan(
+		&a.Seq, &a.BranchSeq, &a.BranchName, &a.UserID, &a.Role,
+		&a.IsActive, &a.MustChangePassword, &a.TOTPEnabled,
+		&a.CreatedDate, &a.LastUpdateDate,
+	)
+	if err != nil {
+		log.Printf("GetUserAccountBySeq error: %v", err)
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// UserIDExists - 아이디 중복 확인
+func UserIDExists(userID string) (bool, error) {
+	exists, err := Exists(`SELECT 1 FROM user_info WHERE user_id = ?`, userID)
+	if err != nil {
+		log.Printf("UserIDExists error: %v", err)
+	}
+	return exists, err
+}
+
+// CountActiveSuperAdmins - 활성 상태인 최고 관리자 수 (마지막 관리자 비활성화/삭제 방지용)
+func CountActiveSuperAdmins() (int, error) {
+	count, err := Count(`SELECT COUNT(*) FROM user_info WHERE role = ? AND is_active = 1`, RoleSuperAdmin)
+	if err != nil {
+		log.Printf("CountActiveSuperAdmins error: %v", err)
+	}
+	return count, err
+}
+
+// CountUserReservations - 사용자가 등록한 예약 건수 (reservation_info가 ON DELETE CASCADE라 삭제 전 확인용)
+func CountUserReservations(userSeq int) (int, error) {
+	count, err := Count(`SELECT COUNT(*) FROM reservation_info WHERE user_seq = ?`, userSeq)
+	if err != nil {
+		log.Printf("CountUserReservations error: %v", err)
+	}
+	return count, err
+}
+
+// InsertUser - 사용자 계정 생성 (비밀번호는 해시 저장, 첫 로그인 시 변경 강제)
+// 파라미터: userID (아이디), password (초기 비밀번호), role (역할), branchSeq (소속 지점, nil이면 전체)
+// 반환: 생성된 seq, 에러
+func InsertUser(userID, password, role string, branchSeq *int) (int64, error) {
+	hashed, err := HashPassword(password)
+	if err != nil {
+		log.Printf("InsertUser - hash error: %v", err)
+		return 0, err
+	}
+
+	query := `INSERT INTO user_info (branch_seq, role, user_id, user_password, is_active, must_change_password, createdDate, lastUpdateDate)
+	          VALUES (?, ?, ?, ?, 1, 1, CURDATE(), CURDATE())`
+
+	result, err := DB.Exec(query, branchSeq, role, userID, hashed)
+	if err != nil {
+		log.Printf("InsertUser error: %v", err)
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Printf("InsertUser get last insert id error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("InsertUser success - Seq: %d, UserID: %s, Role: %s", id, userID, role)
+	return id, nil
+}
+
+// UpdateUserAccount - 사용자 역할/소속 지점/활성 여부 수정
+// 파라미터: userSeq (사용자 seq), role (역할), branchSeq (소속 지점, nil이면 전체), isActive (활성 여부)
+// 반환: 영향받은 행 수, 에러
+func UpdateUserAccount(userSeq int, role string, branchSeq *int, isActive bool) (int64, error) {
+	query := `UPDATE user_info
+	          SET role = ?, branch_seq = ?, is_active = ?, lastUpdateDate = CURDATE()
+	          WHERE seq = ?`
+
+	rowsAffected, err := Update(query, role, branchSeq, isActive, userSeq)
+	if err != nil {
+		log.Printf("UpdateUserAccount error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("UpdateUserAccount success - Seq: %d, Role: %s, Active: %t", userSeq, role, isActive)
+	return rowsAffected, nil
+}
+
+// SetUserActive - 사용자 계정 활성/비활성 전환
+func SetUserActive(userSeq int, isActive bool) error {
+	query := `UPDATE user_info SET is_active = ?, lastUpdateDate = CURDATE() WHERE seq = ?`
+	if _, err := DB.Exec(query, isActive, userSeq); err != nil {
+		log.Printf("SetUserActive error: %v", err)
+		return err
+	}
+
+	log.Printf("SetUserActive success - Seq: %d, Active: %t", userSeq, isActive)
+	return nil
+}
+
+// ChangeUserPassword - 비밀번호 변경 (해시 저장)
+// 파라미터: userSeq (사용자 seq), password (새 비밀번호), mustChange (다음 로그인 시 변경 강제 여부)
+// 관리자 초기화는 mustChange=true, 본인 변경은 mustChange=false
+func ChangeUserPassword(userSeq int, password string, mustChange bool) error {
+	hashed, err := HashPassword(password)
+	if err != nil {
+		log.Printf("ChangeUserPassword - hash error: %v", err)
+		return err
+	}
+
+	query := `UPDATE user_info
+	          SET user_password = ?, must_change_password = ?, lastUpdateDate = CURDATE()
+	          WHERE seq = ?`
+	if _, err := DB.Exec(query, hashed, mustChange, userSeq); err != nil {
+		log.Printf("ChangeUserPassword error: %v", err)
+		return err
+	}
+
+	log.Printf("ChangeUserPassword success - Seq: %d, MustChange: %t", userSeq, mustChange)
+	return nil
+}
+
+// DeleteUser - 사용자 계정 삭제
+// 파라미터: userSeq (사용자 seq)
+// 반환: 영향받은 행 수, 에러
+func DeleteUser(userSeq int) (int64, error) {
+	rowsAffected, err := Delete(`DELETE FROM user_info WHERE seq = ?`, userSeq)
+	if err != nil {
+		log.Printf("DeleteUser error: %v", err)
+		return 0, err
+	}
+
+	log.Printf("DeleteUser success - Seq: %d, Rows: %d", userSeq, rowsAffected)
+	return rowsAffected, nil
+}
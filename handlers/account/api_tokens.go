@@ -0,0 +1,186 @@
+package account
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// ToAPITokenItem - DB 토큰 정보를 목록 화면용 데이터로 변환
+func ToAPITokenItem(t database.APIToken) APITokenItem {
+	var scopeNames []string
+	for _, scope := range t.Scopes {
+		if name, ok := database.APIScopeDisplayNames[scope]; ok {
+			scopeNames = append(scopeNames, name)
+		} else {
+			scopeNames = append(scopeNames, scope)
+		}
+	}
+
+	return APITokenItem{
+		Seq:         t.Seq,
+		UserSeq:     t.UserSeq,
+		UserID:      t.UserID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		ScopeNames:  scopeNames,
+		BranchName:  t.BranchName,
+		ExpiresAt:   t.ExpiresAt,
+		LastUsedAt:  t.LastUsedAt,
+		LastUsedIP:  t.LastUsedIP,
+		RevokedAt:   t.RevokedAt,
+		CreatedBy:   t.CreatedBy,
+		CreatedDate: t.CreatedDate,
+		IsExpired:   t.IsExpired,
+		IsActive:    t.RevokedAt == "" && !t.IsExpired,
+	}
+}
+
+// ScopeOptionsForRole - 역할이 발급받을 수 있는 API 토큰 범위 목록
+func ScopeOptionsForRole(role string) []ScopeOption {
+	var options []ScopeOption
+	for _, scope := range database.APIScopes {
+		if middleware.CanUseAPIScope(role, scope) {
+			options = append(options, ScopeOption{Value: scope, Name: database.APIScopeDisplayNames[scope]})
+		}
+	}
+	return options
+}
+
+// ExpiryOptions - API 토큰 유효 기간 선택 항목
+func ExpiryOptions() []ExpiryOption {
+	var options []ExpiryOption
+	for _, days := range apiTokenExpiryDays {
+		name := fmt.Sprintf("%d일", days)
+		if days == 0 {
+			name = "만료 없음"
+		}
+		options = append(options, ExpiryOption{Days: days, Name: name})
+	}
+	return options
+}
+
+// APITokensHandler - 본인 API 토큰 관리 페이지 핸들러
+// GET: 토큰 목록, POST action=create|revoke
+func APITokensHandler(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			log.Println("Form parse error:", err)
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+
+		switch r.FormValue("action") {
+		case "create":
+			createAPIToken(w, r, user)
+		case "revoke":
+			revokeAPIToken(w, r, user)
+		default:
+			http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+		}
+		return
+	}
+
+	data, err := buildAPITokensPageData(r, user)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	data.SuccessMessage = utils.GetFlashMessage(w, r, "success")
+	data.ErrorMessage = utils.GetFlashMessage(w, r, "error")
+	renderAPITokens(w, r, data)
+}
+
+// buildAPITokensPageData - 본인 토큰 목록으로 페이지 데이터 구성
+func buildAPITokensPageData(r *http.Request, user *database.User) (APITokensPageData, error) {
+	data := APITokensPageData{
+		BasePageData:  middleware.GetBasePageData(r),
+		Title:         "API 토큰",
+		ActiveMenu:    "account",
+		Scopes:        ScopeOptionsForRole(user.Role),
+		ExpiryOptions: ExpiryOptions(),
+		NeedBranch:    !user.BranchSeq.Valid,
+	}
+
+	tokens, err := database.GetAPITokens(user.Seq)
+	if err != nil {
+		return data, err
+	}
+	for _, t := range tokens {
+		data.Tokens = append(data.Tokens, ToAPITokenItem(t))
+	}
+
+	return data, nil
+}
+
+// createAPIToken - 본인 API 토큰 발급 (토큰 원문은 응답 화면에만 한 번 표시)
+func createAPIToken(w http.ResponseWriter, r *http.Request, user *database.User) {
+	name := r.FormValue("name")
+	scopes := r.Form["scopes"]
+
+	branchSeq, expiresDays, err := ValidateAPITokenForm(user.Role, user.BranchSeq, name, scopes, r.FormValue("branch_seq"), r.FormValue("expires_days"))
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+		return
+	}
+
+	token, err := database.CreateAPIToken(user.Seq, name, scopes, branchSeq, expiresDays, user.UserID)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "API 토큰 발급에 실패했습니다.")
+		http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+		return
+	}
+
+	data, err := buildAPITokensPageData(r, user)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("API 토큰 발급 - 사용자: %s, 이름: %s", user.UserID, name)
+	w.Header().Set("Cache-Control", "no-store")
+	data.NewToken = token
+	data.SuccessMessage = "API 토큰이 발급되었습니다."
+	renderAPITokens(w, r, data)
+}
+
+// revokeAPIToken - 본인 API 토큰 폐기
+func revokeAPIToken(w http.ResponseWriter, r *http.Request, user *database.User) {
+	tokenSeq, err := strconv.Atoi(r.FormValue("token_seq"))
+	if err != nil || tokenSeq <= 0 {
+		utils.SetFlashMessage(w, r, "error", "폐기할 토큰이 없습니다.")
+		http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+		return
+	}
+
+	count, err := database.RevokeAPIToken(tokenSeq, user.Seq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "API 토큰 폐기에 실패했습니다.")
+	} else if count == 0 {
+		utils.SetFlashMessage(w, r, "error", "이미 폐기되었거나 존재하지 않는 토큰입니다.")
+	} else {
+		log.Printf("API 토큰 폐기 - 사용자: %s, 토큰: %d", user.UserID, tokenSeq)
+		utils.SetFlashMessage(w, r, "success", "API 토큰을 폐기했습니다.")
+	}
+
+	http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+}
+
+// renderAPITokens - API 토큰 템플릿 렌더링
+func renderAPITokens(w http.ResponseWriter, r *http.Request, data APITokensPageData) {
+	if err := Templates.ExecuteTemplate(w, "account/api-tokens.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"html/template"
+	"log"
+	"net/http"
+)
+
+var Templates *template.Template
+
+// PasswordHandler - 본인 비밀번호 변경 페이지 핸들러 (GET: 폼, POST: 변경 처리)
+// must_change_password 계정은 RequireAuth에서 이 페이지로만 접근 가능
+func PasswordHandler(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+		return
+	}
+
+	data := PasswordPageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "비밀번호 변경",
+		ActiveMenu:   "account",
+		MustChange:   user.MustChangePassword,
+	}
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			log.Println("Form parse error:", err)
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+
+		currentPassword := r.FormValue("current_password")
+		newPassword := r.FormValue("new_password")
+		confirmPassword := r.FormValue("confirm_password")
+
+		if err := ValidatePasswordChange(user.Seq, currentPassword, newPassword, confirmPassword); err != nil {
+			data.ErrorMessage = err.Error()
+			renderPassword(w, r, data)
+			return
+		}
+
+		if err := database.ChangeUserPassword(user.Seq, newPassword, false); err != nil {
+			log.Printf("비밀번호 변경 오류: %v", err)
+			data.ErrorMessage = "비밀번호 변경에 실패했습니다."
+			renderPassword(w, r, data)
+			return
+		}
+
+		// 다른 기기에 남아 있는 로그인 세션은 종료 (현재 세션 유지)
+		database.DeleteUserSessions(user.Seq, middleware.GetCurrentSessionID(r))
+
+		log.Printf("비밀번호 변경 완료 - 사용자: %s", user.UserID)
+		utils.SetFlashMessage(w, r, "success", "비밀번호가 변경되었습니다.")
+
+		// 강제 변경이었다면 이제 대시보드로 이동 가능
+		if user.MustChangePassword {
+			http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, middleware.PasswordChangePath, http.StatusSeeOther)
+		return
+	}
+
+	data.SuccessMessage = utils.GetFlashMessage(w, r, "success")
+	renderPassword(w, r, data)
+}
+
+// renderPassword - 비밀번호 변경 템플릿 렌더링
+func renderPassword(w http.ResponseWriter, r *http.Request, data PasswordPageData) {
+	if err := Templates.ExecuteTemplate(w, "account/password.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,76 @@
+package account
+
+import (
+	"backoffice/middleware"
+	"html/template"
+)
+
+// PasswordPageData - 비밀번호 변경 페이지 데이터 구조체
+type PasswordPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	MustChange     bool // 첫 로그인/초기화로 인한 강제 변경 여부
+	ErrorMessage   string
+	SuccessMessage string
+}
+
+// TwoFactorPageData - 2단계 인증 설정 페이지 데이터 구조체
+type TwoFactorPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Enabled        bool         // 2단계 인증 사용 중
+	Pending        bool         // 비밀키 발급 후 코드 확인 대기 중
+	Secret         string       // 수동 입력용 비밀키 (Pending일 때만)
+	QRCode         template.URL // 등록용 QR 코드 data URI (Pending일 때만)
+	RecoveryCodes  []string     // 새로 발급된 복구 코드 (발급 직후 한 번만 표시)
+	RemainingCodes int          // 남은 복구 코드 수
+	ErrorMessage   string
+	SuccessMessage string
+}
+
+// APITokenItem - API 토큰 목록 화면용 데이터 (관리자 화면에서도 사용)
+type APITokenItem struct {
+	Seq         int
+	UserSeq     int
+	UserID      string
+	Name        string
+	TokenPrefix string
+	ScopeNames  []string
+	BranchName  string
+	ExpiresAt   string // 만료 없음이면 빈 문자열
+	LastUsedAt  string
+	LastUsedIP  string
+	RevokedAt   string
+	CreatedBy   string
+	CreatedDate string
+	IsExpired   bool
+	IsActive    bool // 폐기/만료되지 않아 사용 가능한 토큰
+}
+
+// ScopeOption - API 토큰 허용 범위 선택 항목
+type ScopeOption struct {
+	Value string
+	Name  string
+}
+
+// ExpiryOption - API 토큰 유효 기간 선택 항목
+type ExpiryOption struct {
+	Days int
+	Name string
+}
+
+// APITokensPageData - 본인 API 토큰 관리 페이지 데이터 구조체
+type APITokensPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Tokens         []APITokenItem
+	Scopes         []ScopeOption // 현재 역할로 발급 가능한 범위
+	ExpiryOptions  []ExpiryOption
+	NeedBranch     bool   // 전체 지점 계정 여부 (발급 시 지점 선택 필요)
+	NewToken       string // 새로 발급된 토큰 원문 (발급 직후 한 번만 표시)
+	ErrorMessage   string
+	SuccessMessage string
+}
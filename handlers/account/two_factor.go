@@ -0,0 +1,257 @@
+package account
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/services/totp"
+	"backoffice/utils"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+)
+
+// totpIssuer - 인증 앱에 표시될 서비스 이름
+const totpIssuer = "Culcom 백오피스"
+
+// recoveryCodeCount - 한 번에 발급하는 복구 코드 수
+const recoveryCodeCount = 10
+
+// TwoFactorHandler - 2단계 인증 설정 페이지 핸들러
+// GET: 현재 상태 표시, POST action=begin|confirm|regenerate|disable
+func TwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			log.Println("Form parse error:", err)
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+
+		switch r.FormValue("action") {
+		case "begin":
+			beginEnrollment(w, r, user)
+		case "confirm":
+			confirmEnrollment(w, r, user)
+		case "regenerate":
+			regenerateRecoveryCodes(w, r, user)
+		case "disable":
+			disableTwoFactor(w, r, user)
+		default:
+			http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		}
+		return
+	}
+
+	data, err := buildTwoFactorPageData(r, user)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	data.SuccessMessage = utils.GetFlashMessage(w, r, "success")
+	renderTwoFactor(w, r, data)
+}
+
+// buildTwoFactorPageData - 현재 2단계 인증 상태로 페이지 데이터 구성
+func buildTwoFactorPageData(r *http.Request, user *database.User) (TwoFactorPageData, error) {
+	data := TwoFactorPageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "2단계 인증",
+		ActiveMenu:   "account",
+	}
+
+	setting, err := database.GetUserTOTP(user.Seq)
+	if err != nil {
+		return data, err
+	}
+
+	data.Enabled = setting.Enabled
+	if setting.Enabled {
+		data.RemainingCodes, _ = database.CountUnusedRecoveryCodes(user.Seq)
+		return data, nil
+	}
+
+	// 등록 진행 중이면 QR 코드 표시
+	if setting.Secret != "" {
+		qr, err := totp.QRCodeDataURI(totp.ProvisioningURI(totpIssuer, user.UserID, setting.Secret))
+		if err != nil {
+			log.Printf("QR 코드 생성 오류: %v", err)
+			return data, err
+		}
+		data.Pending = true
+		data.Secret = setting.Secret
+		data.QRCode = template.URL(qr)
+	}
+
+	return data, nil
+}
+
+// beginEnrollment - 새 비밀키 발급 (코드 확인 전까지는 로그인에 적용되지 않음)
+func beginEnrollment(w http.ResponseWriter, r *http.Request, user *database.User) {
+	if user.TOTPEnabled {
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Printf("TOTP 비밀키 생성 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if err := database.SetPendingTOTPSecret(user.Seq, secret); err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+}
+
+// confirmEnrollment - 인증 앱 코드 확인 후 2단계 인증 활성화 및 복구 코드 발급
+func confirmEnrollment(w http.ResponseWriter, r *http.Request, user *database.User) {
+	data, err := buildTwoFactorPageData(r, user)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	if !data.Pending {
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return
+	}
+
+	step, ok := totp.Validate(data.Secret, r.FormValue("code"), time.Now(), 0)
+	if !ok {
+		data.ErrorMessage = "인증 코드가 올바르지 않습니다. 인증 앱의 시간이 정확한지 확인해주세요."
+		renderTwoFactor(w, r, data)
+		return
+	}
+
+	codes, hashes, err := issueRecoveryCodes()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if err := database.EnableTOTP(user.Seq, step, hashes); err != nil {
+		data.ErrorMessage = "2단계 인증 활성화에 실패했습니다."
+		renderTwoFactor(w, r, data)
+		return
+	}
+
+	log.Printf("2단계 인증 활성화 - 사용자: %s", user.UserID)
+	renderIssuedCodes(w, r, codes, "2단계 인증이 활성화되었습니다.")
+}
+
+// regenerateRecoveryCodes - 복구 코드 재발급 (현재 인증 코드 확인 필요)
+func regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request, user *database.User) {
+	if !user.TOTPEnabled {
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return
+	}
+
+	if !totp.VerifyUserCode(user.Seq, r.FormValue("code")) {
+		renderTwoFactorError(w, r, user, "인증 코드가 올바르지 않습니다.")
+		return
+	}
+
+	codes, hashes, err := issueRecoveryCodes()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if err := database.ReplaceRecoveryCodes(user.Seq, hashes); err != nil {
+		renderTwoFactorError(w, r, user, "복구 코드 재발급에 실패했습니다.")
+		return
+	}
+
+	log.Printf("복구 코드 재발급 - 사용자: %s", user.UserID)
+	renderIssuedCodes(w, r, codes, "새 복구 코드가 발급되었습니다. 이전 복구 코드는 더 이상 사용할 수 없습니다.")
+}
+
+// disableTwoFactor - 2단계 인증 해제 (비밀번호와 인증 코드 모두 확인)
+func disableTwoFactor(w http.ResponseWriter, r *http.Request, user *database.User) {
+	if !user.TOTPEnabled {
+		// 등록 진행 중인 비밀키 취소
+		if err := database.DisableTOTP(user.Seq); err != nil {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return
+	}
+
+	if !database.VerifyUserPassword(user.Seq, r.FormValue("password")) {
+		renderTwoFactorError(w, r, user, "비밀번호가 일치하지 않습니다.")
+		return
+	}
+
+	if !totp.VerifyUserCode(user.Seq, r.FormValue("code")) {
+		renderTwoFactorError(w, r, user, "인증 코드가 올바르지 않습니다.")
+		return
+	}
+
+	if err := database.DisableTOTP(user.Seq); err != nil {
+		renderTwoFactorError(w, r, user, "2단계 인증 해제에 실패했습니다.")
+		return
+	}
+
+	log.Printf("2단계 인증 해제 - 사용자: %s", user.UserID)
+	utils.SetFlashMessage(w, r, "success", "2단계 인증이 해제되었습니다.")
+	http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+}
+
+// issueRecoveryCodes - 복구 코드 생성 (화면 표시용 원문, 저장용 해시)
+func issueRecoveryCodes() ([]string, []string, error) {
+	codes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		log.Printf("복구 코드 생성 오류: %v", err)
+		return nil, nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// renderIssuedCodes - 복구 코드 발급 결과 표시 (원문은 저장하지 않으므로 리다이렉트 없이 응답)
+func renderIssuedCodes(w http.ResponseWriter, r *http.Request, codes []string, message string) {
+	w.Header().Set("Cache-Control", "no-store")
+	data := TwoFactorPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "2단계 인증",
+		ActiveMenu:     "account",
+		Enabled:        true,
+		RecoveryCodes:  codes,
+		RemainingCodes: len(codes),
+		SuccessMessage: message,
+	}
+	renderTwoFactor(w, r, data)
+}
+
+// renderTwoFactorError - 현재 상태 페이지에 오류 메시지 표시
+func renderTwoFactorError(w http.ResponseWriter, r *http.Request, user *database.User, message string) {
+	data, err := buildTwoFactorPageData(r, user)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	data.ErrorMessage = message
+	renderTwoFactor(w, r, data)
+}
+
+// renderTwoFactor - 2단계 인증 템플릿 렌더링
+func renderTwoFactor(w http.ResponseWriter, r *http.Request, data TwoFactorPageData) {
+	if err := Templates.ExecuteTemplate(w, "account/two-factor.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
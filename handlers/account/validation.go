@@ -0,0 +1,88 @@
+package account
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// apiTokenExpiryDays - 선택 가능한 API 토큰 유효 기간 (일, 0은 만료 없음)
+var apiTokenExpiryDays = []int{30, 90, 365, 0}
+
+// ValidatePasswordChange 비밀번호 변경 요청 검증
+// 현재 비밀번호 확인, 새 비밀번호 정책 및 확인 입력 일치 여부 검사
+func ValidatePasswordChange(userSeq int, currentPassword, newPassword, confirmPassword string) error {
+	if currentPassword == "" || newPassword == "" || confirmPassword == "" {
+		return fmt.Errorf("모든 항목을 입력해주세요")
+	}
+
+	if !database.VerifyUserPassword(userSeq, currentPassword) {
+		return fmt.Errorf("현재 비밀번호가 일치하지 않습니다")
+	}
+
+	if newPassword != confirmPassword {
+		return fmt.Errorf("새 비밀번호 확인이 일치하지 않습니다")
+	}
+
+	if newPassword == currentPassword {
+		return fmt.Errorf("현재 비밀번호와 다른 비밀번호를 입력해주세요")
+	}
+
+	return utils.ValidatePassword(newPassword)
+}
+
+// ValidateAPITokenForm API 토큰 발급 폼 검증 (본인 발급/관리자 발급 공용)
+// 범위는 소유자 역할이 사용할 수 있는 것만 허용하고, 전체 지점 계정은 요청에 사용할 지점이 반드시 필요
+// 반환: 지점 seq (지점 소속 계정이면 nil), 유효 기간 일수, 에러
+func ValidateAPITokenForm(ownerRole string, ownerBranchSeq sql.NullInt64, name string, scopes []string, branchSeqStr, expiresDaysStr string) (*int, int, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, 0, fmt.Errorf("토큰 이름을 입력해주세요")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return nil, 0, fmt.Errorf("토큰 이름은 100자 이내로 입력해주세요")
+	}
+
+	if len(scopes) == 0 {
+		return nil, 0, fmt.Errorf("허용 범위를 하나 이상 선택해주세요")
+	}
+	for _, scope := range scopes {
+		if !database.IsValidAPIScope(scope) {
+			return nil, 0, fmt.Errorf("올바르지 않은 허용 범위입니다: %s", scope)
+		}
+		if !middleware.CanUseAPIScope(ownerRole, scope) {
+			return nil, 0, fmt.Errorf("소유자 권한으로 사용할 수 없는 범위입니다: %s", database.APIScopeDisplayNames[scope])
+		}
+	}
+
+	expiresDays, err := strconv.Atoi(expiresDaysStr)
+	if err != nil || !isAllowedExpiryDays(expiresDays) {
+		return nil, 0, fmt.Errorf("유효 기간을 선택해주세요")
+	}
+
+	if ownerBranchSeq.Valid {
+		return nil, expiresDays, nil
+	}
+
+	branchSeq, err := strconv.Atoi(branchSeqStr)
+	if err != nil || branchSeq <= 0 {
+		return nil, 0, fmt.Errorf("전체 지점 계정의 토큰은 요청에 사용할 지점을 선택해야 합니다")
+	}
+
+	return &branchSeq, expiresDays, nil
+}
+
+// isAllowedExpiryDays - 선택 가능한 유효 기간인지 확인
+func isAllowedExpiryDays(days int) bool {
+	for _, d := range apiTokenExpiryDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
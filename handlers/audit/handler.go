@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+)
+
+var Templates *template.Template
+
+// entityTypeNames - 감사 대상 종류 화면 표시 이름 (필터 표시 순서)
+var entityTypeNames = []FilterOption{
+	{Value: "customer", Name: "고객"},
+	{Value: "branch", Name: "지점"},
+	{Value: "customer_status", Name: "고객 상태"},
+	{Value: "assignment_rule", Name: "자동 배정 규칙"},
+	{Value: "customer_tag", Name: "고객 태그"},
+	{Value: "sms_config", Name: "SMS 연동 설정"},
+	{Value: "message_template", Name: "메시지 템플릿"},
+	{Value: "notice", Name: "공지사항"},
+}
+
+// Handler - 감사 로그 목록 페이지 핸들러
+// 작업자/작업 종류/대상/기간으로 필터링
+func Handler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := database.AuditFilter{
+		UserID:     query.Get("userId"),
+		Action:     query.Get("action"),
+		EntityType: query.Get("entityType"),
+		EntityID:   query.Get("entityId"),
+		DateFrom:   validDate(query.Get("from")),
+		DateTo:     validDate(query.Get("to")),
+	}
+
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	itemsPerPage := 30
+
+	totalItems, err := database.GetAuditLogsCount(filter)
+	if err != nil {
+		log.Printf("감사 로그 수 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	dbLogs, err := database.GetAuditLogs(filter, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		log.Printf("감사 로그 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var logs []LogItem
+	for _, l := range dbLogs {
+		logs = append(logs, LogItem{
+			UserID:         l.UserID,
+			Action:         l.Action,
+			ActionName:     actionName(l.Action),
+			EntityType:     l.EntityType,
+			EntityTypeName: entityTypeName(l.EntityType),
+			EntityID:       l.EntityID,
+			BeforeData:     prettyJSON(l.BeforeData),
+			AfterData:      prettyJSON(l.AfterData),
+			IPAddress:      l.IPAddress,
+			CreatedDate:    l.CreatedDate,
+		})
+	}
+
+	actions := make([]FilterOption, 0, len(database.AuditActions))
+	for _, action := range database.AuditActions {
+		actions = append(actions, FilterOption{Value: action, Name: actionName(action)})
+	}
+
+	data := PageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "감사 로그",
+		ActiveMenu:   "audit",
+		Logs:         logs,
+		Actions:      actions,
+		EntityTypes:  entityTypeNames,
+		Pagination:   pagination,
+		UserID:       filter.UserID,
+		Action:       filter.Action,
+		EntityType:   filter.EntityType,
+		EntityID:     filter.EntityID,
+		DateFrom:     filter.DateFrom,
+		DateTo:       filter.DateTo,
+		TotalCount:   totalItems,
+	}
+
+	if err := Templates.ExecuteTemplate(w, "audit/list.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
+
+// actionName - 작업 종류 표시 이름 (정의되지 않은 값은 그대로)
+func actionName(action string) string {
+	if name, ok := database.AuditActionDisplayNames[action]; ok {
+		return name
+	}
+	return action
+}
+
+// entityTypeName - 대상 종류 표시 이름 (정의되지 않은 값은 그대로)
+func entityTypeName(entityType string) string {
+	for _, option := range entityTypeNames {
+		if option.Value == entityType {
+			return option.Name
+		}
+	}
+	return entityType
+}
+
+// validDate - YYYY-MM-DD 형식이 아니면 빈 문자열 반환
+func validDate(value string) string {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// prettyJSON - JSON 문자열을 들여쓰기해 반환 (JSON이 아니면 원문 그대로)
+func prettyJSON(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(raw), "", "  "); err != nil {
+		return raw
+	}
+	return buf.String()
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"backoffice/middleware"
+	"backoffice/utils"
+)
+
+// LogItem - 감사 로그 화면용 데이터
+type LogItem struct {
+	UserID         string
+	Action         string
+	ActionName     string
+	EntityType     string
+	EntityTypeName string
+	EntityID       string
+	BeforeData     string // 보기 좋게 들여쓴 JSON
+	AfterData      string // 보기 좋게 들여쓴 JSON
+	IPAddress      string
+	CreatedDate    string
+}
+
+// FilterOption - 필터 선택박스 항목
+type FilterOption struct {
+	Value string
+	Name  string
+}
+
+// PageData - 감사 로그 페이지 데이터 구조체
+type PageData struct {
+	middleware.BasePageData
+	Title       string
+	ActiveMenu  string
+	Logs        []LogItem
+	Actions     []FilterOption
+	EntityTypes []FilterOption
+	Pagination  utils.Pagination
+	UserID      string
+	Action      string
+	EntityType  string
+	EntityID    string
+	DateFrom    string
+	DateTo      string
+	TotalCount  int
+}
@@ -3,6 +3,7 @@ package board
 import (
 	"backoffice/config"
 	"backoffice/database"
+	"backoffice/utils"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -56,7 +57,7 @@ type kakaoAccount struct {
 // KakaoLoginHandler - 공개 게시판용 카카오 로그인 시작
 func KakaoLoginHandler(w http.ResponseWriter, r *http.Request) {
 	// 전체 쿼리 로그
-	log.Printf("KakaoLoginHandler - Incoming RawQuery: %s", r.URL.RawQuery)
+	log.Printf("KakaoLoginHandler - Incoming RawQuery: %s", utils.RedactPII(r.URL.RawQuery))
 
 	// "state" 파라미터 확인 (예: state=17)
 	branchSeq := r.URL.Query().Get("state")
@@ -203,7 +204,7 @@ func KakaoCallbackHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("카카오 회원 로그인 성공 - seq: %d, 이름: %s", memberSeq, memberName)
+	log.Printf("카카오 회원 로그인 성공 - seq: %d, 이름: %s", memberSeq, utils.MaskName(memberName))
 
 	// 신규 가입인 경우 완료 페이지로, 기존 회원은 메인으로
 	if isNew {
@@ -309,14 +310,22 @@ func UnlinkKakaoUser(kakaoID int64) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("카카오 unlink 실패 - status: %d, body: %s, kakao_id: %d", resp.StatusCode, string(body), kakaoID)
+		log.Printf("카카오 unlink 실패 - status: %d, body: %s, kakao_id: %d", resp.StatusCode, utils.RedactPII(string(body)), kakaoID)
 		return fmt.Errorf("카카오 unlink API 실패 (status: %d): %s", resp.StatusCode, string(body))
 	}
 
-	log.Printf("카카오 unlink 성공 - kakao_id: %d, response: %s", kakaoID, string(body))
+	log.Printf("카카오 unlink 성공 - kakao_id: %d, response: %s", kakaoID, utils.RedactPII(string(body)))
 	return nil
 }
 
+// UnlinkPurgedCustomer - 휴지통에서 영구 삭제된 카카오 가입 고객의 카카오 연결 해제
+// database.OnKakaoCustomerPurged에 등록하여 사용 (고객 행은 이미 삭제되었으므로 실패는 로그만 남김)
+func UnlinkPurgedCustomer(customer database.PurgedKakaoCustomer) {
+	if err := UnlinkKakaoUser(customer.KakaoID); err != nil {
+		log.Printf("영구 삭제 고객 카카오 연결 해제 실패 - CustomerSeq: %d, error: %v", customer.CustomerSeq, err)
+	}
+}
+
 // --- 내부 헬퍼 함수 ---
 
 func generateBoardState(branchSeq string) string {
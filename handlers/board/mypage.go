@@ -87,7 +87,7 @@ func WithdrawHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("회원탈퇴 완료 - seq: %d, 이름: %s", memberSeq, memberName)
+	log.Printf("회원탈퇴 완료 - seq: %d, 이름: %s", memberSeq, utils.MaskName(memberName))
 
 	// 세션 삭제
 	session, _ := config.SessionStore.Get(r, "board-session")
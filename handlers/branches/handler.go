@@ -322,7 +322,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// DB에서 지점 삭제
-	rowsAffected, err := database.DeleteBranch(id)
+	rowsAffected, err := database.DeleteBranch(middleware.GetAuditActor(r), id)
 	if err != nil {
 		log.Printf("지점 삭제 오류: %v", err)
 		http.Redirect(w, r, "/branches?error=delete_failed", http.StatusSeeOther)
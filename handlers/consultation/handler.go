@@ -2,11 +2,13 @@ package consultation
 
 import (
 	"backoffice/database"
+	"backoffice/utils"
 	"html/template"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 var Templates *template.Template
@@ -39,6 +41,7 @@ func SubmitHandler(w http.ResponseWriter, r *http.Request) {
 
 	name := strings.TrimSpace(r.FormValue("name"))
 	phoneNumber := strings.TrimSpace(r.FormValue("phone_number"))
+	region := strings.TrimSpace(r.FormValue("region")) // 선택 항목 (지역 배정 규칙에 사용)
 
 	// 유효성 검사
 	if name == "" {
@@ -65,19 +68,25 @@ func SubmitHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 상담신청을 통해 들어온 고객은 지점 미배정 상태 (NULL)
-	// 관리자가 나중에 지점을 배정할 수 있음
-	var branchSeq *int = nil
+	if utf8.RuneCountInString(region) > 50 {
+		renderError(w, "지역은 50자 이하로 입력해주세요.")
+		return
+	}
 
-	// 고객 정보 저장
-	customerSeq, err := database.CreateCustomer(branchSeq, name, phoneNumber, "", "", "상담신청")
+	// 자동 배정 규칙에 맞으면 해당 지점으로, 맞는 규칙이 없으면 지점 미배정 상태 (NULL)
+	// 미배정 고객은 관리자가 미배정 고객 화면에서 지점을 배정
+	result, branchSeq, err := database.CreateConsultationCustomer(name, phoneNumber, region)
 	if err != nil {
 		log.Printf("고객 정보 저장 오류: %v", err)
+		if _, ok := err.(*database.DuplicateCustomerError); ok {
+			renderError(w, "이미 상담 신청이 접수된 전화번호입니다. 담당자가 곧 연락드리겠습니다.")
+			return
+		}
 		renderError(w, "상담 신청 중 오류가 발생했습니다. 잠시 후 다시 시도해주세요.")
 		return
 	}
 
-	log.Printf("상담 신청 완료 - Customer ID: %d, Name: %s, Phone: %s", customerSeq, name, phoneNumber)
+	log.Printf("상담 신청 완료 - Customer ID: %d, Name: %s, Phone: %s, DuplicateOf: %d, BranchSeq: %d", result.Seq, utils.MaskName(name), utils.MaskPhoneNumber(phoneNumber), result.DuplicateOf, branchSeq)
 
 	// 성공 페이지로 리다이렉트
 	http.Redirect(w, r, "/consultation/success?name="+name, http.StatusSeeOther)
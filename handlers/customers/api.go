@@ -1,19 +1,19 @@
 package customers
 
 import (
-	"backoffice/config"
 	"backoffice/database"
-	"backoffice/handlers/board"
 	"backoffice/middleware"
 	"backoffice/utils"
+	"database/sql"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // UpdateCommentHandler godoc
 // @Summary      고객 코멘트 업데이트
-// @Description  고객의 코멘트를 업데이트합니다
+// @Description  고객 메모를 고정 메모로 추가합니다 (가장 최근 고정 메모가 목록 코멘트로 표시되며 이전 메모는 메모 목록에 남습니다)
 // @Tags         customers
 // @Accept       x-www-form-urlencoded
 // @Produce      json
@@ -22,8 +22,10 @@ import (
 // @Success      200  {object}  map[string]interface{}  "성공"
 // @Failure      400  {string}  string  "잘못된 요청"
 // @Failure      401  {string}  string  "인증 실패"
+// @Failure      404  {string}  string  "고객 없음"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /customers/comment [post]
 func UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -43,8 +45,24 @@ func UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 코멘트 업데이트
-	err = database.UpdateCustomerComment(customerSeq, comment)
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
+	comment = strings.TrimSpace(comment)
+	if err := ValidateCustomerNote(comment); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 코멘트는 고정 메모로 추가 (이전 메모와 작성자는 메모 목록에 남음)
+	_, err = database.AddCustomerNote(middleware.GetAuditActor(r), customerSeq, comment, true)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusNotFound, "고객을 찾을 수 없습니다")
+		return
+	}
 	if err != nil {
 		log.Printf("코멘트 업데이트 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, "database failed: "+err.Error())
@@ -69,6 +87,7 @@ func UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      400  {string}  string  "잘못된 요청"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /customers/process-call [post]
 func ProcessCallHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -89,25 +108,35 @@ func ProcessCallHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
 	if caller == "" {
 		utils.JSONError(w, http.StatusBadRequest, "Caller is required")
 		return
 	}
 
 	// CALLER 선택 이력 저장 + 통화 횟수 증가 (트랜잭션)
-	callCount, lastUpdateDate, err := database.ProcessCallWithCallerSelection(customerSeq, branchSeq, caller)
+	result, err := database.ProcessCallWithCallerSelection(middleware.GetAuditActor(r), customerSeq, branchSeq, caller)
 	if err != nil {
 		log.Printf("통화 처리 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, "Failed to process call")
 		return
 	}
 
-	log.Printf("통화 횟수 증가 처리 완료 - CustomerSeq: %d, Caller: %s, CallCount: %d", customerSeq, caller, callCount)
+	log.Printf("통화 횟수 증가 처리 완료 - CustomerSeq: %d, Caller: %s, CallCount: %d, Status: %s", customerSeq, caller, result.CallCount, result.Status)
 
 	// 성공 응답
 	utils.JSONSuccess(w, map[string]interface{}{
-		"call_count":       callCount,
-		"last_update_date": lastUpdateDate,
+		"call_count":       result.CallCount,
+		"call_limit":       result.CallLimit,
+		"last_update_date": result.LastUpdateDate,
+		"status":           result.Status,
+		"status_color":     result.StatusColor,
+		"status_active":    result.StatusActive,
 		"message":          "해당 고객에 대한 통화 횟수 증가가 완료되었습니다",
 	})
 }
@@ -126,6 +155,7 @@ func ProcessCallHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      401  {string}  string  "인증 실패"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /customers/reservation [post]
 func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -150,25 +180,29 @@ func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	caller = callerValue // validated caller
 
-	// 세션에서 사용자 정보 가져오기
-	session, err := config.SessionStore.Get(r, "user-session")
-	if err != nil {
-		log.Printf("세션 조회 오류: %v", err)
-		http.Error(w, "Session error", http.StatusInternalServerError)
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 
-	userSeq, err := ValidateUserSeqFromSession(session.Values["user_seq"])
-	if err != nil {
-		log.Printf("세션 검증 실패: %v", err)
-		http.Error(w, "User not found in session", http.StatusUnauthorized)
+	// 로그인 사용자 정보 가져오기 (세션 또는 API 토큰)
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		log.Printf("로그인 사용자 없음")
+		http.Error(w, "User not found", http.StatusUnauthorized)
 		return
 	}
+	userSeq := user.Seq
 
 	// 예약 정보 저장
-	reservationID, err := database.CreateReservation(branchSeq, customerSeq, userSeq, caller, interviewDate)
+	reservationID, err := database.CreateReservation(middleware.GetAuditActor(r), branchSeq, customerSeq, userSeq, caller, interviewDate)
 	if err != nil {
 		log.Printf("예약 생성 오류: %v", err)
+		if transitionErr, ok := err.(*database.InvalidStatusTransitionError); ok {
+			http.Error(w, transitionErr.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, "Failed to create reservation", http.StatusInternalServerError)
 		return
 	}
@@ -182,6 +216,73 @@ func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RevertStatusHandler godoc
+// @Summary      고객 상태 되돌리기
+// @Description  실수로 바뀐 고객 상태를 이전 상태로 되돌립니다 (가장 최근 상태 변경만 가능)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  string  true  "고객 시퀀스"
+// @Param        history_seq   formData  string  true  "되돌릴 상태 변경 이력 시퀀스"
+// @Param        reason        formData  string  true  "되돌리는 사유 (최대 200자)"
+// @Success      200  {object}  map[string]interface{}  "성공"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      409  {string}  string  "되돌릴 수 없는 상태 변경"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/status/revert [post]
+func RevertStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		log.Printf("customer_seq 검증 실패: %v", err)
+		utils.JSONError(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	historySeq, err := strconv.ParseInt(r.FormValue("history_seq"), 10, 64)
+	if err != nil || historySeq <= 0 {
+		utils.JSONError(w, http.StatusBadRequest, "Invalid history ID")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if err := ValidateStatusRevertReason(reason); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
+	status, err := database.RevertCustomerStatus(middleware.GetAuditActor(r), customerSeq, historySeq, reason)
+	if err != nil {
+		if revertErr, ok := err.(*database.StatusRevertError); ok {
+			utils.JSONError(w, http.StatusConflict, revertErr.Message)
+			return
+		}
+		log.Printf("상태 되돌리기 오류: %v", err)
+		utils.JSONError(w, http.StatusInternalServerError, "Failed to revert status")
+		return
+	}
+
+	log.Printf("상태 되돌리기 완료 - CustomerSeq: %d, HistorySeq: %d, Status: %s", customerSeq, historySeq, status)
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"status":  status,
+		"message": "상태를 '" + status + "'(으)로 되돌렸습니다",
+	})
+}
+
 // UpdateCustomerNameHandler godoc
 // @Summary      고객 이름 업데이트
 // @Description  고객의 이름을 업데이트합니다
@@ -194,6 +295,7 @@ func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      400  {string}  string  "잘못된 요청"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /customers/update-name [post]
 func UpdateCustomerNameHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -213,6 +315,12 @@ func UpdateCustomerNameHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
 	err = ValidateCustomerName(name)
 	if err != nil {
 		log.Printf("이름 검증 실패: %v", err)
@@ -221,7 +329,7 @@ func UpdateCustomerNameHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 이름 업데이트
-	err = database.UpdateCustomerName(customerSeq, name)
+	err = database.UpdateCustomerName(middleware.GetAuditActor(r), customerSeq, name)
 	if err != nil {
 		log.Printf("이름 업데이트 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, "Failed to update name : "+err.Error())
@@ -232,7 +340,7 @@ func UpdateCustomerNameHandler(w http.ResponseWriter, r *http.Request) {
 	utils.JSONSuccess(w, map[string]interface{}{"success": true})
 }
 
-// DeleteCustomerHandler - 고객 삭제 API
+// DeleteCustomerHandler - 고객 삭제 API (휴지통으로 이동)
 // DELETE /api/customers/delete
 func DeleteCustomerHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -255,22 +363,15 @@ func DeleteCustomerHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 카카오 연결 해제 처리 (카카오 가입 고객인 경우)
-	customer, err := database.GetCustomerBySeqForMypage(customerSeq)
-	if err == nil && customer != nil && customer.KakaoID != 0 {
-		log.Printf("카카오 고객 삭제 시도 - CustomerSeq: %d, KakaoID: %d", customerSeq, customer.KakaoID)
-		if err := board.UnlinkKakaoUser(customer.KakaoID); err != nil {
-			log.Printf("카카오 unlink 실패 (삭제 중) - seq: %d, kakao_id: %d, error: %v", customerSeq, customer.KakaoID, err)
-			// unlink 실패해도 DB 삭제는 진행할지 고민 필요.
-			// 여기선 사용자 경험을 위해 중단하거나, 혹은 로그만 남기고 진행할 수 있음.
-			// 일단 board.WithdrawHandler와 동일하게 에러 반환.
-			utils.JSONError(w, http.StatusInternalServerError, "카카오 연결 해제 중 오류가 발생했습니다: "+err.Error())
-			return
-		}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
 	}
 
-	// 고객 삭제 (reservation_info는 FK constraint에 의해 자동으로 customer_id가 NULL로 변경됨)
-	err = database.DeleteCustomer(customerSeq)
+	// 고객 삭제 (휴지통으로 이동, 예약/통화 이력은 복원할 수 있도록 유지)
+	// 카카오 연결 해제는 복원할 수 없으므로 휴지통에서 영구 삭제될 때 처리
+	err = database.DeleteCustomer(middleware.GetAuditActor(r), customerSeq)
 	if err != nil {
 		log.Printf("고객 삭제 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, "Failed to delete customer")
@@ -279,7 +380,7 @@ func DeleteCustomerHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("고객 삭제 완료 - CustomerSeq: %d", customerSeq)
 	utils.JSONSuccess(w, map[string]interface{}{
-		"message": "고객이 삭제되었습니다",
+		"message": "고객을 휴지통으로 이동했습니다",
 	})
 }
 
@@ -295,6 +396,7 @@ func DeleteCustomerHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      400  {string}  string  "잘못된 요청"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /customers/mark-no-phone-interview [post]
 func MarkNoPhoneInterviewHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -315,15 +417,25 @@ func MarkNoPhoneInterviewHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
 	if caller == "" {
 		utils.JSONError(w, http.StatusBadRequest, "Caller is required")
 		return
 	}
 
 	// 전화상안함 처리 (상태 변경 + CALLER 이력 저장 + call_count 업데이트)
-	err = database.MarkCustomerAsNoPhoneInterview(customerSeq, branchSeq, caller)
+	err = database.MarkCustomerAsNoPhoneInterview(middleware.GetAuditActor(r), customerSeq, branchSeq, caller)
 	if err != nil {
 		log.Printf("전화상안함 처리 오류: %v", err)
+		if transitionErr, ok := err.(*database.InvalidStatusTransitionError); ok {
+			utils.JSONError(w, http.StatusConflict, transitionErr.Error())
+			return
+		}
 		utils.JSONError(w, http.StatusInternalServerError, "Failed to mark as no phone interview")
 		return
 	}
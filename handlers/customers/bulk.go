@@ -0,0 +1,412 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/services/sms"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bulkSelection - 일괄 처리 공통 요청 검증 (POST, 선택 지점, customer_seq[])
+// 검증에 실패하면 JSON 에러 응답을 쓰고 ok=false 반환
+func bulkSelection(w http.ResponseWriter, r *http.Request) (branchSeq int, customerSeqs []int, ok bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return 0, nil, false
+	}
+
+	branchSeq = middleware.GetSelectedBranch(r)
+	if branchSeq == 0 {
+		utils.JSONError(w, http.StatusUnauthorized, "지점 정보가 없습니다.")
+		return 0, nil, false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "잘못된 요청입니다.")
+		return 0, nil, false
+	}
+
+	customerSeqs, err := ValidateBulkCustomerSeqs(r.Form["customer_seq"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return 0, nil, false
+	}
+	return branchSeq, customerSeqs, true
+}
+
+// writeBulkResults - 일괄 처리 결과 응답 (요약 메시지 + 고객별 결과)
+func writeBulkResults(w http.ResponseWriter, action string, results []database.BulkResult) {
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"message":   fmt.Sprintf("%s: 성공 %d명, 실패 %d명", action, succeeded, len(results)-succeeded),
+		"total":     len(results),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+// BulkStatusHandler godoc
+// @Summary      선택 고객 상태 일괄 변경
+// @Description  선택한 고객의 상태를 하나의 트랜잭션으로 변경하고 고객별 결과를 반환합니다 (허용되지 않은 변경은 해당 고객만 실패)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  []int   true   "고객 시퀀스 (여러 개)"
+// @Param        status        formData  string  true   "변경할 상태"
+// @Param        reason        formData  string  false  "변경 사유"
+// @Success      200  {object}  map[string]interface{}  "고객별 결과"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/bulk/status [post]
+func BulkStatusHandler(w http.ResponseWriter, r *http.Request) {
+	branchSeq, customerSeqs, ok := bulkSelection(w, r)
+	if !ok {
+		return
+	}
+
+	status := strings.TrimSpace(r.FormValue("status"))
+	if status == "" {
+		utils.JSONError(w, http.StatusBadRequest, "변경할 상태를 선택해주세요.")
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if err := ValidateBulkStatusReason(reason); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if reason == "" {
+		reason = "일괄 상태 변경"
+	} else {
+		reason = "일괄 상태 변경: " + reason
+	}
+
+	results, err := database.BulkChangeCustomerStatus(middleware.GetAuditActor(r), branchSeq, customerSeqs, status, reason)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusBadRequest, "등록되지 않은 고객 상태입니다.")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "상태 일괄 변경 중 오류가 발생했습니다.")
+		return
+	}
+
+	writeBulkResults(w, "상태 변경", results)
+}
+
+// BulkDeleteHandler godoc
+// @Summary      선택 고객 일괄 삭제
+// @Description  선택한 고객을 하나의 트랜잭션으로 휴지통에 이동하고 고객별 결과를 반환합니다
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  []int  true  "고객 시퀀스 (여러 개)"
+// @Success      200  {object}  map[string]interface{}  "고객별 결과"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/bulk/delete [post]
+func BulkDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	branchSeq, customerSeqs, ok := bulkSelection(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := database.BulkDeleteCustomers(middleware.GetAuditActor(r), branchSeq, customerSeqs)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "고객 일괄 삭제 중 오류가 발생했습니다.")
+		return
+	}
+
+	writeBulkResults(w, "삭제", results)
+}
+
+// BulkTransferHandler godoc
+// @Summary      선택 고객 지점 일괄 이동
+// @Description  선택한 고객을 하나의 트랜잭션으로 다른 지점에 이동하고 고객별 결과를 반환합니다 (customers:assign 권한 필요)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq   formData  []int  true  "고객 시퀀스 (여러 개)"
+// @Param        to_branch_seq  formData  int    true  "이동할 지점 시퀀스"
+// @Success      200  {object}  map[string]interface{}  "고객별 결과"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "권한 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/bulk/transfer [post]
+func BulkTransferHandler(w http.ResponseWriter, r *http.Request) {
+	branchSeq, customerSeqs, ok := bulkSelection(w, r)
+	if !ok {
+		return
+	}
+
+	// 지점 간 이동은 미배정 고객 배정과 같은 권한으로 제한
+	user := middleware.GetCurrentUser(r)
+	if user == nil || !middleware.HasPermission(user.Role, middleware.PermCustomerAssign) {
+		utils.JSONError(w, http.StatusForbidden, "고객 지점 이동 권한이 없습니다.")
+		return
+	}
+
+	toBranchSeq, err := strconv.Atoi(r.FormValue("to_branch_seq"))
+	if err != nil || toBranchSeq <= 0 {
+		utils.JSONError(w, http.StatusBadRequest, "이동할 지점을 선택해주세요.")
+		return
+	}
+	if toBranchSeq == branchSeq {
+		utils.JSONError(w, http.StatusBadRequest, "현재 지점과 다른 지점을 선택해주세요.")
+		return
+	}
+	if !middleware.CanAccessBranch(r, toBranchSeq) {
+		utils.JSONError(w, http.StatusForbidden, "해당 지점에 접근할 수 없습니다.")
+		return
+	}
+
+	results, err := database.BulkTransferCustomers(middleware.GetAuditActor(r), branchSeq, customerSeqs, toBranchSeq)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusBadRequest, "지점을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "지점 일괄 이동 중 오류가 발생했습니다.")
+		return
+	}
+
+	writeBulkResults(w, "지점 이동", results)
+}
+
+// bulkMessageRenderer - 템플릿 변수를 고객/지점 정보로 치환하는 함수 (static/js/template-utils.js의 replaceTemplateVariables와 같은 변수)
+// 지점 정보는 한 번만 조회하고, 예약 관련 변수와 담당자/메모는 일괄 발송에서 알 수 없으므로 빈 문자열로 치환
+func bulkMessageRenderer(r *http.Request, content string, now time.Time) func(database.BulkSMSTarget) string {
+	unescape := strings.NewReplacer(`\r\n`, "\n", `\n`, "\n", `\r`, "\r", `\t`, "\t")
+	content = unescape.Replace(content)
+
+	common := []string{
+		"{{예약일시}}", "",
+		"{{예약날짜}}", "",
+		"{{예약시간}}", "",
+		"{{예약일자}}", "",
+		"{{지점명}}", middleware.GetSelectedBranchName(r),
+		"{{지점주소}}", middleware.GetSelectedBranchAddress(r),
+		"{{지점담당자}}", middleware.GetSelectedBranchManager(r),
+		"{{오시는길}}", middleware.GetSelectedBranchDirections(r),
+		"{{현재날짜시간}}", now.Format("2006-01-02 15:04"),
+		"{{현재날짜}}", now.Format("2006-01-02"),
+		"{{현재시간}}", now.Format("15:04"),
+		"{{담당자}}", "",
+		"{{메모}}", "",
+	}
+
+	return func(target database.BulkSMSTarget) string {
+		name := target.Name
+		if name == "" {
+			name = "고객"
+		}
+		pairs := append([]string{"{{고객명}}", name, "{{전화번호}}", target.PhoneNumber}, common...)
+		return strings.NewReplacer(pairs...).Replace(content)
+	}
+}
+
+// BulkSMSHandler godoc
+// @Summary      선택 고객 템플릿 문자 일괄 발송
+// @Description  선택한 고객에게 같은 템플릿을 고객별로 치환해 발송하고 고객별 결과를 반환합니다. 수신거부 번호는 발송하지 않으며, 발송 전 마이문자 잔여건수가 부족하면 발송하지 않습니다
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  []int   true  "고객 시퀀스 (여러 개)"
+// @Param        template_seq  formData  int     true  "메시지 템플릿 시퀀스"
+// @Param        sender_phone  formData  string  true  "발신번호"
+// @Success      200  {object}  map[string]interface{}  "고객별 결과"
+// @Failure      400  {string}  string  "잘못된 요청 또는 잔여건수 부족"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/bulk/sms [post]
+func BulkSMSHandler(w http.ResponseWriter, r *http.Request) {
+	branchSeq, customerSeqs, ok := bulkSelection(w, r)
+	if !ok {
+		return
+	}
+
+	// SMS 설정 확인 (활성화 + 등록된 발신번호)
+	smsConfig, err := database.GetSMSConfig(branchSeq)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "SMS 설정을 조회할 수 없습니다.")
+		return
+	}
+	if smsConfig == nil {
+		utils.JSONError(w, http.StatusBadRequest, "SMS 설정이 등록되지 않았습니다.")
+		return
+	}
+	if !smsConfig.IsActive {
+		utils.JSONError(w, http.StatusBadRequest, "마이문자 연동이 비활성화 상태입니다.\n연동 관리 페이지에서 마이문자를 활성화해주세요.")
+		return
+	}
+
+	senderPhone := r.FormValue("sender_phone")
+	senderRegistered := false
+	for _, phone := range smsConfig.SenderPhones {
+		if phone == senderPhone {
+			senderRegistered = true
+			break
+		}
+	}
+	if !senderRegistered {
+		utils.JSONError(w, http.StatusBadRequest, "등록된 발신번호를 선택해주세요.")
+		return
+	}
+
+	// 지점의 사용 중인 템플릿만 발송 가능
+	templateSeq, _ := strconv.Atoi(r.FormValue("template_seq"))
+	templates, err := database.GetMessageTemplates(branchSeq, false)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "메시지 템플릿을 조회할 수 없습니다.")
+		return
+	}
+	var content string
+	for _, t := range templates {
+		if t.ID == templateSeq {
+			content = t.Content
+			break
+		}
+	}
+	if content == "" {
+		utils.JSONError(w, http.StatusBadRequest, "메시지 템플릿을 선택해주세요.")
+		return
+	}
+
+	targets, err := database.GetBulkSMSTargets(branchSeq, customerSeqs)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "발송 대상 고객을 조회할 수 없습니다.")
+		return
+	}
+
+	// 수신거부 번호는 발송 대상과 잔여건수 계산에서 제외
+	var phones []string
+	for _, target := range targets {
+		phones = append(phones, target.PhoneNumber)
+	}
+	optedOut, err := database.GetSMSOptedOutPhones(branchSeq, phones)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "수신거부 목록을 확인할 수 없습니다.")
+		return
+	}
+
+	// 고객별 메시지 치환 후 필요한 SMS/LMS 건수 계산
+	render := bulkMessageRenderer(r, content, time.Now())
+	messages := map[int]string{}
+	required := map[string]int{}
+	for _, seq := range customerSeqs {
+		target, ok := targets[seq]
+		if !ok || target.PhoneNumber == "" || optedOut[utils.NormalizePhoneNumber(target.PhoneNumber)] {
+			continue
+		}
+		message := render(target)
+		messages[seq] = message
+		required[sms.MessageType(message)]++
+	}
+	if len(messages) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "발송할 수 있는 고객이 없습니다. (전화번호 없음 또는 수신거부)")
+		return
+	}
+
+	// 발송 전 마이문자 잔여건수 확인 (부족하면 한 건도 발송하지 않음)
+	remainingSMS, remainingLMS, err := sms.CheckRemainingCount(smsConfig.AccountID, smsConfig.Password)
+	if err != nil {
+		log.Printf("일괄 발송 잔여건수 조회 오류: %v", err)
+		utils.JSONError(w, http.StatusInternalServerError, "마이문자 잔여건수를 확인할 수 없습니다.")
+		return
+	}
+	if required["SMS"] > remainingSMS || required["LMS"] > remainingLMS {
+		utils.JSONError(w, http.StatusBadRequest, fmt.Sprintf("마이문자 잔여건수가 부족합니다. (필요 SMS %d건/LMS %d건, 잔여 SMS %d건/LMS %d건)",
+			required["SMS"], required["LMS"], remainingSMS, remainingLMS))
+		return
+	}
+
+	// 고객별 발송 (외부 발송은 되돌릴 수 없으므로 고객마다 결과와 감사 로그를 남김)
+	actor := middleware.GetAuditActor(r)
+	lastCols := map[string]string{}
+	stopped := ""
+	var results []database.BulkResult
+	for _, seq := range customerSeqs {
+		message, ok := messages[seq]
+		if !ok {
+			if target, found := targets[seq]; found && optedOut[utils.NormalizePhoneNumber(target.PhoneNumber)] {
+				results = append(results, database.BulkResult{CustomerSeq: seq, Message: sms.ErrOptedOut.Error()})
+				continue
+			}
+			results = append(results, database.BulkResult{CustomerSeq: seq, Message: "고객을 찾을 수 없거나 전화번호가 없습니다"})
+			continue
+		}
+		if stopped != "" {
+			results = append(results, database.BulkResult{CustomerSeq: seq, Message: "발송 중단: " + stopped})
+			continue
+		}
+
+		target := targets[seq]
+		sendResp, err := sms.Send(sms.SendRequest{
+			AccountID:     smsConfig.AccountID,
+			Password:      smsConfig.Password,
+			SenderPhone:   senderPhone,
+			ReceiverPhone: target.PhoneNumber,
+			Message:       message,
+			BranchSeq:     branchSeq,
+		})
+		if err != nil {
+			log.Printf("일괄 발송 오류 - 고객 ID: %d, %v", seq, err)
+			results = append(results, database.BulkResult{CustomerSeq: seq, Message: err.Error()})
+			continue
+		}
+		if !sendResp.Success {
+			results = append(results, database.BulkResult{CustomerSeq: seq, Message: sendResp.Message})
+			// 잔여콜수 없음/부족이면 나머지 고객은 발송하지 않음
+			if sendResp.Code == "0003" || sendResp.Code == "0008" {
+				stopped = sendResp.Message
+			}
+			continue
+		}
+
+		if sendResp.Cols != "" {
+			lastCols[sendResp.MsgType] = sendResp.Cols
+		}
+		// 감사 로그에는 마스킹된 수신번호와 개인정보를 가린 메시지만 저장
+		database.RecordAudit(actor, database.AuditSMSSend, "customer", seq, nil, map[string]interface{}{
+			"branch_seq":     branchSeq,
+			"sender_phone":   senderPhone,
+			"receiver_phone": utils.MaskPhoneNumber(target.PhoneNumber),
+			"msg_type":       sendResp.MsgType,
+			"message":        utils.RedactPII(message),
+			"template_seq":   templateSeq,
+			"bulk":           true,
+		})
+		results = append(results, database.BulkResult{CustomerSeq: seq, Success: true, Message: "발송 완료"})
+	}
+
+	// 마지막 발송 응답 기준으로 잔여건수 업데이트
+	for msgType, cols := range lastCols {
+		if err := sms.UpdateRemainingCount(branchSeq, cols, msgType); err != nil {
+			log.Printf("%s 잔여건수 업데이트 실패: %v", msgType, err)
+		}
+	}
+
+	log.Printf("일괄 문자 발송 완료 - BranchSeq: %d, TemplateSeq: %d, 요청: %d", branchSeq, templateSeq, len(customerSeqs))
+	writeBulkResults(w, "문자 발송", results)
+}
@@ -0,0 +1,194 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// callbackRedirectURL - 콜백 처리 후 이동할 주소 (return=dashboard이면 대시보드, 아니면 고객 상세)
+func callbackRedirectURL(r *http.Request, customerSeq int) string {
+	if r.FormValue("return") == "dashboard" {
+		return "/dashboard"
+	}
+	return fmt.Sprintf("/customers/detail?seq=%d#callbacks", customerSeq)
+}
+
+// toCallbackItems - DB 콜백 목록을 화면용 목록으로 변환
+func toCallbackItems(tasks []database.CallbackTask) []CallbackItem {
+	var items []CallbackItem
+	for _, t := range tasks {
+		items = append(items, CallbackItem{
+			ID:              t.Seq,
+			DueAt:           t.DueAt,
+			Owner:           t.OwnerUserID,
+			Note:            t.Note,
+			CreatedBy:       t.CreatedBy,
+			CreatedDate:     t.CreatedDate,
+			Completed:       t.CompletedAt != "",
+			CompletedAt:     t.CompletedAt,
+			CompletedBy:     t.CompletedBy,
+			CompletedCaller: t.CompletedCaller,
+			Overdue:         t.Overdue,
+		})
+	}
+	return items
+}
+
+// CallbackCreateHandler - 고객 콜백 등록 (POST customer_seq, due_at, owner_user_seq, note)
+func CallbackCreateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("콜백 등록 접근 거부: %v", err)
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+
+	redirectURL := callbackRedirectURL(r, customerSeq)
+	note := strings.TrimSpace(r.FormValue("note"))
+	dueAt, err := ValidateCallbackForm(r.FormValue("due_at"), note)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	// 담당자는 고객 지점 계정 또는 전체 지점 계정만 지정 가능
+	var ownerUserSeq *int
+	if ownerStr := r.FormValue("owner_user_seq"); ownerStr != "" {
+		owners, err := database.GetCallbackOwners(customerSeq)
+		if err != nil {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+		for _, o := range owners {
+			if strconv.Itoa(o.Seq) == ownerStr {
+				seq := o.Seq
+				ownerUserSeq = &seq
+				break
+			}
+		}
+		if ownerUserSeq == nil {
+			utils.SetFlashMessage(w, r, "error", "담당자를 찾을 수 없습니다.")
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+	}
+
+	if _, err := database.CreateCallback(middleware.GetAuditActor(r), customerSeq, dueAt, ownerUserSeq, note); err != nil {
+		if err == sql.ErrNoRows {
+			utils.SetFlashMessage(w, r, "error", "고객을 찾을 수 없습니다.")
+		} else {
+			utils.SetFlashMessage(w, r, "error", "콜백 등록 중 오류가 발생했습니다.")
+		}
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", "콜백이 등록되었습니다.")
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// CallbackCompleteHandler - 고객 콜백 완료 (POST customer_seq, callback_seq, caller, return)
+// 완료와 함께 통화 처리 (CALLER 선택 이력 + 통화 횟수 증가)
+func CallbackCompleteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("콜백 완료 접근 거부: %v", err)
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+
+	redirectURL := callbackRedirectURL(r, customerSeq)
+	callbackSeq, err := strconv.Atoi(r.FormValue("callback_seq"))
+	if err != nil {
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	caller := r.FormValue("caller")
+	if len(filterAllowed([]string{caller}, callerLetters)) != 1 {
+		utils.SetFlashMessage(w, r, "error", "통화한 CALLER를 선택해주세요.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	result, err := database.CompleteCallback(middleware.GetAuditActor(r), customerSeq, callbackSeq, middleware.GetSelectedBranch(r), caller)
+	if err == sql.ErrNoRows {
+		utils.SetFlashMessage(w, r, "error", "완료할 콜백을 찾을 수 없습니다. 이미 완료되었는지 확인해주세요.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "콜백 완료 중 오류가 발생했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", fmt.Sprintf("콜백을 완료하고 통화를 기록했습니다. (누적 %d회, 상태: %s)", result.CallCount, result.Status))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// CallbackCancelHandler - 완료되지 않은 고객 콜백 취소 (POST customer_seq, callback_seq)
+func CallbackCancelHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("콜백 취소 접근 거부: %v", err)
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+
+	redirectURL := callbackRedirectURL(r, customerSeq)
+	callbackSeq, err := strconv.Atoi(r.FormValue("callback_seq"))
+	if err != nil {
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.CancelCallback(middleware.GetAuditActor(r), customerSeq, callbackSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "콜백 취소 중 오류가 발생했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "콜백이 취소되었습니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "error", "취소할 콜백을 찾을 수 없습니다. 이미 완료되었는지 확인해주세요.")
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
@@ -0,0 +1,353 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/handlers/errorhandler"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// DetailHandler - 고객 상세 페이지 핸들러 (기본 정보 + 활동 타임라인)
+func DetailHandler(w http.ResponseWriter, r *http.Request) {
+	customerSeq, err := ValidateCustomerSeq(r.URL.Query().Get("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("고객 상세 접근 거부: %v", err)
+		errorhandler.Handler404(w, r)
+		return
+	}
+
+	detail, err := database.GetCustomerDetail(customerSeq)
+	if err == sql.ErrNoRows {
+		errorhandler.Handler404(w, r)
+		return
+	}
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	events, err := database.GetCustomerTimeline(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	fullPhone := canViewFullPhone(r)
+
+	var timeline []TimelineItem
+	for _, e := range events {
+		timeline = append(timeline, toTimelineItem(e, fullPhone))
+	}
+
+	candidates, err := database.GetDuplicateCandidates(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var duplicates []DuplicateItem
+	for _, c := range candidates {
+		duplicates = append(duplicates, toDuplicateItem(r, c, fullPhone))
+	}
+
+	history, err := database.GetCustomerStatusHistory(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var statusHistory []StatusHistoryItem
+	for i, h := range history {
+		statusHistory = append(statusHistory, StatusHistoryItem{
+			ID:         h.Seq,
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			Reason:     h.Reason,
+			Actor:      h.UserID,
+			ChangedAt:  h.CreatedDate,
+			Reverted:   h.RevertedSeq != nil,
+			CanRevert:  i == 0 && h.RevertedSeq == nil && h.ToStatus == detail.Status,
+		})
+	}
+
+	smsOptedOut := false
+	if detail.BranchSeq.Valid {
+		if smsOptedOut, err = database.IsSMSOptedOut(int(detail.BranchSeq.Int64), detail.PhoneNumber); err != nil {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+	}
+
+	notes, err := database.GetCustomerNotes(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	callbacks, err := database.GetCustomerCallbacks(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	owners, err := database.GetCallbackOwners(customerSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var callbackOwners []CallbackOwnerOption
+	currentUser := middleware.GetCurrentUser(r)
+	for _, o := range owners {
+		callbackOwners = append(callbackOwners, CallbackOwnerOption{
+			ID:       o.Seq,
+			UserID:   o.UserID,
+			Selected: currentUser != nil && currentUser.Seq == o.Seq,
+		})
+	}
+
+	data := DetailPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "고객 상세",
+		ActiveMenu:     "customers",
+		Customer:       toCustomerDetailView(detail, fullPhone),
+		Timeline:       timeline,
+		Duplicates:     duplicates,
+		StatusHistory:  statusHistory,
+		Notes:          toNoteItems(notes),
+		Callbacks:      toCallbackItems(callbacks),
+		CallbackOwners: callbackOwners,
+		CallerLetters:  callerLetters,
+		SMSOptedOut:    smsOptedOut,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	if err := Templates.ExecuteTemplate(w, "customers/detail.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
+
+// toCustomerDetailView - DB 고객 상세 정보를 화면용 구조체로 변환 (fullPhone=false면 전화번호 마스킹)
+func toCustomerDetailView(d *database.CustomerDetail, fullPhone bool) Customer {
+	view := Customer{
+		ID:              strconv.Itoa(d.Seq),
+		Name:            d.Name,
+		Phone:           visiblePhone(d.PhoneNumber, fullPhone),
+		Status:          d.Status,
+		RegisterDate:    d.CreatedDate,
+		LastContactDate: "-",
+		CallCount:       d.CallCount,
+		Branch:          d.BranchName,
+	}
+
+	if d.LastUpdateDate != nil {
+		view.LastContactDate = *d.LastUpdateDate
+	}
+	if d.CommercialName != nil {
+		view.AdName = *d.CommercialName
+	}
+	if d.AdSource != nil {
+		view.AdSource = *d.AdSource
+	}
+	if d.Comment != nil {
+		view.Comment = *d.Comment
+	}
+	if !d.BranchSeq.Valid {
+		view.Branch = "미배정"
+	}
+	if d.DuplicateOf != nil {
+		view.DuplicateOf = strconv.Itoa(*d.DuplicateOf)
+	}
+
+	return view
+}
+
+// toDuplicateItem - 병합 후보 고객을 화면용 구조체로 변환 (fullPhone=false면 전화번호 마스킹)
+func toDuplicateItem(r *http.Request, c database.DuplicateCandidate, fullPhone bool) DuplicateItem {
+	item := DuplicateItem{
+		ID:           strconv.Itoa(c.Seq),
+		Name:         c.Name,
+		Phone:        visiblePhone(c.PhoneNumber, fullPhone),
+		Branch:       c.BranchName,
+		Status:       c.Status,
+		CallCount:    c.CallCount,
+		RegisterDate: c.CreatedDate,
+		Accessible:   canAccessCustomerBranch(r, c.BranchSeq),
+	}
+	if !c.BranchSeq.Valid {
+		item.Branch = "미배정"
+	}
+	return item
+}
+
+// toTimelineItem - 타임라인 이벤트를 화면 표시용으로 변환
+// 감사 로그 이벤트는 변경 전/후 JSON에서 필요한 값만 꺼내 설명을 만듦 (fullPhone=false면 전화번호 마스킹)
+func toTimelineItem(e database.CustomerTimelineEvent, fullPhone bool) TimelineItem {
+	item := TimelineItem{
+		OccurredAt: e.OccurredAt,
+		Actor:      e.Actor,
+	}
+
+	before := parseAuditData(e.BeforeData)
+	after := parseAuditData(e.AfterData)
+
+	switch e.Type {
+	case database.TimelineCustomerCreated:
+		item.TypeClass, item.TypeName = "type-other", "등록"
+		item.Title = "고객 등록"
+		if e.Extra != "" {
+			item.Description = "광고 출처: " + e.Extra
+		}
+	case database.TimelineCall:
+		item.TypeClass, item.TypeName = "type-call", "통화"
+		item.Title = fmt.Sprintf("CALLER %s 통화", e.Caller)
+		item.Actor = ""
+	case database.TimelineReservation:
+		item.TypeClass, item.TypeName = "type-meeting", "예약"
+		item.Title = "상담 예약 등록"
+		item.Description = fmt.Sprintf("상담 일시: %s (CALLER %s)", e.Extra, e.Caller)
+		if len(item.OccurredAt) >= 10 {
+			item.OccurredAt = item.OccurredAt[:10] // 예약 등록은 일자만 기록됨
+		}
+	case database.AuditCustomerStatusChange:
+		item.TypeClass, item.TypeName = "type-contract", "상태"
+		item.Title = fmt.Sprintf("상태 변경: %s → %s", before["status"], after["status"])
+		if reason, ok := after["reason"].(string); ok {
+			item.Description = reason
+		}
+	case database.AuditCustomerStatusRevert:
+		item.TypeClass, item.TypeName = "type-contract", "상태"
+		item.Title = fmt.Sprintf("상태 되돌리기: %s → %s", before["status"], after["status"])
+		item.Description = auditValueOrDash(after["reason"])
+	case database.AuditCustomerUpdateComment:
+		item.TypeClass, item.TypeName = "type-quote", "코멘트"
+		item.Title = "코멘트 수정"
+		item.Description = fmt.Sprintf("%s → %s", auditValueOrDash(before["comment"]), auditValueOrDash(after["comment"]))
+	case database.AuditCustomerNoteAdd:
+		item.TypeClass, item.TypeName = "type-quote", "메모"
+		item.Title = "메모 추가"
+		if pinned, ok := after["pinned"].(bool); ok && pinned {
+			item.Title = "고정 메모 추가"
+		}
+		item.Description = auditValueOrDash(after["content"])
+	case database.AuditCustomerNoteUpdate:
+		item.TypeClass, item.TypeName = "type-quote", "메모"
+		item.Title = "메모 수정"
+		item.Description = fmt.Sprintf("%s → %s", auditValueOrDash(before["content"]), auditValueOrDash(after["content"]))
+	case database.AuditCustomerNotePin:
+		item.TypeClass, item.TypeName = "type-quote", "메모"
+		item.Title = "메모 고정 해제"
+		if pinned, ok := after["pinned"].(bool); ok && pinned {
+			item.Title = "메모 고정"
+		}
+		item.Description = auditValueOrDash(after["content"])
+	case database.AuditCustomerUpdateName:
+		item.TypeClass, item.TypeName = "type-quote", "이름"
+		item.Title = "이름 변경"
+		item.Description = fmt.Sprintf("%s → %s", auditValueOrDash(before["name"]), auditValueOrDash(after["name"]))
+	case database.AuditCustomerDuplicateAttach:
+		item.TypeClass, item.TypeName = "type-other", "중복"
+		item.Title = "같은 전화번호로 재유입 (기존 고객에 연결)"
+		item.Description = fmt.Sprintf("이름: %s / 광고 출처: %s", auditValueOrDash(after["name"]), auditValueOrDash(after["ad_source"]))
+	case database.AuditCustomerMerge:
+		item.TypeClass, item.TypeName = "type-other", "병합"
+		item.Title = fmt.Sprintf("고객 #%v 병합", after["merged_seq"])
+		mergedPhone := auditValueOrDash(before["phone_number"])
+		if mergedPhone != "-" {
+			mergedPhone = visiblePhone(mergedPhone, fullPhone)
+		}
+		item.Description = fmt.Sprintf("병합된 고객: %s (%s)", auditValueOrDash(before["name"]), mergedPhone)
+	case database.AuditCustomerAssign:
+		item.TypeClass, item.TypeName = "type-other", "배정"
+		item.Title = fmt.Sprintf("지점 배정: %s", auditValueOrDash(after["branch_name"]))
+		if ruleType, ok := after["rule_type"].(string); ok {
+			item.Description = "자동 배정 규칙: " + database.AssignmentRuleTypeNames[ruleType]
+			if value, ok := after["match_value"].(string); ok && value != "" {
+				item.Description += " (" + value + ")"
+			}
+		} else {
+			item.Description = "수동 배정"
+		}
+	case database.AuditCustomerTransfer:
+		item.TypeClass, item.TypeName = "type-other", "이동"
+		item.Title = fmt.Sprintf("지점 이동: %s → %s", auditValueOrDash(before["branch_name"]), auditValueOrDash(after["branch_name"]))
+		if removed, ok := after["removed_tags"].(float64); ok && removed > 0 {
+			item.Description = fmt.Sprintf("이전 지점 태그 %d개 제거", int(removed))
+		}
+	case database.AuditCustomerTagAdd:
+		item.TypeClass, item.TypeName = "type-other", "태그"
+		item.Title = fmt.Sprintf("태그 추가: %s", auditValueOrDash(after["tag"]))
+	case database.AuditCustomerTagRemove:
+		item.TypeClass, item.TypeName = "type-other", "태그"
+		item.Title = fmt.Sprintf("태그 제거: %s", auditValueOrDash(before["tag"]))
+	case database.AuditCustomerCallbackCreate:
+		item.TypeClass, item.TypeName = "type-email", "콜백"
+		item.Title = fmt.Sprintf("콜백 등록: %s", auditValueOrDash(after["due_at"]))
+		item.Description = callbackAuditDescription(after)
+	case database.AuditCustomerCallbackComplete:
+		item.TypeClass, item.TypeName = "type-email", "콜백"
+		item.Title = fmt.Sprintf("콜백 완료 (CALLER %s, %v회째 통화)", auditValueOrDash(after["caller"]), after["call_count"])
+		item.Description = fmt.Sprintf("예정: %s", auditValueOrDash(before["due_at"]))
+	case database.AuditCustomerCallbackCancel:
+		item.TypeClass, item.TypeName = "type-email", "콜백"
+		item.Title = fmt.Sprintf("콜백 취소: %s", auditValueOrDash(before["due_at"]))
+		item.Description = callbackAuditDescription(before)
+		if reason, ok := after["reason"].(string); ok {
+			item.Description += " (자동 취소 - " + reason + ")"
+		}
+	case database.AuditCustomerPhoneReveal:
+		item.TypeClass, item.TypeName = "type-other", "열람"
+		item.Title = "전화번호 열람"
+	case database.AuditCustomerDelete:
+		item.TypeClass, item.TypeName = "type-other", "삭제"
+		item.Title = "고객 삭제 (휴지통 이동)"
+	case database.AuditCustomerRestore:
+		item.TypeClass, item.TypeName = "type-other", "복원"
+		item.Title = "휴지통에서 복원"
+		item.Description = fmt.Sprintf("삭제일시: %s", auditValueOrDash(before["deleted_at"]))
+	case database.AuditSMSSend:
+		item.TypeClass, item.TypeName = "type-email", "SMS"
+		item.Title = fmt.Sprintf("SMS 발송 (%v)", after["msg_type"])
+		item.Description = fmt.Sprint(after["message"])
+	default:
+		item.TypeClass, item.TypeName = "type-other", "기타"
+		item.Title = database.AuditActionDisplayNames[e.Type]
+	}
+
+	return item
+}
+
+// parseAuditData - 감사 로그 JSON 값을 맵으로 변환 (비어 있거나 형식이 다르면 빈 맵)
+func parseAuditData(data string) map[string]interface{} {
+	values := map[string]interface{}{}
+	if data != "" {
+		json.Unmarshal([]byte(data), &values)
+	}
+	return values
+}
+
+// callbackAuditDescription - 콜백 감사 로그 값의 담당자/메모 표시
+func callbackAuditDescription(data map[string]interface{}) string {
+	return fmt.Sprintf("담당자: %s / 메모: %s", auditValueOrDash(data["owner"]), auditValueOrDash(data["note"]))
+}
+
+// auditValueOrDash - 감사 로그 값 표시 (없으면 "-")
+func auditValueOrDash(v interface{}) string {
+	if v == nil || v == "" {
+		return "-"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,210 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportFlushInterval - CSV 내보내기 시 응답으로 흘려보내는 행 단위
+const exportFlushInterval = 500
+
+// exportHeaders - 내보내기 파일 컬럼
+var exportHeaders = []string{"ID", "이름", "전화번호", "상태", "통화 횟수", "광고명", "광고 출처", "코멘트", "등록일시", "최근 연락일시", "중복 의심"}
+
+// ExportHandler - 고객 목록 내보내기 (CSV/XLSX)
+// 쿼리 파라미터: format (csv/xlsx), filter, searchType, searchKeyword, 복합 검색 조건 (고객 목록과 동일), mask (1이면 전화번호 마스킹, 전화번호 전체 보기 권한이 없으면 항상 마스킹)
+// 내보낼 때마다 감사 로그에 조건과 건수를 기록
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := utils.GetQueryParam(r, "format", "csv")
+	if format != "csv" && format != "xlsx" {
+		http.Error(w, "지원하지 않는 형식입니다", http.StatusBadRequest)
+		return
+	}
+
+	searchParams := utils.GetSearchParams(r)
+	filter := utils.GetQueryParam(r, "filter", "new")
+	// 전화번호 전체 보기 권한이 없으면 mask 값과 관계없이 마스킹
+	masked := r.URL.Query().Get("mask") == "1" || !canViewFullPhone(r)
+	branchCode := middleware.GetSelectedBranch(r)
+
+	statuses, err := database.GetCustomerStatusDefs()
+	if err != nil {
+		log.Printf("고객 상태 목록 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	advanced := parseCustomerSearch(r, statuses)
+
+	fileName := fmt.Sprintf("customers_%s.%s", time.Now().Format("20060102_150405"), format)
+
+	var count int
+	if format == "csv" {
+		count, err = exportCustomersCSV(w, branchCode, filter, searchParams, advanced, masked, fileName)
+	} else {
+		count, err = exportCustomersXLSX(w, branchCode, filter, searchParams, advanced, masked, fileName)
+	}
+
+	// 실패한 내보내기도 일부 데이터가 전송되었을 수 있으므로 함께 기록
+	database.RecordAudit(middleware.GetAuditActor(r), database.AuditCustomerExport, "branch", branchCode, nil, map[string]interface{}{
+		"format":         format,
+		"filter":         filter,
+		"search_type":    searchParams.SearchType,
+		"search_keyword": searchParams.SearchKeyword,
+		"query":          customerSearchQuery(r.URL.Query()).Encode(),
+		"masked":         masked,
+		"rows":           count,
+		"completed":      err == nil,
+	})
+
+	if err != nil {
+		log.Printf("고객 목록 내보내기 오류: %v", err)
+		if count == 0 && format == "xlsx" {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+		}
+		return
+	}
+
+	log.Printf("고객 목록 내보내기 - Branch: %d, Format: %s, Rows: %d, Masked: %t", branchCode, format, count, masked)
+}
+
+// exportCustomersCSV - CSV로 스트리밍 (한글 엑셀 호환을 위해 UTF-8 BOM 포함)
+func exportCustomersCSV(w http.ResponseWriter, branchSeq int, filter string, params utils.SearchParams, advanced database.CustomerSearchFilter, masked bool, fileName string) (int, error) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	w.Header().Set("Cache-Control", "no-store")
+
+	if _, err := w.Write([]byte("\xef\xbb\xbf")); err != nil {
+		return 0, err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeaders); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	count, err := database.StreamCustomersByBranch(branchSeq, filter, params.SearchType, params.SearchKeyword, advanced, func(c database.CustomerInfo) error {
+		record := exportRecord(c, masked)
+		for i := range record {
+			record[i] = escapeCSVFormula(record[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		// 일정 행마다 응답으로 흘려보내 대량 내보내기 시 메모리 사용 제한
+		written++
+		if written%exportFlushInterval == 0 {
+			writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	writer.Flush()
+	return count, writer.Error()
+}
+
+// exportCustomersXLSX - XLSX로 내보내기 (스트림 작성 후 한 번에 전송)
+func exportCustomersXLSX(w http.ResponseWriter, branchSeq int, filter string, params utils.SearchParams, advanced database.CustomerSearchFilter, masked bool, fileName string) (int, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := sw.SetRow("A1", toExcelRow(exportHeaders)); err != nil {
+		return 0, err
+	}
+
+	line := 1
+	count, err := database.StreamCustomersByBranch(branchSeq, filter, params.SearchType, params.SearchKeyword, advanced, func(c database.CustomerInfo) error {
+		line++
+		cell, err := excelize.CoordinatesToCellName(1, line)
+		if err != nil {
+			return err
+		}
+		return sw.SetRow(cell, toExcelRow(exportRecord(c, masked)))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := sw.Flush(); err != nil {
+		return 0, err
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := f.Write(w); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// exportRecord - 고객 한 건을 내보내기 컬럼 순서대로 변환
+func exportRecord(c database.CustomerInfo, masked bool) []string {
+	phone := c.PhoneNumber
+	if masked {
+		phone = utils.MaskPhoneNumber(phone)
+	}
+
+	duplicateOf := ""
+	if c.DuplicateOf != nil {
+		duplicateOf = strconv.Itoa(*c.DuplicateOf)
+	}
+
+	return []string{
+		strconv.Itoa(c.Seq),
+		c.Name,
+		phone,
+		c.Status,
+		strconv.Itoa(c.CallCount),
+		utils.PointerToString(c.CommercialName),
+		utils.PointerToString(c.AdSource),
+		utils.PointerToString(c.Comment),
+		c.CreatedDate,
+		utils.PointerToString(c.LastUpdateDate),
+		duplicateOf,
+	}
+}
+
+// toExcelRow - 문자열 행을 StreamWriter 입력 형식으로 변환 (모든 셀을 문자열로 기록해 전화번호 앞자리 0 유지)
+func toExcelRow(record []string) []interface{} {
+	row := make([]interface{}, len(record))
+	for i, v := range record {
+		row[i] = v
+	}
+	return row
+}
+
+// escapeCSVFormula - 엑셀에서 수식으로 실행될 수 있는 값 앞에 ' 추가 (CSV 수식 주입 방지)
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
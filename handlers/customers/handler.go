@@ -4,6 +4,7 @@ import (
 	"backoffice/database"
 	"backoffice/middleware"
 	"backoffice/utils"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
@@ -16,6 +17,7 @@ var Templates *template.Template
 func Handler(w http.ResponseWriter, r *http.Request) {
 	// 세션에서 플래시 메시지 읽기
 	successMessage := utils.GetFlashMessage(w, r, "success")
+	errorMessage := utils.GetFlashMessage(w, r, "error")
 
 	// 페이지 파라미터 가져오기
 	currentPage := utils.GetCurrentPageFromRequest(r)
@@ -27,9 +29,30 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	// 세션에서 선택된 지점 정보 가져오기
 	branchCode := middleware.GetSelectedBranch(r)
 
+	// 고객 상태 목록과 지점 통화 횟수 제한
+	statuses, err := database.GetCustomerStatusDefs()
+	if err != nil {
+		log.Printf("고객 상태 목록 조회 오류: %v", err)
+		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+	callLimit, err := database.GetBranchCallLimit(branchCode)
+	if err != nil {
+		log.Printf("통화 횟수 제한 조회 오류: %v", err)
+		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	statusColors := map[string]string{}
+	for _, s := range statuses {
+		statusColors[s.Name] = s.Color
+	}
+
+	advanced := parseCustomerSearch(r, statuses)
+
 	// 페이징을 위한 전체 고객 수 조회
 	itemsPerPage := 10
-	totalItems, err := database.GetCustomersCountByBranch(branchCode, filter, searchParams.SearchType, searchParams.SearchKeyword)
+	totalItems, err := database.GetCustomersCountByBranch(branchCode, filter, searchParams.SearchType, searchParams.SearchKeyword, advanced)
 	if err != nil {
 		log.Printf("고객 수 조회 오류: %v", err)
 		http.Error(w, "고객 수 조회 실패", http.StatusInternalServerError)
@@ -40,14 +63,27 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
 
 	// DB에서 현재 페이지의 고객 목록만 조회
-	dbCustomers, err := database.GetCustomersByBranch(branchCode, filter, searchParams.SearchType, searchParams.SearchKeyword, currentPage, itemsPerPage)
+	dbCustomers, err := database.GetCustomersByBranch(branchCode, filter, searchParams.SearchType, searchParams.SearchKeyword, advanced, currentPage, itemsPerPage)
 	if err != nil {
 		log.Printf("고객 목록 조회 오류: %v", err)
 		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
 		return
 	}
 
-	// DB 고객을 핸들러 모델로 변환
+	// 고객별 태그
+	customerSeqs := make([]int, 0, len(dbCustomers))
+	for _, dbCust := range dbCustomers {
+		customerSeqs = append(customerSeqs, dbCust.Seq)
+	}
+	customerTags, err := database.GetTagsByCustomers(customerSeqs)
+	if err != nil {
+		log.Printf("고객 태그 조회 오류: %v", err)
+		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// DB 고객을 핸들러 모델로 변환 (전화번호 전체 보기 권한이 없으면 마스킹)
+	fullPhone := canViewFullPhone(r)
 	var customers []Customer
 	for _, dbCust := range dbCustomers {
 		LastContactDate := "-"
@@ -73,17 +109,55 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		customer := Customer{
 			ID:              strconv.Itoa(dbCust.Seq),
 			Name:            dbCust.Name,
-			Phone:           dbCust.PhoneNumber,
+			Phone:           visiblePhone(dbCust.PhoneNumber, fullPhone),
 			CallCount:       dbCust.CallCount,
+			Status:          dbCust.Status,
+			StatusColor:     statusColors[dbCust.Status],
 			RegisterDate:    dbCust.CreatedDate,
 			LastContactDate: LastContactDate,
 			AdName:          adName,
 			AdSource:        adSource,
 			Comment:         comment,
+			Tags:            toTagOptions(customerTags[dbCust.Seq], nil),
+		}
+		if dbCust.DuplicateOf != nil {
+			customer.DuplicateOf = strconv.Itoa(*dbCust.DuplicateOf)
 		}
 		customers = append(customers, customer)
 	}
 
+	// 복합 검색 선택지 (광고 출처/광고명은 지점에 등록된 값)
+	adSources, commercialNames, err := database.GetCustomerFilterOptions(branchCode)
+	if err != nil {
+		log.Printf("검색 선택지 조회 오류: %v", err)
+		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 지점 태그 (상세 검색 선택지, 일괄 태그 변경)
+	tags, err := database.GetCustomerTags(branchCode)
+	if err != nil {
+		log.Printf("고객 태그 목록 조회 오류: %v", err)
+		http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+		return
+	}
+
+	// 저장된 검색 조건
+	var presets []SearchPreset
+	if user := middleware.GetCurrentUser(r); user != nil {
+		dbPresets, err := database.GetCustomerSearchPresets(user.Seq)
+		if err != nil {
+			log.Printf("검색 프리셋 조회 오류: %v", err)
+			http.Error(w, "고객 목록 조회 실패", http.StatusInternalServerError)
+			return
+		}
+		for _, p := range dbPresets {
+			presets = append(presets, SearchPreset{ID: p.Seq, Name: p.Name, Query: template.URL(p.QueryString)})
+		}
+	}
+
+	searchQuery := customerSearchQuery(r.URL.Query())
+
 	data := PageData{
 		BasePageData:   middleware.GetBasePageData(r),
 		Title:          "지원자 회신 관리",
@@ -95,6 +169,19 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		SearchType:     searchParams.SearchType,
 		SearchKeyword:  searchParams.SearchKeyword,
 		TotalCount:     totalItems,
+		CallLimit:      callLimit,
+		ErrorMessage:   errorMessage,
+
+		AdvancedActive:    isAdvancedSearchActive(advanced),
+		Advanced:          advanced,
+		StatusOptions:     toStatusOptions(statuses, advanced.Statuses),
+		AdSourceOptions:   toSearchOptions(mergeOptionValues(adSources, advanced.AdSources), advanced.AdSources),
+		CommercialOptions: toSearchOptions(mergeOptionValues(commercialNames, advanced.CommercialNames), advanced.CommercialNames),
+		CallerOptions:     toSearchOptions(callerLetters, []string{advanced.Caller}),
+		TagOptions:        toTagOptions(tags, advanced.TagSeqs),
+		SearchQuery:       searchQuery.Encode(),
+		SearchQueryParams: toSearchQueryParams(searchQuery),
+		Presets:           presets,
 	}
 
 	if err := Templates.ExecuteTemplate(w, "customers/list.html", data); err != nil {
@@ -144,17 +231,29 @@ func AddHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// DB에 저장
-		_, err = database.CreateCustomer(&branchCode, name, phoneNumber, comment, "", "")
+		result, err := database.CreateCustomer(&branchCode, name, phoneNumber, comment, "", "")
 		if err != nil {
 			log.Printf("고객 저장 오류: %v", err)
+			if dupErr, ok := err.(*database.DuplicateCustomerError); ok {
+				utils.SetFlashMessage(w, r, "error", "같은 전화번호의 고객이 이미 등록되어 있습니다.")
+				http.Redirect(w, r, fmt.Sprintf("/customers/detail?seq=%d", dupErr.ExistingSeq), http.StatusSeeOther)
+				return
+			}
 			http.Redirect(w, r, "/error", http.StatusSeeOther)
 			return
 		}
 
-		log.Printf("고객 추가 성공 - Name: %s, Phone: %s", name, phoneNumber)
+		log.Printf("고객 추가 성공 - Name: %s, Phone: %s", utils.MaskName(name), utils.MaskPhoneNumber(phoneNumber))
 
 		// 세션에 플래시 메시지 저장
-		utils.SetFlashMessage(w, r, "success", "워크인 고객이 성공적으로 추가되었습니다.")
+		switch {
+		case result.Attached:
+			utils.SetFlashMessage(w, r, "success", "같은 전화번호의 기존 고객이 있어 기존 고객에 연결되었습니다.")
+		case result.DuplicateOf > 0:
+			utils.SetFlashMessage(w, r, "success", "워크인 고객이 추가되었습니다. 같은 전화번호의 기존 고객이 있어 중복 의심으로 표시됩니다.")
+		default:
+			utils.SetFlashMessage(w, r, "success", "워크인 고객이 성공적으로 추가되었습니다.")
+		}
 
 		// 성공 시 목록 페이지로 리다이렉트
 		http.Redirect(w, r, "/customers", http.StatusSeeOther)
@@ -0,0 +1,416 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/korean"
+)
+
+const (
+	importMaxFileSize = 5 << 20 // 업로드 파일 최대 크기 (5MB)
+	importMaxRows     = 2000    // 한 번에 가져올 수 있는 최대 데이터 행 수
+)
+
+// importFieldDefs - 가져오기 대상 customers 필드와 자동 매핑용 헤더 이름
+var importFieldDefs = []struct {
+	Key      string
+	Name     string
+	Required bool
+	Aliases  []string
+}{
+	{"name", "이름", true, []string{"이름", "성함", "고객명", "성명", "name"}},
+	{"phone_number", "전화번호", true, []string{"전화번호", "연락처", "휴대폰", "휴대폰번호", "핸드폰", "phone", "phone_number", "mobile"}},
+	{"comment", "코멘트", false, []string{"코멘트", "메모", "비고", "comment", "memo"}},
+	{"commercial_name", "광고명", false, []string{"광고명", "광고", "캠페인", "commercial_name", "campaign"}},
+	{"ad_source", "광고 출처", false, []string{"광고출처", "출처", "유입경로", "유입", "ad_source", "source"}},
+}
+
+// duplicatePolicyDescriptions - 가져오기 화면에 표시할 중복 처리 방식 설명
+var duplicatePolicyDescriptions = map[string]string{
+	database.DuplicatePolicyFlag:   "중복 행도 등록되며 중복 의심으로 표시됩니다.",
+	database.DuplicatePolicyAttach: "중복 행은 새로 등록하지 않고 기존 고객에 연결됩니다.",
+	database.DuplicatePolicyReject: "중복 행은 등록하지 않고 건너뜁니다.",
+}
+
+// ImportHandler - 고객 일괄 가져오기 (CSV/XLSX)
+// GET: 파일 선택, POST action=upload: 파일 읽기 + 사전 검증, action=preview: 매핑 변경 후 다시 검증, action=commit: 가져오기
+func ImportHandler(w http.ResponseWriter, r *http.Request) {
+	data := ImportPageData{
+		BasePageData:    middleware.GetBasePageData(r),
+		Title:           "고객 일괄 가져오기",
+		ActiveMenu:      "customers",
+		Step:            "upload",
+		DuplicatePolicy: duplicatePolicyDescriptions[database.GetDuplicatePolicy()],
+		MaxRows:         importMaxRows,
+	}
+
+	if r.Method == http.MethodGet {
+		renderImport(w, r, data)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, importMaxFileSize+(1<<20))
+	// 미리보기 화면의 다시 검증/가져오기 요청은 multipart가 아님
+	if err := r.ParseMultipartForm(importMaxFileSize); err != nil && err != http.ErrNotMultipart {
+		data.ErrorMessage = "파일 크기는 5MB 이하여야 합니다."
+		renderImport(w, r, data)
+		return
+	}
+
+	branchCode := middleware.GetSelectedBranch(r)
+	if branchCode == 0 {
+		data.ErrorMessage = "가져올 지점을 먼저 선택해주세요."
+		renderImport(w, r, data)
+		return
+	}
+
+	data.DefaultAdSource = strings.TrimSpace(r.FormValue("default_ad_source"))
+	if utf8.RuneCountInString(data.DefaultAdSource) > 100 {
+		data.ErrorMessage = "기본 광고 출처는 100자 이하여야 합니다."
+		renderImport(w, r, data)
+		return
+	}
+
+	action := r.FormValue("action")
+
+	// 파일 내용 읽기 (처음 업로드 시 파일에서, 이후에는 미리보기 화면에서 재전송된 내용에서)
+	var table [][]string
+	if action == "upload" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			data.ErrorMessage = "가져올 파일을 선택해주세요."
+			renderImport(w, r, data)
+			return
+		}
+		defer file.Close()
+
+		table, err = readImportFile(file, header.Filename)
+		if err != nil {
+			log.Printf("가져오기 파일 읽기 실패: %v", err)
+			data.ErrorMessage = err.Error()
+			renderImport(w, r, data)
+			return
+		}
+		data.FileName = header.Filename
+	} else {
+		if err := json.Unmarshal([]byte(r.FormValue("table_json")), &table); err != nil || len(table) == 0 {
+			data.ErrorMessage = "파일 내용을 확인할 수 없습니다. 파일을 다시 업로드해주세요."
+			renderImport(w, r, data)
+			return
+		}
+		data.FileName = r.FormValue("file_name")
+	}
+
+	if err := ValidateImportTable(table, importMaxRows); err != nil {
+		data.ErrorMessage = err.Error()
+		renderImport(w, r, data)
+		return
+	}
+
+	tableJSON, _ := json.Marshal(table)
+	data.Step = "preview"
+	data.Headers = table[0]
+	data.TableJSON = string(tableJSON)
+
+	if action == "upload" {
+		data.Fields = guessImportFields(table[0])
+	} else {
+		data.Fields = importFieldsFromForm(r, len(table[0]))
+	}
+
+	for _, f := range data.Fields {
+		if f.Required && f.Column < 0 {
+			data.ErrorMessage = fmt.Sprintf("'%s' 컬럼을 선택해주세요.", f.Name)
+			renderImport(w, r, data)
+			return
+		}
+	}
+
+	data.Rows, data.Summary = buildImportRows(table, data.Fields, data.DefaultAdSource)
+	if err := markImportDuplicates(branchCode, data.Rows, &data.Summary); err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if action != "commit" {
+		renderImport(w, r, data)
+		return
+	}
+
+	// 오류 행을 제외하고 하나의 트랜잭션으로 가져오기
+	var rows []database.CustomerImportRow
+	for _, row := range data.Rows {
+		if len(row.Errors) > 0 {
+			continue
+		}
+		rows = append(rows, database.CustomerImportRow{
+			Line:           row.Line,
+			Name:           row.Name,
+			PhoneNumber:    row.Phone,
+			Comment:        row.Comment,
+			CommercialName: row.CommercialName,
+			AdSource:       row.AdSource,
+		})
+	}
+
+	if len(rows) == 0 {
+		data.ErrorMessage = "가져올 수 있는 행이 없습니다."
+		renderImport(w, r, data)
+		return
+	}
+
+	result, err := database.ImportCustomers(middleware.GetAuditActor(r), branchCode, data.FileName, rows)
+	if err != nil {
+		data.ErrorMessage = "가져오기 중 오류가 발생해 아무 고객도 등록되지 않았습니다."
+		renderImport(w, r, data)
+		return
+	}
+
+	log.Printf("고객 일괄 가져오기 성공 - Branch: %d, File: %s, 등록: %d", branchCode, data.FileName, result.Created)
+
+	message := fmt.Sprintf("%d명의 고객을 가져왔습니다.", result.Created)
+	if result.Flagged > 0 {
+		message += fmt.Sprintf(" 중복 의심 %d명", result.Flagged)
+	}
+	if result.Attached > 0 {
+		message += fmt.Sprintf(" 기존 고객 연결 %d건", result.Attached)
+	}
+	if result.Rejected > 0 {
+		message += fmt.Sprintf(" 중복 제외 %d건", result.Rejected)
+	}
+	if data.Summary.Errors > 0 {
+		message += fmt.Sprintf(" 오류 제외 %d건", data.Summary.Errors)
+	}
+	utils.SetFlashMessage(w, r, "success", message)
+
+	http.Redirect(w, r, "/customers", http.StatusSeeOther)
+}
+
+// renderImport - 가져오기 페이지 렌더링
+func renderImport(w http.ResponseWriter, r *http.Request, data ImportPageData) {
+	if err := Templates.ExecuteTemplate(w, "customers/import.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
+
+// readImportFile - 업로드 파일을 행 목록으로 읽기 (첫 행은 헤더)
+// CSV는 UTF-8(BOM 포함)과 EUC-KR(엑셀 기본 저장 형식)을 지원하고, XLSX는 첫 번째 시트만 읽음
+func readImportFile(file io.Reader, fileName string) ([][]string, error) {
+	content, err := io.ReadAll(io.LimitReader(file, importMaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("파일을 읽을 수 없습니다")
+	}
+	if len(content) > importMaxFileSize {
+		return nil, fmt.Errorf("파일 크기는 5MB 이하여야 합니다")
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+		if !utf8.Valid(content) {
+			if content, err = korean.EUCKR.NewDecoder().Bytes(content); err != nil {
+				return nil, fmt.Errorf("CSV 파일 인코딩을 확인할 수 없습니다 (UTF-8 또는 EUC-KR)")
+			}
+		}
+
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		table, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("CSV 파일 형식이 올바르지 않습니다: %v", err)
+		}
+		return trimImportTable(table), nil
+
+	case ".xlsx":
+		f, err := excelize.OpenReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("XLSX 파일을 열 수 없습니다")
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("XLSX 파일에 시트가 없습니다")
+		}
+		table, err := f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("XLSX 시트를 읽을 수 없습니다")
+		}
+		return trimImportTable(table), nil
+
+	default:
+		return nil, fmt.Errorf("CSV 또는 XLSX 파일만 가져올 수 있습니다")
+	}
+}
+
+// trimImportTable - 셀 앞뒤 공백 제거, 파일 끝의 빈 행 제거
+func trimImportTable(table [][]string) [][]string {
+	for _, record := range table {
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+	}
+	for len(table) > 0 && isBlankImportRecord(table[len(table)-1]) {
+		table = table[:len(table)-1]
+	}
+	return table
+}
+
+// isBlankImportRecord - 모든 셀이 비어 있는 행인지 확인
+func isBlankImportRecord(record []string) bool {
+	for _, cell := range record {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// guessImportFields - 헤더 이름으로 컬럼 매핑 추정 (공백 무시, 대소문자 무시)
+func guessImportFields(headers []string) []ImportField {
+	fields := make([]ImportField, 0, len(importFieldDefs))
+	used := map[int]bool{}
+
+	for _, def := range importFieldDefs {
+		field := ImportField{Key: def.Key, Name: def.Name, Required: def.Required, Column: -1}
+		for i, header := range headers {
+			if used[i] {
+				continue
+			}
+			normalized := strings.ToLower(strings.ReplaceAll(header, " ", ""))
+			for _, alias := range def.Aliases {
+				if normalized == alias {
+					field.Column = i
+					break
+				}
+			}
+			if field.Column >= 0 {
+				used[i] = true
+				break
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// importFieldsFromForm - 미리보기 화면에서 선택한 컬럼 매핑 읽기 (map_<필드>, 범위를 벗어나면 매핑 안 함)
+func importFieldsFromForm(r *http.Request, columnCount int) []ImportField {
+	fields := make([]ImportField, 0, len(importFieldDefs))
+	for _, def := range importFieldDefs {
+		column, err := strconv.Atoi(r.FormValue("map_" + def.Key))
+		if err != nil || column < 0 || column >= columnCount {
+			column = -1
+		}
+		fields = append(fields, ImportField{Key: def.Key, Name: def.Name, Required: def.Required, Column: column})
+	}
+	return fields
+}
+
+// buildImportRows - 컬럼 매핑에 따라 데이터 행을 고객 필드로 변환하고 검증
+func buildImportRows(table [][]string, fields []ImportField, defaultAdSource string) ([]ImportRow, ImportSummary) {
+	columns := map[string]int{}
+	for _, f := range fields {
+		columns[f.Key] = f.Column
+	}
+
+	var rows []ImportRow
+	var summary ImportSummary
+
+	for i, record := range table[1:] {
+		if isBlankImportRecord(record) {
+			continue
+		}
+
+		cell := func(key string) string {
+			column := columns[key]
+			if column < 0 || column >= len(record) {
+				return ""
+			}
+			return record[column]
+		}
+
+		row := ImportRow{
+			Line:           i + 2,
+			Name:           cell("name"),
+			Phone:          cell("phone_number"),
+			Comment:        cell("comment"),
+			CommercialName: cell("commercial_name"),
+			AdSource:       cell("ad_source"),
+		}
+		if row.AdSource == "" {
+			row.AdSource = defaultAdSource
+		}
+
+		ValidateImportRow(&row)
+
+		summary.Total++
+		if len(row.Errors) > 0 {
+			summary.Errors++
+		} else {
+			summary.Valid++
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, summary
+}
+
+// markImportDuplicates - 파일 내 중복과 지점 기존 고객 중복 표시 (오류 없는 행만)
+func markImportDuplicates(branchSeq int, rows []ImportRow, summary *ImportSummary) error {
+	firstLine := map[string]int{}
+	var phones []string
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			continue
+		}
+		if _, ok := firstLine[row.PhoneNormalized]; !ok {
+			firstLine[row.PhoneNormalized] = row.Line
+			phones = append(phones, row.PhoneNormalized)
+		}
+	}
+
+	existing, err := database.FindDuplicatePhones(branchSeq, phones)
+	if err != nil {
+		return err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if len(row.Errors) > 0 {
+			continue
+		}
+		if seq, ok := existing[row.PhoneNormalized]; ok {
+			row.DuplicateNote = fmt.Sprintf("기존 고객 #%d과 같은 전화번호", seq)
+		} else if line := firstLine[row.PhoneNormalized]; line != row.Line {
+			row.DuplicateNote = fmt.Sprintf("파일 %d행과 같은 전화번호", line)
+		}
+		if row.DuplicateNote != "" {
+			summary.Duplicates++
+		}
+	}
+
+	return nil
+}
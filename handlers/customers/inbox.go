@@ -0,0 +1,264 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// InboxHandler - 미배정 고객 배정 대기함 (상담신청 등 지점이 없는 고객 + 자동 배정 규칙)
+func InboxHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	itemsPerPage := 20
+
+	totalItems, err := database.GetUnassignedCustomersCount()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	dbCustomers, err := database.GetUnassignedCustomers(pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var customers []InboxCustomer
+	for _, c := range dbCustomers {
+		customer := InboxCustomer{
+			ID:          strconv.Itoa(c.Seq),
+			Name:        c.Name,
+			Phone:       c.PhoneNumber,
+			AdSource:    utils.PointerToString(c.AdSource),
+			Region:      utils.PointerToString(c.Region),
+			Comment:     utils.PointerToString(c.Comment),
+			Status:      c.Status,
+			CreatedDate: c.CreatedDate,
+		}
+		if c.DuplicateOf != nil {
+			customer.DuplicateOf = strconv.Itoa(*c.DuplicateOf)
+		}
+		customers = append(customers, customer)
+	}
+
+	branches, err := database.GetBranchesForSelect()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	dbRules, err := database.GetAssignmentRules()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var rules []AssignmentRuleItem
+	for _, rule := range dbRules {
+		item := AssignmentRuleItem{
+			ID:             rule.Seq,
+			TypeName:       database.AssignmentRuleTypeNames[rule.RuleType],
+			MatchValue:     rule.MatchValue,
+			BranchName:     rule.BranchName,
+			Priority:       rule.Priority,
+			IsActive:       rule.IsActive,
+			LastAssignedAt: "-",
+		}
+		if rule.LastAssignedAt.Valid {
+			item.LastAssignedAt = rule.LastAssignedAt.Time.Local().Format("2006-01-02 15:04")
+		}
+		rules = append(rules, item)
+	}
+
+	var ruleTypes []RuleTypeOption
+	for _, t := range database.AssignmentRuleTypes {
+		ruleTypes = append(ruleTypes, RuleTypeOption{Value: t, Name: database.AssignmentRuleTypeNames[t]})
+	}
+
+	data := InboxPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "미배정 고객",
+		ActiveMenu:     "customers",
+		Customers:      customers,
+		Pagination:     pagination,
+		TotalCount:     totalItems,
+		Branches:       branches,
+		Rules:          rules,
+		RuleTypes:      ruleTypes,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	if err := Templates.ExecuteTemplate(w, "customers/inbox.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Println("Template error:", err)
+	}
+}
+
+// AssignHandler - 선택한 미배정 고객을 지점에 일괄 배정 (POST customer_seq 여러 개, branch_seq)
+func AssignHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "잘못된 요청입니다", http.StatusBadRequest)
+		return
+	}
+
+	var customerSeqs []int
+	for _, v := range r.Form["customer_seq"] {
+		if seq, err := ValidateCustomerSeq(v); err == nil {
+			customerSeqs = append(customerSeqs, seq)
+		}
+	}
+	if len(customerSeqs) == 0 {
+		utils.SetFlashMessage(w, r, "error", "배정할 고객을 선택해주세요.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	branchSeq, err := strconv.Atoi(r.FormValue("branch_seq"))
+	if err != nil || branchSeq <= 0 {
+		utils.SetFlashMessage(w, r, "error", "배정할 지점을 선택해주세요.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	assigned, err := database.AssignCustomers(middleware.GetAuditActor(r), customerSeqs, branchSeq)
+	if err == sql.ErrNoRows {
+		utils.SetFlashMessage(w, r, "error", "지점을 찾을 수 없습니다.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		log.Printf("고객 지점 배정 오류: %v", err)
+		utils.SetFlashMessage(w, r, "error", "고객 배정 중 오류가 발생했습니다.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	message := fmt.Sprintf("%d명의 고객을 배정했습니다.", assigned)
+	if skipped := len(customerSeqs) - assigned; skipped > 0 {
+		message += fmt.Sprintf(" (이미 배정된 고객 %d명 제외)", skipped)
+	}
+	utils.SetFlashMessage(w, r, "success", message)
+	http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+}
+
+// ApplyRulesHandler - 현재 미배정 고객 전체에 자동 배정 규칙 적용 (POST)
+func ApplyRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	assigned, err := database.ApplyAssignmentRules(middleware.GetAuditActor(r))
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "자동 배정 중 오류가 발생했습니다.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	if assigned == 0 {
+		utils.SetFlashMessage(w, r, "error", "규칙에 맞는 미배정 고객이 없습니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "success", fmt.Sprintf("자동 배정 규칙으로 %d명의 고객을 배정했습니다.", assigned))
+	}
+	http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+}
+
+// RuleSaveHandler - 자동 배정 규칙 추가 (POST rule_type, match_value, branch_seq, priority)
+func RuleSaveHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rule := database.AssignmentRule{
+		RuleType:   r.FormValue("rule_type"),
+		MatchValue: strings.TrimSpace(r.FormValue("match_value")),
+	}
+	rule.BranchSeq, _ = strconv.Atoi(r.FormValue("branch_seq"))
+	rule.Priority, _ = strconv.Atoi(r.FormValue("priority"))
+
+	if err := ValidateAssignmentRule(&rule); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := database.CreateAssignmentRule(middleware.GetAuditActor(r), rule); err != nil {
+		utils.SetFlashMessage(w, r, "error", "규칙을 추가하지 못했습니다. 지점이 존재하는지 확인해주세요.")
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", "자동 배정 규칙이 추가되었습니다.")
+	http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+}
+
+// RuleToggleHandler - 자동 배정 규칙 사용/중지 (POST seq, active)
+func RuleToggleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ruleSeq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+	active := r.FormValue("active") == "1"
+
+	if _, err := database.SetAssignmentRuleActive(middleware.GetAuditActor(r), ruleSeq, active); err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if active {
+		utils.SetFlashMessage(w, r, "success", "규칙을 다시 사용합니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "success", "규칙 사용을 중지했습니다.")
+	}
+	http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+}
+
+// RuleDeleteHandler - 자동 배정 규칙 삭제 (POST seq)
+func RuleDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ruleSeq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.DeleteAssignmentRule(middleware.GetAuditActor(r), ruleSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "자동 배정 규칙이 삭제되었습니다.")
+	}
+	http.Redirect(w, r, "/customers/inbox", http.StatusSeeOther)
+}
@@ -0,0 +1,66 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// MergeHandler - 중복 고객 병합 (POST)
+// 폼 파라미터: survivor_seq (남길 고객), merged_seq (병합 후 휴지통으로 이동할 고객)
+func MergeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "잘못된 요청입니다", http.StatusBadRequest)
+		return
+	}
+
+	survivorSeq, err := ValidateCustomerSeq(r.FormValue("survivor_seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+	detailURL := fmt.Sprintf("/customers/detail?seq=%d", survivorSeq)
+
+	mergedSeq, err := ValidateCustomerSeq(r.FormValue("merged_seq"))
+	if err != nil || mergedSeq == survivorSeq {
+		utils.SetFlashMessage(w, r, "error", "병합할 고객이 올바르지 않습니다.")
+		http.Redirect(w, r, detailURL, http.StatusSeeOther)
+		return
+	}
+
+	// 두 고객 모두 접근 가능한 지점의 고객이어야 병합 가능
+	for _, seq := range []int{survivorSeq, mergedSeq} {
+		if err := ValidateCustomerAccess(r, seq); err != nil {
+			log.Printf("고객 병합 접근 거부: %v", err)
+			utils.SetFlashMessage(w, r, "error", "병합 권한이 없는 고객입니다.")
+			http.Redirect(w, r, detailURL, http.StatusSeeOther)
+			return
+		}
+	}
+
+	err = database.MergeCustomers(middleware.GetAuditActor(r), survivorSeq, mergedSeq)
+	if err == sql.ErrNoRows {
+		utils.SetFlashMessage(w, r, "error", "병합할 고객을 찾을 수 없습니다.")
+		http.Redirect(w, r, detailURL, http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		log.Printf("고객 병합 오류: %v", err)
+		utils.SetFlashMessage(w, r, "error", "고객 병합 중 오류가 발생했습니다.")
+		http.Redirect(w, r, detailURL, http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("고객 병합 성공 - 남은 고객: %d, 병합된 고객: %d", survivorSeq, mergedSeq)
+	utils.SetFlashMessage(w, r, "success", "고객이 병합되었습니다.")
+	http.Redirect(w, r, detailURL, http.StatusSeeOther)
+}
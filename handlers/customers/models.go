@@ -1,8 +1,10 @@
 package customers
 
 import (
+	"backoffice/database"
 	"backoffice/middleware"
 	"backoffice/utils"
+	"html/template"
 )
 
 // Customer - 고객 데이터 구조체
@@ -18,6 +20,9 @@ type Customer struct {
 	CallCount       int
 	Branch          string
 	Comment         string
+	DuplicateOf     string // 중복 의심 기존 고객 ID (없으면 빈 문자열)
+	StatusColor     string // 상태 표시 색상 (customer_statuses.color)
+	Tags            []TagOption
 }
 
 // PageData - 고객 관리 페이지 데이터 구조체
@@ -32,4 +37,286 @@ type PageData struct {
 	SearchType     string // 검색 타입 (name/phone)
 	SearchKeyword  string // 검색어
 	TotalCount     int    // 조건에 맞는 전체 고객 수
+	CallLimit      int    // 지점 통화 횟수 제한 (0이면 제한 없음)
+	ErrorMessage   string // 플래시 메시지
+
+	// 복합 검색
+	AdvancedActive    bool                          // 복합 검색 조건 적용 중 (상세 검색 영역 펼침)
+	Advanced          database.CustomerSearchFilter // 현재 복합 검색 조건
+	StatusOptions     []SearchOption
+	AdSourceOptions   []SearchOption
+	CommercialOptions []SearchOption
+	CallerOptions     []SearchOption
+	TagOptions        []TagOption    // 지점 태그 (상세 검색 선택지, 일괄 태그 변경)
+	SearchQuery       string         // 현재 검색 조건 쿼리 문자열 (프리셋 저장용)
+	SearchQueryParams []QueryParam   // 현재 검색 조건 (내보내기 폼 hidden input)
+	Presets           []SearchPreset // 저장된 검색 조건
+}
+
+// SearchOption - 복합 검색 선택지
+type SearchOption struct {
+	Value    string
+	Selected bool
+	Color    string // 상태 선택지 표시 색상 (상태 외에는 빈 문자열)
+}
+
+// TagOption - 고객 태그 (목록 표시, 검색 선택지)
+type TagOption struct {
+	ID       int
+	Name     string
+	Color    string
+	Selected bool // 상세 검색에서 선택된 태그
+}
+
+// QueryParam - 쿼리 파라미터 한 개 (hidden input 생성용)
+type QueryParam struct {
+	Key   string
+	Value string
+}
+
+// SearchPreset - 저장된 검색 조건 (고객 목록 화면)
+type SearchPreset struct {
+	ID    int
+	Name  string
+	Query template.URL // 고객 목록 쿼리 문자열 (저장 시 검색 파라미터만 남겨 인코딩한 값)
+}
+
+// TimelineItem - 고객 활동 타임라인 항목
+type TimelineItem struct {
+	TypeClass   string // history-type 색상 클래스 (type-call 등)
+	TypeName    string // 이벤트 종류 표시 이름
+	Title       string
+	Description string
+	Actor       string // 작업자 아이디 (없으면 빈 문자열)
+	OccurredAt  string
+}
+
+// DetailPageData - 고객 상세 페이지 데이터 구조체
+type DetailPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Customer       Customer
+	Timeline       []TimelineItem
+	Duplicates     []DuplicateItem // 같은 전화번호의 다른 고객 (병합 후보)
+	StatusHistory  []StatusHistoryItem
+	Notes          []NoteItem            // 메모 (고정 → 최근 작성순)
+	Callbacks      []CallbackItem        // 콜백 (예정 → 완료 순)
+	CallbackOwners []CallbackOwnerOption // 콜백 담당자 선택지
+	CallerLetters  []string              // 콜백 완료 시 CALLER 선택지
+	SMSOptedOut    bool                  // 고객 지점의 문자 수신거부 번호
+	SuccessMessage string                // 플래시 메시지
+	ErrorMessage   string                // 플래시 메시지
+}
+
+// NoteItem - 고객 메모 (고객 상세 페이지, 메모 API 응답)
+type NoteItem struct {
+	ID          int                `json:"seq"`
+	Content     string             `json:"content"`
+	Pinned      bool               `json:"pinned"`
+	Author      string             `json:"author"` // 작성자 아이디 (이관/외부 유입 메모는 빈 문자열)
+	CreatedDate string             `json:"created_date"`
+	UpdatedDate string             `json:"updated_date"` // 수정하지 않았으면 빈 문자열
+	Revisions   []NoteRevisionItem `json:"revisions"`    // 수정 이력 (최근 수정순)
+}
+
+// NoteRevisionItem - 고객 메모 수정 이력 (수정 전 내용)
+type NoteRevisionItem struct {
+	Content    string `json:"content"`
+	EditedBy   string `json:"edited_by"`
+	EditedDate string `json:"edited_date"`
+}
+
+// CallbackItem - 고객 콜백 (고객 상세 페이지)
+type CallbackItem struct {
+	ID              int
+	DueAt           string
+	Owner           string // 담당자 아이디 (없으면 빈 문자열)
+	Note            string
+	CreatedBy       string
+	CreatedDate     string
+	Completed       bool
+	CompletedAt     string
+	CompletedBy     string
+	CompletedCaller string
+	Overdue         bool // 완료되지 않았고 예정 일시가 지남
+}
+
+// CallbackOwnerOption - 콜백 담당자 선택지
+type CallbackOwnerOption struct {
+	ID       int
+	UserID   string
+	Selected bool // 기본 선택 (현재 사용자)
+}
+
+// StatusHistoryItem - 고객 상태 변경 이력 (고객 상세 페이지)
+type StatusHistoryItem struct {
+	ID         int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	Actor      string
+	ChangedAt  string
+	Reverted   bool // 되돌리기로 생긴 이력
+	CanRevert  bool // 가장 최근 변경이며 되돌리기 이력이 아님
+}
+
+// DuplicateItem - 병합 후보 고객 (고객 상세 페이지)
+type DuplicateItem struct {
+	ID           string
+	Name         string
+	Phone        string
+	Branch       string
+	Status       string
+	CallCount    int
+	RegisterDate string
+	Accessible   bool // 현재 사용자가 접근 가능한 지점의 고객 (병합 가능)
+}
+
+// ImportField - 가져오기 컬럼 매핑 항목 (customers 필드 ↔ 파일 컬럼)
+type ImportField struct {
+	Key      string // 폼 필드 이름 (map_<Key>)
+	Name     string // 화면 표시 이름
+	Required bool
+	Column   int // 매핑된 파일 컬럼 인덱스 (-1이면 매핑 안 함)
+}
+
+// ImportRow - 가져오기 사전 검증 결과 한 행
+type ImportRow struct {
+	Line            int // 파일의 행 번호 (헤더 = 1행)
+	Name            string
+	Phone           string // 파일에 입력된 형식 그대로 저장
+	PhoneNormalized string // 숫자만 남긴 번호 (검증, 중복 확인용)
+	Comment         string
+	CommercialName  string
+	AdSource        string
+	Errors          []string // 검증 오류 (있으면 가져오기에서 제외)
+	DuplicateNote   string   // 중복 안내 (기존 고객 또는 파일 내 중복)
+}
+
+// ImportSummary - 가져오기 사전 검증 요약
+type ImportSummary struct {
+	Total      int // 데이터 행 수
+	Valid      int // 가져올 수 있는 행 수 (중복 포함)
+	Errors     int // 오류 행 수
+	Duplicates int // 중복 행 수 (기존 고객 + 파일 내 중복)
+}
+
+// ImportPageData - 고객 일괄 가져오기 페이지 데이터 구조체
+type ImportPageData struct {
+	middleware.BasePageData
+	Title           string
+	ActiveMenu      string
+	Step            string // upload: 파일 선택, preview: 사전 검증 결과
+	FileName        string
+	DefaultAdSource string // 광고 출처 컬럼이 없거나 비어 있을 때 사용할 값
+	Headers         []string
+	Fields          []ImportField
+	TableJSON       string // 업로드 파일 내용 (헤더 + 데이터 행, 다시 검증/가져오기 시 재전송)
+	Rows            []ImportRow
+	Summary         ImportSummary
+	DuplicatePolicy string // 중복 처리 방식 설명
+	MaxRows         int
+	ErrorMessage    string
+}
+
+// InboxPageData - 미배정 고객 배정 대기함 페이지 데이터
+type InboxPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Customers      []InboxCustomer
+	Pagination     utils.Pagination
+	TotalCount     int                 // 미배정 고객 수
+	Branches       []map[string]string // 배정할 지점 선택지
+	Rules          []AssignmentRuleItem
+	RuleTypes      []RuleTypeOption
+	SuccessMessage string // 플래시 메시지
+	ErrorMessage   string // 플래시 메시지
+}
+
+// InboxCustomer - 배정 대기 고객
+type InboxCustomer struct {
+	ID          string
+	Name        string
+	Phone       string
+	AdSource    string
+	Region      string
+	Comment     string
+	Status      string
+	CreatedDate string
+	DuplicateOf string // 중복 의심 기존 고객 ID (없으면 빈 문자열)
+}
+
+// AssignmentRuleItem - 자동 배정 규칙 표시 항목
+type AssignmentRuleItem struct {
+	ID             int
+	TypeName       string
+	MatchValue     string
+	BranchName     string
+	Priority       int
+	IsActive       bool
+	LastAssignedAt string // 마지막 배정 일시 (없으면 "-")
+}
+
+// RuleTypeOption - 자동 배정 규칙 종류 선택지
+type RuleTypeOption struct {
+	Value string
+	Name  string
+}
+
+// TrashPageData - 고객 휴지통 페이지 데이터
+type TrashPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Customers      []TrashCustomer
+	Pagination     utils.Pagination
+	TotalCount     int    // 휴지통 고객 수
+	Unassigned     bool   // 미배정 고객 휴지통 표시 중
+	CanUnassigned  bool   // 미배정 고객 휴지통 조회 가능 (전체 지점 계정)
+	RetentionDays  int    // 보관 기간 (0이면 자동 영구 삭제 안 함)
+	SuccessMessage string // 플래시 메시지
+	ErrorMessage   string // 플래시 메시지
+}
+
+// TrashCustomer - 휴지통 고객
+type TrashCustomer struct {
+	ID          string
+	Name        string
+	Phone       string
+	AdSource    string
+	Status      string
+	CallCount   int
+	CreatedDate string
+	DeletedAt   string
+	DeletedBy   string // 삭제한 사용자 아이디 (없으면 "-")
+	PurgeDate   string // 영구 삭제 예정일 (자동 영구 삭제를 사용하지 않으면 "-")
+	MergedInto  string // 병합되어 휴지통으로 이동한 경우 남긴 고객 ID (없으면 빈 문자열)
+}
+
+// SMSOptOutPageData - 문자 수신거부 번호 관리 페이지 데이터
+type SMSOptOutPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	OptOuts        []SMSOptOutItem
+	Pagination     utils.Pagination
+	TotalCount     int    // 조건에 맞는 수신거부 번호 수
+	SearchPhone    string // 번호 검색어
+	Today          string // 수신거부 일자 입력 최대값 (YYYY-MM-DD)
+	ImportMaxRows  int    // 080 수신거부 목록 한 번에 가져올 수 있는 최대 행 수
+	SuccessMessage string // 플래시 메시지
+	ErrorMessage   string // 플래시 메시지
+}
+
+// SMSOptOutItem - 문자 수신거부 번호
+type SMSOptOutItem struct {
+	ID           int
+	Phone        string
+	Reason       string
+	SourceName   string // 등록 경로 표시 이름
+	OptedOutDate string
+	CreatedBy    string // 등록한 사용자 아이디 (없으면 "-")
+	CreatedDate  string
 }
@@ -0,0 +1,242 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// toNoteItems - DB 메모 목록을 화면/응답용 목록으로 변환
+func toNoteItems(notes []database.CustomerNote) []NoteItem {
+	items := []NoteItem{}
+	for _, n := range notes {
+		revisions := []NoteRevisionItem{}
+		for _, rev := range n.Revisions {
+			revisions = append(revisions, NoteRevisionItem{
+				Content:    rev.Content,
+				EditedBy:   rev.UserID,
+				EditedDate: rev.CreatedDate,
+			})
+		}
+		items = append(items, NoteItem{
+			ID:          n.Seq,
+			Content:     n.Content,
+			Pinned:      n.Pinned,
+			Author:      n.UserID,
+			CreatedDate: n.CreatedDate,
+			UpdatedDate: n.UpdatedDate,
+			Revisions:   revisions,
+		})
+	}
+	return items
+}
+
+// noteCustomer - 메모 API 공통 고객 검증 (customer_seq, 고객 지점 접근 권한)
+// 검증에 실패하면 JSON 에러 응답을 쓰고 ok=false 반환
+func noteCustomer(w http.ResponseWriter, r *http.Request) (customerSeq int, ok bool) {
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "Invalid customer ID")
+		return 0, false
+	}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("메모 접근 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return 0, false
+	}
+	return customerSeq, true
+}
+
+// NotesHandler godoc
+// @Summary      고객 메모 목록
+// @Description  고객 메모를 고정 메모 → 최근 작성순으로 반환합니다 (작성자, 작성 일시, 수정 이력 포함)
+// @Tags         customers
+// @Produce      json
+// @Param        customer_seq  query  int  true  "고객 시퀀스"
+// @Success      200  {object}  map[string]interface{}  "메모 목록"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/notes [get]
+func NotesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, ok := noteCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	notes, err := database.GetCustomerNotes(customerSeq)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "메모 조회에 실패했습니다")
+		return
+	}
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"notes": toNoteItems(notes),
+	})
+}
+
+// AddNoteHandler godoc
+// @Summary      고객 메모 추가
+// @Description  고객 메모를 추가합니다 (고정 메모 중 가장 최근 메모가 고객 목록의 코멘트로 표시됩니다)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  int     true   "고객 시퀀스"
+// @Param        content       formData  string  true   "메모 내용 (1000자 이하)"
+// @Param        pinned        formData  bool    false  "고정 여부"
+// @Success      200  {object}  map[string]interface{}  "추가된 메모 시퀀스"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      404  {string}  string  "고객 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/notes/add [post]
+func AddNoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, ok := noteCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	content := strings.TrimSpace(r.FormValue("content"))
+	if err := ValidateCustomerNote(content); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pinned := r.FormValue("pinned") == "true"
+
+	noteSeq, err := database.AddCustomerNote(middleware.GetAuditActor(r), customerSeq, content, pinned)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusNotFound, "고객을 찾을 수 없습니다")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "메모 추가에 실패했습니다")
+		return
+	}
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"note_seq": noteSeq,
+	})
+}
+
+// UpdateNoteHandler godoc
+// @Summary      고객 메모 수정
+// @Description  고객 메모 내용을 수정합니다 (수정 전 내용은 수정 이력에 보관됩니다)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  int     true  "고객 시퀀스"
+// @Param        note_seq      formData  int     true  "메모 시퀀스"
+// @Param        content       formData  string  true  "메모 내용 (1000자 이하)"
+// @Success      200  {object}  map[string]interface{}  "수정 여부"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      404  {string}  string  "메모 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/notes/update [post]
+func UpdateNoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, ok := noteCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	noteSeq, err := ValidateCustomerSeq(r.FormValue("note_seq"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	content := strings.TrimSpace(r.FormValue("content"))
+	if err := ValidateCustomerNote(content); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rowsAffected, err := database.UpdateCustomerNote(middleware.GetAuditActor(r), customerSeq, noteSeq, content)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusNotFound, "메모를 찾을 수 없습니다")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "메모 수정에 실패했습니다")
+		return
+	}
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"updated": rowsAffected > 0,
+	})
+}
+
+// PinNoteHandler godoc
+// @Summary      고객 메모 고정/고정 해제
+// @Description  고객 메모 고정 여부를 변경합니다 (고객 목록의 코멘트도 가장 최근 고정 메모로 갱신됩니다)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  int   true  "고객 시퀀스"
+// @Param        note_seq      formData  int   true  "메모 시퀀스"
+// @Param        pinned        formData  bool  true  "고정 여부"
+// @Success      200  {object}  map[string]interface{}  "변경 여부"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      404  {string}  string  "메모 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/notes/pin [post]
+func PinNoteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, ok := noteCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	noteSeq, err := ValidateCustomerSeq(r.FormValue("note_seq"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+	pinned := r.FormValue("pinned") == "true"
+
+	rowsAffected, err := database.SetCustomerNotePinned(middleware.GetAuditActor(r), customerSeq, noteSeq, pinned)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusNotFound, "메모를 찾을 수 없습니다")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "메모 고정 변경에 실패했습니다")
+		return
+	}
+
+	utils.JSONSuccess(w, map[string]interface{}{
+		"updated": rowsAffected > 0,
+	})
+}
@@ -0,0 +1,82 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"log"
+	"net/http"
+)
+
+// canViewFullPhone - 현재 사용자가 고객 전화번호 전체를 볼 수 있는지 (PermCustomerPhoneView)
+// 요청마다 사용자 조회가 일어날 수 있으므로 목록 변환 전에 한 번만 호출
+func canViewFullPhone(r *http.Request) bool {
+	user := middleware.GetCurrentUser(r)
+	return user != nil && middleware.HasPermission(user.Role, middleware.PermCustomerPhoneView)
+}
+
+// visiblePhone - 화면/내보내기에 표시할 전화번호 (전체 보기 권한이 없으면 010-****-5678 형식으로 마스킹)
+func visiblePhone(phone string, fullPhone bool) string {
+	if fullPhone {
+		return phone
+	}
+	return utils.MaskPhoneNumber(phone)
+}
+
+// RevealPhoneHandler godoc
+// @Summary      고객 전화번호 열람
+// @Description  마스킹된 고객 전화번호 전체를 반환합니다 (열람할 때마다 감사 로그에 기록됩니다)
+// @Tags         customers
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        customer_seq  formData  int  true  "고객 시퀀스"
+// @Success      200  {object}  map[string]interface{}  "전화번호"
+// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      403  {string}  string  "접근 권한 없음"
+// @Failure      404  {string}  string  "고객 없음"
+// @Failure      500  {string}  string  "서버 오류"
+// @Security     SessionAuth
+// @Security     BearerAuth
+// @Router       /customers/reveal-phone [post]
+func RevealPhoneHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("customer_seq"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+	if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+		log.Printf("전화번호 열람 거부: %v", err)
+		utils.JSONError(w, http.StatusForbidden, "해당 고객에 접근할 수 없습니다")
+		return
+	}
+
+	detail, err := database.GetCustomerDetail(customerSeq)
+	if err == sql.ErrNoRows {
+		utils.JSONError(w, http.StatusNotFound, "고객을 찾을 수 없습니다")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "전화번호 조회에 실패했습니다")
+		return
+	}
+
+	// 열람 기록이 남지 않으면 번호를 내주지 않음 (감사 로그에는 마스킹된 번호만 저장)
+	if err := database.RecordAudit(middleware.GetAuditActor(r), database.AuditCustomerPhoneReveal, "customer", customerSeq, nil, map[string]interface{}{
+		"phone_number": utils.MaskPhoneNumber(detail.PhoneNumber),
+		"branch_seq":   middleware.GetSelectedBranch(r),
+	}); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "전화번호 열람 기록에 실패했습니다")
+		return
+	}
+
+	log.Printf("고객 전화번호 열람 - CustomerSeq: %d", customerSeq)
+	utils.JSONSuccess(w, map[string]interface{}{
+		"phone": detail.PhoneNumber,
+	})
+}
@@ -0,0 +1,300 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// customerSearchKeys - 고객 목록 검색 파라미터 (프리셋 저장/내보내기/페이지 이동 시 유지)
+var customerSearchKeys = []string{
+	"filter", "searchType", "searchKeyword",
+	"status", "ad_source", "commercial_name",
+	"created_from", "created_to", "contact_from", "contact_to", "reservation_from", "reservation_to",
+	"call_min", "call_max", "caller", "tag",
+}
+
+// callerLetters - CALLER 선택지 (고객 목록 CALLER 버튼과 동일)
+var callerLetters = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P"}
+
+// parseCustomerSearch - 요청 쿼리에서 복합 검색 조건 읽기 (형식이 잘못된 값은 무시)
+// 파라미터: statuses (고객 상태 목록, 목록에 없는 상태 값은 무시)
+func parseCustomerSearch(r *http.Request, statuses []database.CustomerStatusDef) database.CustomerSearchFilter {
+	query := r.URL.Query()
+
+	f := database.CustomerSearchFilter{
+		Statuses:        filterAllowed(query["status"], customerStatusNames(statuses)),
+		AdSources:       nonEmptyValues(query["ad_source"]),
+		CommercialNames: nonEmptyValues(query["commercial_name"]),
+		CreatedFrom:     validDateOrEmpty(query.Get("created_from")),
+		CreatedTo:       validDateOrEmpty(query.Get("created_to")),
+		ContactFrom:     validDateOrEmpty(query.Get("contact_from")),
+		ContactTo:       validDateOrEmpty(query.Get("contact_to")),
+		ReservationFrom: validDateOrEmpty(query.Get("reservation_from")),
+		ReservationTo:   validDateOrEmpty(query.Get("reservation_to")),
+		CallCountMin:    nonNegativeIntOrNil(query.Get("call_min")),
+		CallCountMax:    nonNegativeIntOrNil(query.Get("call_max")),
+		TagSeqs:         positiveIntValues(query["tag"]),
+	}
+
+	if caller := query.Get("caller"); len(filterAllowed([]string{caller}, callerLetters)) == 1 {
+		f.Caller = caller
+	}
+
+	return f
+}
+
+// customerStatusNames - 상태 정의 목록에서 이름만 추출
+func customerStatusNames(statuses []database.CustomerStatusDef) []string {
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// toStatusOptions - 상태 선택지 (상태 색상 포함)
+func toStatusOptions(statuses []database.CustomerStatusDef, selected []string) []SearchOption {
+	options := toSearchOptions(customerStatusNames(statuses), selected)
+	for i := range options {
+		options[i].Color = statuses[i].Color
+	}
+	return options
+}
+
+// isAdvancedSearchActive - 복합 검색 조건이 하나라도 있는지 확인 (상세 검색 영역 펼침 여부)
+func isAdvancedSearchActive(f database.CustomerSearchFilter) bool {
+	return len(f.Statuses) > 0 || len(f.AdSources) > 0 || len(f.CommercialNames) > 0 ||
+		f.CreatedFrom != "" || f.CreatedTo != "" || f.ContactFrom != "" || f.ContactTo != "" ||
+		f.ReservationFrom != "" || f.ReservationTo != "" ||
+		f.CallCountMin != nil || f.CallCountMax != nil || f.Caller != "" || len(f.TagSeqs) > 0
+}
+
+// customerSearchQuery - 검색 파라미터만 남긴 쿼리 (빈 값 제외)
+func customerSearchQuery(values url.Values) url.Values {
+	query := url.Values{}
+	for _, key := range customerSearchKeys {
+		for _, v := range values[key] {
+			if v = strings.TrimSpace(v); v != "" {
+				query.Add(key, v)
+			}
+		}
+	}
+	return query
+}
+
+// toSearchQueryParams - 쿼리를 hidden input용 목록으로 변환 (검색 파라미터 순서 유지)
+func toSearchQueryParams(query url.Values) []QueryParam {
+	var params []QueryParam
+	for _, key := range customerSearchKeys {
+		for _, v := range query[key] {
+			params = append(params, QueryParam{Key: key, Value: v})
+		}
+	}
+	return params
+}
+
+// toSearchOptions - 선택지 목록을 선택 여부와 함께 변환
+func toSearchOptions(values, selected []string) []SearchOption {
+	selectedSet := map[string]bool{}
+	for _, v := range selected {
+		selectedSet[v] = true
+	}
+
+	options := make([]SearchOption, 0, len(values))
+	for _, v := range values {
+		options = append(options, SearchOption{Value: v, Selected: selectedSet[v]})
+	}
+	return options
+}
+
+// mergeOptionValues - DB 선택지에 현재 선택된 값을 추가 (목록 제한으로 빠진 값도 선택 상태 유지)
+func mergeOptionValues(values, selected []string) []string {
+	exists := map[string]bool{}
+	for _, v := range values {
+		exists[v] = true
+	}
+	for _, v := range selected {
+		if !exists[v] {
+			values = append(values, v)
+			exists[v] = true
+		}
+	}
+	return values
+}
+
+// filterAllowed - 허용된 값만 남김
+func filterAllowed(values, allowed []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, a := range allowed {
+			if v == a {
+				result = append(result, v)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// nonEmptyValues - 공백 제거 후 빈 값 제외 (최대 100자)
+func nonEmptyValues(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && utf8.RuneCountInString(v) <= 100 {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// validDateOrEmpty - YYYY-MM-DD 형식이면 그대로, 아니면 빈 문자열
+func validDateOrEmpty(value string) string {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// nonNegativeIntOrNil - 0 이상의 정수면 포인터, 아니면 nil
+func nonNegativeIntOrNil(value string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
+
+// positiveIntValues - 1 이상의 정수 값만 남김 (중복 제외)
+func positiveIntValues(values []string) []int {
+	var result []int
+	seen := map[int]bool{}
+	for _, v := range values {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || n <= 0 || seen[n] {
+			continue
+		}
+		seen[n] = true
+		result = append(result, n)
+	}
+	return result
+}
+
+// toTagOptions - 태그 선택지 (선택 여부 포함)
+func toTagOptions(tags []database.CustomerTag, selected []int) []TagOption {
+	selectedSet := map[int]bool{}
+	for _, seq := range selected {
+		selectedSet[seq] = true
+	}
+
+	options := make([]TagOption, 0, len(tags))
+	for _, t := range tags {
+		options = append(options, TagOption{ID: t.Seq, Name: t.Name, Color: t.Color, Selected: selectedSet[t.Seq]})
+	}
+	return options
+}
+
+// SavePresetHandler - 현재 검색 조건을 프리셋으로 저장 (POST name, query)
+func SavePresetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+		return
+	}
+
+	values, _ := url.ParseQuery(r.FormValue("query"))
+	queryString := customerSearchQuery(values).Encode()
+	redirectURL := "/customers?" + queryString
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if err := ValidatePresetName(name); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	count, err := database.CountCustomerSearchPresets(user.Seq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	// 개수 제한 확인 (같은 이름 덮어쓰기는 허용)
+	if count >= database.MaxCustomerSearchPresets {
+		presets, err := database.GetCustomerSearchPresets(user.Seq)
+		if err != nil {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+		exists := false
+		for _, p := range presets {
+			if p.Name == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			utils.SetFlashMessage(w, r, "error", fmt.Sprintf("검색 조건은 최대 %d개까지 저장할 수 있습니다.", database.MaxCustomerSearchPresets))
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+	}
+
+	created, err := database.SaveCustomerSearchPreset(user.Seq, name, queryString)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("검색 프리셋 저장 - User: %s, Name: %s, Query: %s", user.UserID, name, queryString)
+	if created {
+		utils.SetFlashMessage(w, r, "success", "검색 조건이 저장되었습니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "success", "같은 이름의 검색 조건을 현재 조건으로 변경했습니다.")
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// DeletePresetHandler - 검색 프리셋 삭제 (POST seq, 본인 프리셋만)
+func DeletePresetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	user := middleware.GetCurrentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+		return
+	}
+
+	presetSeq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/customers", http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.DeleteCustomerSearchPreset(user.Seq, presetSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "검색 조건이 삭제되었습니다.")
+	}
+	http.Redirect(w, r, "/customers", http.StatusSeeOther)
+}
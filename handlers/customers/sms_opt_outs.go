@@ -0,0 +1,344 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+const (
+	smsOptOutsURL          = "/customers/sms-opt-outs"
+	smsOptOutImportMaxRows = 10000      // 080 수신거부 목록 한 번에 가져올 수 있는 최대 행 수
+	smsOptOutImportReason  = "080 수신거부" // 080 수신거부 목록으로 등록한 번호의 사유
+)
+
+// smsOptOutDateLayouts - 080 수신거부 목록의 수신거부 일자 형식 (업체마다 다름)
+var smsOptOutDateLayouts = []string{"2006-01-02", "2006.01.02", "2006/01/02", "20060102"}
+
+// SMSOptOutsHandler - 문자 수신거부 번호 관리 (선택된 지점, 번호 검색)
+func SMSOptOutsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	branchSeq := middleware.GetSelectedBranch(r)
+	searchPhone := strings.TrimSpace(r.URL.Query().Get("phone"))
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	itemsPerPage := 20
+
+	totalItems, err := database.GetSMSOptOutsCount(branchSeq, searchPhone)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	optOuts, err := database.GetSMSOptOuts(branchSeq, searchPhone, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	// 전화번호 전체 보기 권한이 없으면 010-****-5678 형식으로 마스킹
+	fullPhone := canViewFullPhone(r)
+
+	var items []SMSOptOutItem
+	for _, o := range optOuts {
+		item := SMSOptOutItem{
+			ID:           o.Seq,
+			Phone:        visiblePhone(o.PhoneNumber, fullPhone),
+			Reason:       o.Reason,
+			SourceName:   database.SMSOptOutSourceNames[o.Source],
+			OptedOutDate: o.OptedOutDate,
+			CreatedBy:    o.UserID,
+			CreatedDate:  o.CreatedDate,
+		}
+		if item.CreatedBy == "" {
+			item.CreatedBy = "-"
+		}
+		items = append(items, item)
+	}
+
+	data := SMSOptOutPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "문자 수신거부",
+		ActiveMenu:     "customers",
+		OptOuts:        items,
+		Pagination:     pagination,
+		TotalCount:     totalItems,
+		SearchPhone:    searchPhone,
+		Today:          time.Now().Format("2006-01-02"),
+		ImportMaxRows:  smsOptOutImportMaxRows,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	if err := Templates.ExecuteTemplate(w, "customers/sms-opt-outs.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
+
+// SMSOptOutAddHandler - 수신거부 번호 등록 (POST phone, reason, opted_out_date)
+// customer_seq가 있으면 고객 전화번호를 고객 지점에 등록하고 고객 상세로 이동
+func SMSOptOutAddHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	redirectURL := smsOptOutsURL
+	branchSeq := middleware.GetSelectedBranch(r)
+	phone := r.FormValue("phone")
+
+	if customerSeqStr := r.FormValue("customer_seq"); customerSeqStr != "" {
+		customerSeq, err := ValidateCustomerSeq(customerSeqStr)
+		if err != nil {
+			http.Redirect(w, r, "/customers", http.StatusSeeOther)
+			return
+		}
+		if err := ValidateCustomerAccess(r, customerSeq); err != nil {
+			log.Printf("수신거부 등록 접근 거부: %v", err)
+			http.Redirect(w, r, "/customers", http.StatusSeeOther)
+			return
+		}
+
+		detail, err := database.GetCustomerDetail(customerSeq)
+		if err != nil {
+			http.Redirect(w, r, "/customers", http.StatusSeeOther)
+			return
+		}
+		redirectURL = fmt.Sprintf("/customers/detail?seq=%d", customerSeq)
+		if !detail.BranchSeq.Valid {
+			utils.SetFlashMessage(w, r, "error", "지점이 배정되지 않은 고객은 수신거부를 등록할 수 없습니다.")
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+		branchSeq = int(detail.BranchSeq.Int64)
+		phone = detail.PhoneNumber
+	}
+
+	digits, err := ValidateSMSOptOutPhone(phone)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	entry := database.SMSOptOutEntry{
+		PhoneNumber:  digits,
+		Reason:       strings.TrimSpace(r.FormValue("reason")),
+		OptedOutDate: strings.TrimSpace(r.FormValue("opted_out_date")),
+	}
+	if err := ValidateSMSOptOutForm(entry.Reason, entry.OptedOutDate); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	seq, err := database.AddSMSOptOut(middleware.GetAuditActor(r), branchSeq, entry, database.SMSOptOutSourceManual)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if seq == 0 {
+		utils.SetFlashMessage(w, r, "success", "이미 수신거부 등록된 번호입니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "success", "수신거부 번호로 등록되었습니다. 이 번호로는 문자가 발송되지 않습니다.")
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// SMSOptOutDeleteHandler - 수신거부 번호 해제 (POST seq, 선택된 지점 번호만)
+func SMSOptOutDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	seq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil || seq <= 0 {
+		http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.DeleteSMSOptOut(middleware.GetAuditActor(r), middleware.GetSelectedBranch(r), seq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "수신거부가 해제되었습니다.")
+	}
+	http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+}
+
+// SMSOptOutImportHandler - 080 수신거부 목록 가져오기 (POST file: CSV/XLSX/TXT 또는 numbers: 붙여넣기)
+// 행마다 전화번호로 보이는 첫 번째 칸을 번호로, 날짜로 보이는 첫 번째 칸을 수신거부 일자로 사용
+func SMSOptOutImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, importMaxFileSize+(1<<20))
+	if err := r.ParseMultipartForm(importMaxFileSize); err != nil && err != http.ErrNotMultipart {
+		utils.SetFlashMessage(w, r, "error", "파일 크기는 5MB 이하여야 합니다.")
+		http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+		return
+	}
+
+	var table [][]string
+	file, header, err := r.FormFile("file")
+	if err == nil {
+		defer file.Close()
+		table, err = readSMSOptOutFile(file, header.Filename)
+		if err != nil {
+			utils.SetFlashMessage(w, r, "error", err.Error())
+			http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+			return
+		}
+	} else {
+		table = splitSMSOptOutText(r.FormValue("numbers"))
+	}
+
+	if len(table) > smsOptOutImportMaxRows {
+		utils.SetFlashMessage(w, r, "error", fmt.Sprintf("한 번에 최대 %d행까지 가져올 수 있습니다.", smsOptOutImportMaxRows))
+		http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+		return
+	}
+
+	entries, skipped := parseSMSOptOutRows(table)
+	if len(entries) == 0 {
+		utils.SetFlashMessage(w, r, "error", "가져올 전화번호가 없습니다. 파일을 선택하거나 번호를 입력해주세요.")
+		http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+		return
+	}
+
+	added, err := database.ImportSMSOptOuts(middleware.GetAuditActor(r), middleware.GetSelectedBranch(r), entries, database.SMSOptOutSource080)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	message := fmt.Sprintf("수신거부 번호 %d개를 새로 등록했습니다. (이미 등록된 번호 %d개", added, len(entries)-added)
+	if skipped > 0 {
+		message += fmt.Sprintf(", 번호를 찾을 수 없는 행 %d개", skipped)
+	}
+	utils.SetFlashMessage(w, r, "success", message+")")
+	http.Redirect(w, r, smsOptOutsURL, http.StatusSeeOther)
+}
+
+// readSMSOptOutFile - 080 수신거부 목록 파일을 행 목록으로 읽기
+// TXT는 한 줄에 한 행 (UTF-8 또는 EUC-KR), CSV/XLSX는 고객 가져오기와 같은 방식으로 읽음
+func readSMSOptOutFile(file io.Reader, fileName string) ([][]string, error) {
+	if strings.ToLower(filepath.Ext(fileName)) != ".txt" {
+		table, err := readImportFile(file, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("%v (TXT 파일도 가져올 수 있습니다)", err)
+		}
+		return table, nil
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, importMaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("파일을 읽을 수 없습니다")
+	}
+	if len(content) > importMaxFileSize {
+		return nil, fmt.Errorf("파일 크기는 5MB 이하여야 합니다")
+	}
+
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(content) {
+		if content, err = korean.EUCKR.NewDecoder().Bytes(content); err != nil {
+			return nil, fmt.Errorf("TXT 파일 인코딩을 확인할 수 없습니다 (UTF-8 또는 EUC-KR)")
+		}
+	}
+	return splitSMSOptOutText(string(content)), nil
+}
+
+// splitSMSOptOutText - 붙여넣은 목록/TXT 내용을 행 목록으로 변환 (쉼표, 탭, 세미콜론으로 칸 구분)
+func splitSMSOptOutText(text string) [][]string {
+	var table [][]string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == '\t' || r == ';'
+		})
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		table = append(table, fields)
+	}
+	return table
+}
+
+// parseSMSOptOutRows - 행 목록에서 수신거부 번호 추출 (같은 번호는 한 번만, 헤더 등 번호가 없는 행은 건너뜀)
+// 반환: 등록할 번호 목록, 번호를 찾을 수 없는 행 수
+func parseSMSOptOutRows(table [][]string) ([]database.SMSOptOutEntry, int) {
+	seen := map[string]bool{}
+	var entries []database.SMSOptOutEntry
+	skipped := 0
+	for _, record := range table {
+		var phone, date string
+		for _, cell := range record {
+			if phone == "" {
+				if digits, err := ValidateSMSOptOutPhone(cell); err == nil {
+					phone = digits
+					continue
+				}
+			}
+			if date == "" {
+				date = parseSMSOptOutDate(cell)
+			}
+		}
+
+		if phone == "" {
+			if !isBlankImportRecord(record) {
+				skipped++
+			}
+			continue
+		}
+		if seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		entries = append(entries, database.SMSOptOutEntry{
+			PhoneNumber:  phone,
+			Reason:       smsOptOutImportReason,
+			OptedOutDate: date,
+		})
+	}
+	return entries, skipped
+}
+
+// parseSMSOptOutDate - 칸 값이 수신거부 일자이면 YYYY-MM-DD로 변환 (시간이 붙어 있으면 무시, 오늘 이후 날짜는 사용하지 않음)
+func parseSMSOptOutDate(cell string) string {
+	if fields := strings.Fields(cell); len(fields) > 0 {
+		cell = fields[0]
+	}
+	for _, layout := range smsOptOutDateLayouts {
+		if date, err := time.ParseInLocation(layout, cell, time.Local); err == nil && !date.After(time.Now()) {
+			return date.Format("2006-01-02")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package customers
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// BulkTagHandler - 선택한 고객에 태그 일괄 추가/제거 (POST customer_seq[], tag_seq, mode=add|remove, query)
+// 처리 후 현재 검색 조건을 유지한 고객 목록으로 이동
+func BulkTagHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "잘못된 요청입니다", http.StatusBadRequest)
+		return
+	}
+
+	values, _ := url.ParseQuery(r.FormValue("query"))
+	redirectURL := "/customers?" + customerSearchQuery(values).Encode()
+
+	var customerSeqs []int
+	for _, v := range r.Form["customer_seq"] {
+		if seq, err := ValidateCustomerSeq(v); err == nil {
+			customerSeqs = append(customerSeqs, seq)
+		}
+	}
+	if len(customerSeqs) == 0 {
+		utils.SetFlashMessage(w, r, "error", "태그를 변경할 고객을 선택해주세요.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	tagSeq, err := strconv.Atoi(r.FormValue("tag_seq"))
+	if err != nil || tagSeq <= 0 {
+		utils.SetFlashMessage(w, r, "error", "태그를 선택해주세요.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	add := r.FormValue("mode") != "remove"
+	branchSeq := middleware.GetSelectedBranch(r)
+	actor := middleware.GetAuditActor(r)
+
+	var changed int
+	if add {
+		changed, err = database.TagCustomers(actor, branchSeq, tagSeq, customerSeqs)
+	} else {
+		changed, err = database.UntagCustomers(actor, branchSeq, tagSeq, customerSeqs)
+	}
+	if err == sql.ErrNoRows {
+		utils.SetFlashMessage(w, r, "error", "태그를 찾을 수 없습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		log.Printf("고객 태그 일괄 변경 오류: %v", err)
+		utils.SetFlashMessage(w, r, "error", "태그 변경 중 오류가 발생했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	var message string
+	if add {
+		message = fmt.Sprintf("%d명의 고객에 태그를 추가했습니다.", changed)
+		if skipped := len(customerSeqs) - changed; skipped > 0 {
+			message += fmt.Sprintf(" (이미 태그가 있는 고객 %d명 제외)", skipped)
+		}
+	} else {
+		message = fmt.Sprintf("%d명의 고객에서 태그를 제거했습니다.", changed)
+		if skipped := len(customerSeqs) - changed; skipped > 0 {
+			message += fmt.Sprintf(" (태그가 없는 고객 %d명 제외)", skipped)
+		}
+	}
+	utils.SetFlashMessage(w, r, "success", message)
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
@@ -0,0 +1,161 @@
+package customers
+
+import (
+	"backoffice/config"
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// trashScope - 요청의 휴지통 범위 (unassigned=1이면 미배정 고객, 아니면 선택된 지점)
+// 미배정 고객 휴지통은 전체 지점 계정만 조회 가능
+// 반환: 지점 seq (nil이면 미배정 고객), 미배정 고객 범위 여부
+func trashScope(r *http.Request) (*int, bool) {
+	if r.FormValue("unassigned") == "1" && canAccessCustomerBranch(r, sql.NullInt64{}) {
+		return nil, true
+	}
+	branchSeq := middleware.GetSelectedBranch(r)
+	return &branchSeq, false
+}
+
+// trashURL - 범위를 유지한 휴지통 주소
+func trashURL(unassigned bool) string {
+	if unassigned {
+		return "/customers/trash?unassigned=1"
+	}
+	return "/customers/trash"
+}
+
+// TrashHandler - 고객 휴지통 (선택된 지점에서 삭제한 고객 목록)
+func TrashHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	branchSeq, unassigned := trashScope(r)
+	retentionDays := config.GetConfig().Customer.TrashRetentionDays
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	itemsPerPage := 20
+
+	totalItems, err := database.GetDeletedCustomersCount(branchSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	dbCustomers, err := database.GetDeletedCustomers(branchSeq, retentionDays, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	fullPhone := canViewFullPhone(r)
+	var customers []TrashCustomer
+	for _, c := range dbCustomers {
+		customer := TrashCustomer{
+			ID:          strconv.Itoa(c.Seq),
+			Name:        c.Name,
+			Phone:       visiblePhone(c.PhoneNumber, fullPhone),
+			AdSource:    utils.PointerToString(c.AdSource),
+			Status:      c.Status,
+			CallCount:   c.CallCount,
+			CreatedDate: c.CreatedDate,
+			DeletedAt:   c.DeletedAt,
+			DeletedBy:   c.DeletedBy,
+			PurgeDate:   c.PurgeDate,
+		}
+		if c.MergedInto != nil {
+			customer.MergedInto = strconv.Itoa(*c.MergedInto)
+		}
+		if customer.DeletedBy == "" {
+			customer.DeletedBy = "-"
+		}
+		if retentionDays <= 0 {
+			customer.PurgeDate = "-"
+		}
+		customers = append(customers, customer)
+	}
+
+	data := TrashPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "고객 휴지통",
+		ActiveMenu:     "customers",
+		Customers:      customers,
+		Pagination:     pagination,
+		TotalCount:     totalItems,
+		Unassigned:     unassigned,
+		CanUnassigned:  canAccessCustomerBranch(r, sql.NullInt64{}),
+		RetentionDays:  retentionDays,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	if err := Templates.ExecuteTemplate(w, "customers/trash.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Println("Template error:", err)
+	}
+}
+
+// RestoreHandler - 휴지통 고객 복원 (POST seq, unassigned)
+func RestoreHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	branchSeq, unassigned := trashScope(r)
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.RestoreCustomer(middleware.GetAuditActor(r), customerSeq, branchSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "고객 복원 중 오류가 발생했습니다.")
+		http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "고객이 복원되었습니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "error", "휴지통에서 고객을 찾을 수 없습니다.")
+	}
+	http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+}
+
+// PurgeHandler - 휴지통 고객 영구 삭제 (POST seq, unassigned)
+func PurgeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	branchSeq, unassigned := trashScope(r)
+	customerSeq, err := ValidateCustomerSeq(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.PurgeCustomer(middleware.GetAuditActor(r), customerSeq, branchSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "고객 영구 삭제 중 오류가 발생했습니다.")
+		http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "고객이 영구 삭제되었습니다.")
+	} else {
+		utils.SetFlashMessage(w, r, "error", "휴지통에서 고객을 찾을 수 없습니다.")
+	}
+	http.Redirect(w, r, trashURL(unassigned), http.StatusSeeOther)
+}
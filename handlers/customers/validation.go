@@ -1,10 +1,15 @@
 package customers
 
 import (
+	"backoffice/database"
+	"backoffice/middleware"
 	"backoffice/utils"
+	"database/sql"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
+	"unicode/utf8"
 )
 
 // ValidateCustomerSeq 고객 시퀀스 유효성 검증
@@ -65,11 +70,215 @@ func ValidateReservationParams(customerSeqStr, caller, interviewDateStr string)
 	return customerSeq, caller, interviewDate, nil
 }
 
-// ValidateUserSeqFromSession 세션에서 user_seq 유효성 검증
-func ValidateUserSeqFromSession(userSeq interface{}) (int, error) {
-	seq, ok := userSeq.(int)
-	if !ok || seq <= 0 {
-		return 0, fmt.Errorf("세션에서 user_seq를 찾을 수 없음")
+// ValidateCustomerAccess 현재 사용자가 고객 데이터에 접근 가능한지 검증
+// 지점 소속 계정은 소속 지점 고객만, 미배정 고객은 전체 지점 계정만 접근 가능
+func ValidateCustomerAccess(r *http.Request, customerSeq int) error {
+	branchSeq, err := database.GetCustomerBranchSeq(customerSeq)
+	if err != nil {
+		return fmt.Errorf("고객을 찾을 수 없음: %d", customerSeq)
+	}
+
+	if !canAccessCustomerBranch(r, branchSeq) {
+		if !branchSeq.Valid {
+			return fmt.Errorf("미배정 고객 접근 권한 없음: %d", customerSeq)
+		}
+		return fmt.Errorf("다른 지점 고객 접근 시도: customer=%d, branch=%d", customerSeq, branchSeq.Int64)
+	}
+
+	return nil
+}
+
+// canAccessCustomerBranch - 고객 소속 지점에 현재 사용자가 접근 가능한지 확인
+// 미배정 고객(branchSeq.Valid=false)은 지점에 속하지 않은 사용자만 접근 가능
+func canAccessCustomerBranch(r *http.Request, branchSeq sql.NullInt64) bool {
+	if !branchSeq.Valid {
+		user := middleware.GetCurrentUser(r)
+		return user != nil && !user.BranchSeq.Valid
+	}
+	return middleware.CanAccessBranch(r, int(branchSeq.Int64))
+}
+
+// ValidateImportTable 가져오기 파일 내용 검증 (헤더 + 데이터 행, 최대 행 수)
+func ValidateImportTable(table [][]string, maxRows int) error {
+	if len(table) < 2 {
+		return fmt.Errorf("헤더와 데이터 행이 있는 파일을 업로드해주세요")
+	}
+	if len(table)-1 > maxRows {
+		return fmt.Errorf("한 번에 최대 %d행까지 가져올 수 있습니다 (현재 %d행)", maxRows, len(table)-1)
+	}
+	return nil
+}
+
+// ValidateImportRow 가져오기 행 검증 (전화번호는 숫자만 남겨 정규화)
+// 오류는 row.Errors에 추가
+func ValidateImportRow(row *ImportRow) {
+	if row.Name == "" {
+		row.Errors = append(row.Errors, "이름 누락")
+	} else if utf8.RuneCountInString(row.Name) > 100 {
+		row.Errors = append(row.Errors, "이름은 100자 이하")
+	}
+
+	if row.Phone == "" {
+		row.Errors = append(row.Errors, "전화번호 누락")
+	} else {
+		normalized := utils.NormalizePhoneNumber(row.Phone)
+		if !utils.IsValidPhoneNumber(normalized) {
+			row.Errors = append(row.Errors, "잘못된 전화번호 형식")
+		} else {
+			row.PhoneNormalized = normalized
+		}
+	}
+
+	if utf8.RuneCountInString(row.Comment) > 200 {
+		row.Errors = append(row.Errors, "코멘트는 200자 이하")
+	}
+	if utf8.RuneCountInString(row.CommercialName) > 100 {
+		row.Errors = append(row.Errors, "광고명은 100자 이하")
+	}
+	if utf8.RuneCountInString(row.AdSource) > 100 {
+		row.Errors = append(row.Errors, "광고 출처는 100자 이하")
+	}
+}
+
+// ValidatePresetName 검색 프리셋 이름 검증 (1~50자)
+func ValidatePresetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("검색 조건 이름을 입력해주세요")
+	}
+	if utf8.RuneCountInString(name) > 50 {
+		return fmt.Errorf("검색 조건 이름은 50자 이하여야 합니다")
+	}
+	return nil
+}
+
+// ValidateStatusRevertReason 상태 되돌리기 사유 검증 (1~200자)
+func ValidateStatusRevertReason(reason string) error {
+	if reason == "" {
+		return fmt.Errorf("되돌리는 사유를 입력해주세요")
+	}
+	if utf8.RuneCountInString(reason) > 200 {
+		return fmt.Errorf("되돌리는 사유는 200자 이하여야 합니다")
+	}
+	return nil
+}
+
+// ValidateAssignmentRule 자동 배정 규칙 검증 (순환 배정 외에는 비교 값 1~100자 필수)
+func ValidateAssignmentRule(rule *database.AssignmentRule) error {
+	if _, ok := database.AssignmentRuleTypeNames[rule.RuleType]; !ok {
+		return fmt.Errorf("규칙 종류가 올바르지 않습니다")
+	}
+	if rule.BranchSeq <= 0 {
+		return fmt.Errorf("배정할 지점을 선택해주세요")
+	}
+
+	if rule.RuleType == database.AssignmentRuleRoundRobin {
+		rule.MatchValue = ""
+		return nil
+	}
+	if rule.MatchValue == "" {
+		return fmt.Errorf("%s 값을 입력해주세요", database.AssignmentRuleTypeNames[rule.RuleType])
+	}
+	if utf8.RuneCountInString(rule.MatchValue) > 100 {
+		return fmt.Errorf("%s 값은 100자 이하여야 합니다", database.AssignmentRuleTypeNames[rule.RuleType])
 	}
-	return seq, nil
+	return nil
+}
+
+// ValidateCallbackForm 콜백 등록 입력값 검증 (예정 일시는 지금 이후 1년 이내, 메모 200자 이하)
+// 파라미터: dueAtStr (datetime-local 형식, 2006-01-02T15:04), note (메모)
+// 반환: DB 저장용 예정 일시 (한국 시간, 2006-01-02 15:04:05), 에러
+func ValidateCallbackForm(dueAtStr, note string) (string, error) {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return "", fmt.Errorf("시간대 로드 실패: %v", err)
+	}
+
+	dueAt, err := time.ParseInLocation("2006-01-02T15:04", dueAtStr, loc)
+	if err != nil {
+		return "", fmt.Errorf("콜백 예정 일시를 입력해주세요")
+	}
+	now := time.Now().In(loc)
+	if dueAt.Before(now.Truncate(time.Minute)) {
+		return "", fmt.Errorf("콜백 예정 일시는 현재 이후여야 합니다")
+	}
+	if dueAt.After(now.AddDate(1, 0, 0)) {
+		return "", fmt.Errorf("콜백 예정 일시는 1년 이내여야 합니다")
+	}
+
+	if utf8.RuneCountInString(note) > 200 {
+		return "", fmt.Errorf("메모는 200자 이하여야 합니다")
+	}
+	return dueAt.Format("2006-01-02 15:04:05"), nil
+}
+
+// ValidateBulkCustomerSeqs 일괄 처리 고객 선택 검증 (중복 제거, 요청 순서 유지, 최대 database.BulkMaxCustomers명)
+func ValidateBulkCustomerSeqs(values []string) ([]int, error) {
+	seen := map[int]bool{}
+	var seqs []int
+	for _, v := range values {
+		seq, err := ValidateCustomerSeq(v)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[seq] {
+			seen[seq] = true
+			seqs = append(seqs, seq)
+		}
+	}
+
+	if len(seqs) == 0 {
+		return nil, fmt.Errorf("처리할 고객을 선택해주세요")
+	}
+	if len(seqs) > database.BulkMaxCustomers {
+		return nil, fmt.Errorf("한 번에 최대 %d명까지 처리할 수 있습니다", database.BulkMaxCustomers)
+	}
+	return seqs, nil
+}
+
+// ValidateBulkStatusReason 일괄 상태 변경 사유 검증 (선택 입력, 100자 이하)
+func ValidateBulkStatusReason(reason string) error {
+	if utf8.RuneCountInString(reason) > 100 {
+		return fmt.Errorf("변경 사유는 100자 이하여야 합니다")
+	}
+	return nil
+}
+
+// ValidateCustomerNote 고객 메모 내용 검증 (필수, 1000자 이하)
+func ValidateCustomerNote(content string) error {
+	if content == "" {
+		return fmt.Errorf("메모 내용을 입력해주세요")
+	}
+	if utf8.RuneCountInString(content) > 1000 {
+		return fmt.Errorf("메모는 1000자 이하여야 합니다")
+	}
+	return nil
+}
+
+// ValidateSMSOptOutPhone 수신거부 번호 검증 (숫자만 남긴 국내번호 9~11자리, 휴대폰/유선 모두 허용)
+// 반환: 숫자만 남긴 번호, 에러
+func ValidateSMSOptOutPhone(phone string) (string, error) {
+	digits := utils.NormalizePhoneNumber(phone)
+	if len(digits) < 9 || len(digits) > 11 || digits[0] != '0' {
+		return "", fmt.Errorf("올바른 전화번호를 입력해주세요")
+	}
+	return digits, nil
+}
+
+// ValidateSMSOptOutForm 수신거부 등록 입력값 검증 (사유 200자 이하, 수신거부 일자는 선택 입력이며 오늘 이후 불가)
+func ValidateSMSOptOutForm(reason, optedOutDate string) error {
+	if utf8.RuneCountInString(reason) > 200 {
+		return fmt.Errorf("수신거부 사유는 200자 이하여야 합니다")
+	}
+	if optedOutDate == "" {
+		return nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", optedOutDate, time.Local)
+	if err != nil {
+		return fmt.Errorf("수신거부 일자 형식이 올바르지 않습니다")
+	}
+	if date.After(time.Now()) {
+		return fmt.Errorf("수신거부 일자는 오늘 이후일 수 없습니다")
+	}
+	return nil
 }
@@ -3,6 +3,7 @@ package home
 import (
 	"backoffice/database"
 	"backoffice/middleware"
+	"backoffice/utils"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -14,6 +15,15 @@ import (
 
 var Templates *template.Template
 
+// 대시보드 콜백 목록 기준
+const (
+	callbackDueSoonMinutes = 60 // 지금부터 이 시간(분) 안에 예정된 콜백까지 표시
+	callbackListLimit      = 20 // 대시보드에 표시할 최대 콜백 수
+)
+
+// callerLetters - 콜백 완료 시 CALLER 선택지 (고객 목록 CALLER 버튼과 동일)
+var callerLetters = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P"}
+
 // Handler - 홈페이지 핸들러
 func Handler(w http.ResponseWriter, r *http.Request) {
 	// 현재 로그인한 사용자의 지점 정보 가져오기
@@ -73,6 +83,39 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		Color: "#9b59b6",
 	})
 
+	// 연락할 콜백 (지난 콜백 + 곧 예정된 콜백)
+	dueCount, overdueCount, err := database.CountDueCallbacks(branchSeq, callbackDueSoonMinutes)
+	if err != nil {
+		log.Printf("Handler - CountDueCallbacks error: %v", err)
+	}
+	dueCallbacks, err := database.GetDueCallbacks(branchSeq, callbackDueSoonMinutes, callbackListLimit)
+	if err != nil {
+		log.Printf("Handler - GetDueCallbacks error: %v", err)
+		dueCallbacks = []database.CallbackTask{}
+	}
+
+	// 전화번호 전체 보기 권한이 없으면 콜백 목록의 번호를 010-****-5678 형식으로 마스킹
+	user := middleware.GetCurrentUser(r)
+	if user == nil || !middleware.HasPermission(user.Role, middleware.PermCustomerPhoneView) {
+		for i := range dueCallbacks {
+			dueCallbacks[i].PhoneNumber = utils.MaskPhoneNumber(dueCallbacks[i].PhoneNumber)
+		}
+	}
+
+	stats = append(stats, StatCard{
+		Title: "연락할 콜백",
+		Value: fmt.Sprintf("%d건 (지남 %d건)", dueCount, overdueCount),
+		Icon:  "⏰",
+		Color: "#e67e22",
+	})
+
+	// 태그별 고객 수 (휴지통 고객 제외)
+	tags, err := database.GetCustomerTags(branchSeq)
+	if err != nil {
+		log.Printf("Handler - GetCustomerTags error: %v", err)
+		tags = []database.CustomerTag{}
+	}
+
 	data := PageData{
 		BasePageData:   middleware.GetBasePageData(r),
 		Title:          "대시보드",
@@ -81,6 +124,11 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		Stats:          stats,
 		DailyStats:     dailyStats,
 		DailyStatsJSON: string(dailyStatsJSON),
+		Tags:           tags,
+		DueCallbacks:   dueCallbacks,
+		CallerLetters:  callerLetters,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
 	}
 
 	if err := Templates.ExecuteTemplate(w, "dashboard/home.html", data); err != nil {
@@ -22,4 +22,9 @@ type PageData struct {
 	Stats          []StatCard
 	DailyStats     []database.DailyCustomerStats
 	DailyStatsJSON string
+	Tags           []database.CustomerTag  // 지점 태그별 고객 수
+	DueCallbacks   []database.CallbackTask // 연락할 콜백 (지난 콜백 + 곧 예정된 콜백)
+	CallerLetters  []string                // 콜백 완료 시 CALLER 선택지
+	SuccessMessage string
+	ErrorMessage   string
 }
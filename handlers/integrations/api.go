@@ -7,6 +7,7 @@ import (
 	"backoffice/services/sms"
 	"backoffice/utils"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -54,9 +55,9 @@ func SMSTestHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("환경: %s", config.GetEnvironment())
 	log.Printf("계정 ID: %s", req.AccountID)
 	log.Printf("비밀번호: %s", utils.MaskPassword(req.Password))
-	log.Printf("발신번호: %s", req.SenderPhone)
-	log.Printf("수신번호: %s", req.ReceiverPhone)
-	log.Printf("메시지: %s", req.Message)
+	log.Printf("발신번호: %s", utils.MaskPhoneNumber(req.SenderPhone))
+	log.Printf("수신번호: %s", utils.MaskPhoneNumber(req.ReceiverPhone))
+	log.Printf("메시지: %s", utils.RedactPII(req.Message))
 	log.Println("========================")
 
 	// SMS 발송 서비스 호출
@@ -67,9 +68,14 @@ func SMSTestHandler(w http.ResponseWriter, r *http.Request) {
 		ReceiverPhone: req.ReceiverPhone,
 		Message:       req.Message,
 		Subject:       "테스트 메시지",
+		BranchSeq:     middleware.GetSelectedBranch(r),
 	}
 
 	result, err := sms.Send(sendReq)
+	if errors.Is(err, sms.ErrOptedOut) {
+		utils.JSONError(w, http.StatusBadRequest, "수신거부 등록된 번호입니다. 다른 번호로 테스트해주세요.")
+		return
+	}
 	if err != nil {
 		log.Printf("SMS 발송 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, err.Error())
@@ -190,7 +196,7 @@ func DisconnectHandler(w http.ResponseWriter, r *http.Request) {
 
 // CreateCalendarEventHandler godoc
 // @Summary      구글 캘린더 이벤트 생성
-// @Description  구글 캘린더에 새로운 이벤트를 생성합니다
+// @Description  구글 캘린더에 새로운 이벤트를 생성합니다 (전화번호는 customer_seq로 조회한 고객 번호 사용)
 // @Tags         integrations
 // @Accept       json
 // @Produce      json
@@ -199,6 +205,7 @@ func DisconnectHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      400      {string}  string  "잘못된 요청"
 // @Failure      500      {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /calendar/create-event [post]
 func CreateCalendarEventHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -214,6 +221,22 @@ func CreateCalendarEventHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 세션에서 지점 정보 가져오기
+	branchSeq := middleware.GetSelectedBranch(r)
+
+	// 전화번호 전체 보기 권한이 없는 사용자는 마스킹된 번호만 가지고 있으므로 고객 정보의 번호 사용
+	if req.CustomerSeq <= 0 {
+		utils.JSONError(w, http.StatusBadRequest, "고객 정보가 없습니다")
+		return
+	}
+	customer, err := database.GetCustomerDetail(req.CustomerSeq)
+	if err != nil || !customer.BranchSeq.Valid || int(customer.BranchSeq.Int64) != branchSeq {
+		log.Printf("CreateCalendarEvent - 고객 확인 실패 - 고객 ID: %d, 에러: %v", req.CustomerSeq, err)
+		utils.JSONError(w, http.StatusBadRequest, "고객 정보를 확인할 수 없습니다")
+		return
+	}
+	req.PhoneNumber = customer.PhoneNumber
+
 	// 요청 데이터 검증
 	err = ValidateCalendarEventRequest(req.CustomerName, req.PhoneNumber, req.InterviewDate, req.Duration)
 	if err != nil {
@@ -222,9 +245,6 @@ func CreateCalendarEventHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 세션에서 지점 정보 가져오기
-	branchSeq := middleware.GetSelectedBranch(r)
-
 	// 캘린더 이벤트 생성
 	link, err := CreateCalendarEvent(branchSeq, req)
 	if err != nil {
@@ -353,6 +373,7 @@ func CreateCalendarEvent(branchSeq int, req CreateCalendarEventRequest) (string,
 // @Failure      401  {string}  string  "인증 실패"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /integrations/check-sms [get]
 func CheckSMSIntegrationHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -401,6 +422,7 @@ func CheckSMSIntegrationHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      401  {string}  string  "인증 실패"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /integrations/sms-senders [get]
 func GetSMSSenderNumbersHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -185,7 +185,7 @@ func SMSConfigSaveHandler(w http.ResponseWriter, r *http.Request) {
 	log.Println("=== SMS 설정 저장 요청 ===")
 	log.Printf("계정 ID: %s", accountID)
 	log.Printf("비밀번호: %s", utils.MaskPassword(password))
-	log.Printf("발신번호: %v", senderPhones)
+	log.Printf("발신번호 수: %d", len(senderPhones))
 	log.Printf("활성화: %v", isActive)
 	log.Println("========================")
 
@@ -219,7 +219,7 @@ func SMSConfigSaveHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Database를 통해 설정 저장 (지점별, SMS/LMS 잔여건수 포함)
-	if err := database.SaveSMSConfig(branchCode, accountID, password, senderPhones, isActive, remainingCountSMSPtr, remainingCountLMSPtr); err != nil {
+	if err := database.SaveSMSConfig(middleware.GetAuditActor(r), branchCode, accountID, password, senderPhones, isActive, remainingCountSMSPtr, remainingCountLMSPtr); err != nil {
 		log.Printf("SMS 설정 저장 오류: %v", err)
 		http.Redirect(w, r, "/integrations?error=save_failed", http.StatusSeeOther)
 		return
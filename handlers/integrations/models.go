@@ -61,8 +61,9 @@ type SMSConfigSaveResponse struct {
 
 // CreateCalendarEventRequest 캘린더 이벤트 생성 요청 구조체
 type CreateCalendarEventRequest struct {
+	CustomerSeq    int    `json:"customer_seq"`    // 고객 시퀀스 (전화번호는 고객 정보에서 조회)
 	CustomerName   string `json:"customer_name"`   // 고객 이름
-	PhoneNumber    string `json:"phone_number"`    // 전화번호
+	PhoneNumber    string `json:"-"`               // 전화번호 (요청 값은 사용하지 않고 customer_seq로 조회한 번호 사용)
 	InterviewDate  string `json:"interview_date"`  // 인터뷰 일시 (YYYY-MM-DD HH:MM:SS)
 	Comment        string `json:"comment"`         // 코멘트
 	Duration       int    `json:"duration"`        // 소요시간 (분, 기본값 60분)
@@ -3,9 +3,13 @@ package login
 import (
 	"backoffice/config"
 	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 //TODO :: 로그인 세션이 존재하는 사용자가 로그인 페이지로 가면, 대시보드로 리다이렉트 시키기
@@ -27,6 +31,15 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		data.Error = "잘못된 요청입니다."
 	} else if errorParam == "unauthorized" {
 		data.Error = "로그인이 필요합니다. 로그인 후 이용해주세요."
+	} else if errorParam == "account_disabled" {
+		data.Error = "비활성화된 계정입니다. 관리자에게 문의해주세요."
+	} else if errorParam == "locked" {
+		data.Error = "로그인 실패 횟수가 많아 일시적으로 로그인이 제한되었습니다. 잠시 후 다시 시도해주세요."
+		if retry, err := strconv.Atoi(r.URL.Query().Get("retry")); err == nil && retry > 0 {
+			data.Error = fmt.Sprintf("로그인 실패 횟수가 많아 일시적으로 로그인이 제한되었습니다. %d분 후 다시 시도해주세요.", retry)
+		}
+	} else if errorParam == "verify_expired" {
+		data.Error = "2단계 인증 시간이 만료되었습니다. 다시 로그인해주세요."
 	}
 
 	if err := Templates.ExecuteTemplate(w, "auth/login.html", data); err != nil {
@@ -56,49 +69,132 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 
 	username := r.FormValue("username")
 	password := r.FormValue("password")
+	clientIP := utils.GetClientIP(r)
+	userAgent := r.UserAgent()
+
+	// 무차별 대입 방어: 잠금 중이면 비밀번호 검증 없이 거부
+	if remaining := checkLockout(username, clientIP); remaining > 0 {
+		log.Printf("잠금 상태 로그인 시도 - 아이디: %s, IP: %s, 남은 시간: %v", username, clientIP, remaining)
+		database.RecordLoginAttempt(username, clientIP, userAgent, database.LoginResultLocked)
+		http.Redirect(w, r, lockedRedirectURL(remaining), http.StatusSeeOther)
+		return
+	}
 
 	// DB 인증
 	authenticated, userSeq := database.AuthenticateUser(username, password)
 
 	if authenticated {
-		// 세션에 사용자 ID와 Seq 저장
-		session, err := config.SessionStore.Get(r, "user-session")
+		user, err := database.GetUserBySeq(userSeq)
 		if err != nil {
-			log.Printf("세션 가져오기 실패: %v", err)
+			log.Printf("로그인 사용자 조회 실패: %v", err)
 			http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
 			return
 		}
 
-		session.Values["user_id"] = username
-		session.Values["user_seq"] = userSeq
-		session.Values["authenticated"] = true
-
-		if err := session.Save(r, w); err != nil {
-			log.Printf("세션 저장 실패: %v", err)
-			http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
+		// 비활성화된 계정은 로그인 차단
+		if !user.IsActive {
+			log.Printf("비활성화된 계정 로그인 시도: %s", username)
+			database.RecordLoginAttempt(username, clientIP, userAgent, database.LoginResultDisabled)
+			http.Redirect(w, r, "/login?error=account_disabled", http.StatusSeeOther)
 			return
 		}
 
-		// 첫 번째 지점을 기본값으로 설정 (seq만 저장)
-		appSession, _ := config.SessionStore.Get(r, "app-session")
-		branchList, err := database.GetBranchesForSelect()
-		if err == nil && len(branchList) > 0 {
-			// 첫 번째 지점을 기본값으로 설정 (seq 저장)
-			appSession.Values["selectedBranch"] = branchList[0]["seq"]
-			appSession.Save(r, w)
-			log.Printf("로그인 시 기본 지점 설정 - seq: %s", branchList[0]["seq"])
-		} else {
-			log.Printf("지점 목록 조회 실패: %v", err)
+		// 2단계 인증 사용 계정은 코드 확인 전까지 반인증 상태로 보류
+		if user.TOTPEnabled {
+			if err := setPendingTwoFactor(w, r, user.Seq); err != nil {
+				log.Printf("2단계 인증 대기 세션 저장 실패: %v", err)
+				http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
+				return
+			}
+			http.Redirect(w, r, "/login/verify", http.StatusSeeOther)
+			return
 		}
 
-		// 성공: 대시보드로 리다이렉트
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		database.RecordLoginAttempt(username, clientIP, userAgent, database.LoginResultSuccess)
+		completeLogin(w, r, user)
 	} else {
+		database.RecordLoginAttempt(username, clientIP, userAgent, database.LoginResultFailure)
+
+		// 이번 실패로 잠금 기준에 도달했으면 바로 잠금 안내
+		if remaining := checkLockout(username, clientIP); remaining > 0 {
+			log.Printf("로그인 잠금 시작 - 아이디: %s, IP: %s, 잠금 시간: %v", username, clientIP, remaining)
+			http.Redirect(w, r, lockedRedirectURL(remaining), http.StatusSeeOther)
+			return
+		}
+
 		// 실패: 로그인 페이지로 리다이렉트하고 에러 메시지 표시
 		http.Redirect(w, r, "/login?error=login_failed", http.StatusSeeOther)
 	}
 }
 
+// completeLogin - 인증이 끝난 사용자의 세션 생성 및 첫 화면으로 이동
+// 비밀번호 로그인(2단계 인증 미사용)과 2단계 인증 확인 후 공통으로 사용
+func completeLogin(w http.ResponseWriter, r *http.Request, user *database.User) {
+	// 세션에 사용자 ID와 Seq 저장
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		log.Printf("세션 가져오기 실패: %v", err)
+		http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
+		return
+	}
+
+	// 세션 고정 공격 방지: 로그인 전 세션은 버리고 새 세션 ID 발급
+	if session.ID != "" {
+		database.DeleteSession(session.ID)
+		session.ID = ""
+	}
+
+	clearPendingTwoFactor(session)
+	session.Values["user_id"] = user.UserID
+	session.Values["user_seq"] = user.Seq
+	session.Values["authenticated"] = true
+
+	if err := session.Save(r, w); err != nil {
+		log.Printf("세션 저장 실패: %v", err)
+		http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
+		return
+	}
+
+	// 기본 지점 설정 (seq만 저장)
+	// 지점 소속 계정은 소속 지점, 전체 지점 계정은 첫 번째 지점
+	appSession, _ := config.SessionStore.Get(r, "app-session")
+	defaultBranchSeq, err := getDefaultBranchSeq(user)
+	if err == nil && defaultBranchSeq != "" {
+		appSession.Values["selectedBranch"] = defaultBranchSeq
+		appSession.Save(r, w)
+		log.Printf("로그인 시 기본 지점 설정 - seq: %s", defaultBranchSeq)
+	} else {
+		log.Printf("지점 목록 조회 실패: %v", err)
+	}
+
+	// 첫 로그인 또는 비밀번호 초기화 후에는 비밀번호 변경 페이지로 이동
+	if user.MustChangePassword {
+		http.Redirect(w, r, middleware.PasswordChangePath, http.StatusSeeOther)
+		return
+	}
+
+	// 성공: 대시보드로 리다이렉트
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// getDefaultBranchSeq - 로그인 시 선택할 기본 지점 seq 조회
+// 지점 소속 계정은 소속 지점, 전체 지점 계정은 첫 번째 지점 (지점이 없으면 빈 문자열)
+func getDefaultBranchSeq(user *database.User) (string, error) {
+	if user.BranchSeq.Valid {
+		return fmt.Sprintf("%d", user.BranchSeq.Int64), nil
+	}
+
+	branchList, err := database.GetBranchesForSelect()
+	if err != nil {
+		return "", err
+	}
+	if len(branchList) == 0 {
+		return "", nil
+	}
+
+	return branchList[0]["seq"], nil
+}
+
 // LogoutHandler - 로그아웃 처리 핸들러
 func LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	// 세션 가져오기
@@ -0,0 +1,75 @@
+package login
+
+import (
+	"backoffice/config"
+	"backoffice/database"
+	"log"
+	"strconv"
+	"time"
+)
+
+// maxLockShift - 잠금 시간 배수 계산 상한 (2^maxLockShift, 오버플로 방지)
+const maxLockShift = 20
+
+// lockDuration - 실패 횟수에 따른 잠금 시간 계산
+// threshold 회 실패부터 LockBaseSeconds 동안 잠그고, 이후 실패할 때마다 2배씩 늘려 LockMaxSeconds까지 증가
+func lockDuration(failures, threshold int, cfg config.LoginSecurityConfig) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+
+	shift := failures - threshold
+	if shift > maxLockShift {
+		shift = maxLockShift
+	}
+
+	seconds := cfg.LockBaseSeconds << shift
+	if seconds > cfg.LockMaxSeconds {
+		seconds = cfg.LockMaxSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// remainingLock - 마지막 실패 이후 남은 잠금 시간
+func remainingLock(stats database.LoginFailureStats, threshold int, cfg config.LoginSecurityConfig) time.Duration {
+	remaining := lockDuration(stats.Count, threshold, cfg) - time.Duration(stats.SecondsSinceLastFailure)*time.Second
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// checkLockout - 계정/IP 잠금 여부 확인
+// 반환: 남은 잠금 시간 (0이면 잠금 아님, 계정/IP 중 더 긴 쪽)
+// 이력 조회 실패 시에는 로그인 자체를 막지 않음 (DB 장애 시 인증도 실패하므로)
+func checkLockout(userID, ipAddress string) time.Duration {
+	cfg := config.GetConfig().LoginSecurity
+	var remaining time.Duration
+
+	if userID != "" {
+		stats, err := database.GetUserLoginFailureStats(userID, cfg.UserWindowMinutes)
+		if err != nil {
+			log.Printf("계정 로그인 실패 이력 조회 오류: %v", err)
+		} else if d := remainingLock(stats, cfg.UserLockThreshold, cfg); d > remaining {
+			remaining = d
+		}
+	}
+
+	stats, err := database.GetIPLoginFailureStats(ipAddress, cfg.IPWindowMinutes)
+	if err != nil {
+		log.Printf("IP 로그인 실패 이력 조회 오류: %v", err)
+	} else if d := remainingLock(stats, cfg.IPLockThreshold, cfg); d > remaining {
+		remaining = d
+	}
+
+	return remaining
+}
+
+// lockedRedirectURL - 잠금 안내 로그인 페이지 URL (남은 시간은 분 단위 올림)
+func lockedRedirectURL(remaining time.Duration) string {
+	minutes := int((remaining + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return "/login?error=locked&retry=" + strconv.Itoa(minutes)
+}
@@ -0,0 +1,127 @@
+package login
+
+import (
+	"backoffice/config"
+	"backoffice/database"
+	"backoffice/services/totp"
+	"backoffice/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/sessions"
+)
+
+// pendingTwoFactorTTL - 비밀번호 확인 후 2단계 인증 코드를 입력해야 하는 제한 시간
+const pendingTwoFactorTTL = 5 * time.Minute
+
+// setPendingTwoFactor - 비밀번호 확인이 끝난 사용자를 2단계 인증 대기 상태로 세션에 저장
+// authenticated 값은 설정하지 않으므로 RequireAuth를 통과하지 못함
+func setPendingTwoFactor(w http.ResponseWriter, r *http.Request, userSeq int) error {
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return err
+	}
+
+	delete(session.Values, "authenticated")
+	session.Values["pending_2fa_user_seq"] = userSeq
+	session.Values["pending_2fa_at"] = time.Now().Unix()
+	return session.Save(r, w)
+}
+
+// getPendingTwoFactor - 2단계 인증 대기 세션과 사용자 seq 조회 (없거나 만료되면 seq 0)
+func getPendingTwoFactor(r *http.Request) (*sessions.Session, int) {
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return session, 0
+	}
+
+	userSeq, ok := session.Values["pending_2fa_user_seq"].(int)
+	if !ok || userSeq <= 0 {
+		return session, 0
+	}
+
+	startedAt, ok := session.Values["pending_2fa_at"].(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > pendingTwoFactorTTL {
+		return session, 0
+	}
+
+	return session, userSeq
+}
+
+// clearPendingTwoFactor - 2단계 인증 대기 상태 제거
+func clearPendingTwoFactor(session *sessions.Session) {
+	delete(session.Values, "pending_2fa_user_seq")
+	delete(session.Values, "pending_2fa_at")
+}
+
+// VerifyHandler - 2단계 인증 코드 입력 페이지 (GET: 폼, POST: 코드 확인)
+// 인증 앱의 6자리 코드 또는 1회용 복구 코드를 받음
+func VerifyHandler(w http.ResponseWriter, r *http.Request) {
+	session, userSeq := getPendingTwoFactor(r)
+	if userSeq == 0 {
+		http.Redirect(w, r, "/login?error=verify_expired", http.StatusSeeOther)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		renderVerify(w, "")
+		return
+	}
+
+	user, err := database.GetUserBySeq(userSeq)
+	if err != nil {
+		http.Redirect(w, r, "/login?error=invalid_request", http.StatusSeeOther)
+		return
+	}
+	if !user.IsActive {
+		clearPendingTwoFactor(session)
+		session.Save(r, w)
+		http.Redirect(w, r, "/login?error=account_disabled", http.StatusSeeOther)
+		return
+	}
+
+	clientIP := utils.GetClientIP(r)
+	userAgent := r.UserAgent()
+
+	// 코드 입력도 비밀번호와 같은 잠금 정책 적용
+	if remaining := checkLockout(user.UserID, clientIP); remaining > 0 {
+		log.Printf("잠금 상태 2단계 인증 시도 - 아이디: %s, IP: %s", user.UserID, clientIP)
+		database.RecordLoginAttempt(user.UserID, clientIP, userAgent, database.LoginResultLocked)
+		clearPendingTwoFactor(session)
+		session.Save(r, w)
+		http.Redirect(w, r, lockedRedirectURL(remaining), http.StatusSeeOther)
+		return
+	}
+
+	if !totp.VerifyUserCode(user.Seq, r.FormValue("code")) {
+		database.RecordLoginAttempt(user.UserID, clientIP, userAgent, database.LoginResultFailure)
+		log.Printf("2단계 인증 실패 - 아이디: %s, IP: %s", user.UserID, clientIP)
+
+		if remaining := checkLockout(user.UserID, clientIP); remaining > 0 {
+			clearPendingTwoFactor(session)
+			session.Save(r, w)
+			http.Redirect(w, r, lockedRedirectURL(remaining), http.StatusSeeOther)
+			return
+		}
+
+		renderVerify(w, "인증 코드가 올바르지 않습니다.")
+		return
+	}
+
+	database.RecordLoginAttempt(user.UserID, clientIP, userAgent, database.LoginResultSuccess)
+	completeLogin(w, r, user)
+}
+
+// renderVerify - 2단계 인증 코드 입력 템플릿 렌더링
+func renderVerify(w http.ResponseWriter, errorMessage string) {
+	data := PageData{
+		Title: "2단계 인증",
+		Error: errorMessage,
+	}
+
+	if err := Templates.ExecuteTemplate(w, "auth/verify.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Println("Template error:", err)
+	}
+}
@@ -290,7 +290,7 @@ func SetDefaultHandler(w http.ResponseWriter, r *http.Request) {
 	branchCode := middleware.GetSelectedBranch(r)
 
 	// DB를 통해 기본 템플릿 설정 (지점별)
-	err = database.SetDefaultMessageTemplate(branchCode, id)
+	err = database.SetDefaultMessageTemplate(middleware.GetAuditActor(r), branchCode, id)
 	if err != nil {
 		log.Printf("기본 템플릿 설정 실패: %v\n", err)
 		http.Redirect(w, r, "/message-templates?error=set_default_failed", http.StatusSeeOther)
@@ -113,6 +113,12 @@ func DetailHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 다른 지점 게시글 접근 차단
+	if !canAccessNotice(r, id) {
+		http.Redirect(w, r, "/notices", http.StatusSeeOther)
+		return
+	}
+
 	// 조회수 증가
 	_ = database.IncrementNoticeViewCount(id)
 
@@ -200,7 +206,7 @@ func AddHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_, err := database.InsertNotice(branchSeq, title, content, category, isPinned, eventStartDate, eventEndDate, createdBy)
+		_, err := database.InsertNotice(middleware.GetAuditActor(r), branchSeq, title, content, category, isPinned, eventStartDate, eventEndDate, createdBy)
 		if err != nil {
 			log.Printf("공지사항 등록 오류: %v", err)
 			data := AddPageData{
@@ -244,6 +250,12 @@ func EditHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 다른 지점 게시글 접근 차단
+	if !canAccessNotice(r, id) {
+		http.Redirect(w, r, "/notices", http.StatusSeeOther)
+		return
+	}
+
 	if r.Method == http.MethodPost {
 		// POST: 수정 처리
 		title := r.FormValue("title")
@@ -269,7 +281,7 @@ func EditHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_, err := database.UpdateNotice(id, title, content, category, isPinned, eventStartDate, eventEndDate)
+		_, err := database.UpdateNotice(middleware.GetAuditActor(r), id, title, content, category, isPinned, eventStartDate, eventEndDate)
 		if err != nil {
 			log.Printf("공지사항 수정 오류: %v", err)
 		}
@@ -315,7 +327,13 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = database.DeleteNotice(id)
+	// 다른 지점 게시글 접근 차단
+	if !canAccessNotice(r, id) {
+		http.Redirect(w, r, "/notices", http.StatusSeeOther)
+		return
+	}
+
+	_, err = database.DeleteNotice(middleware.GetAuditActor(r), id)
 	if err != nil {
 		log.Printf("공지사항 삭제 오류: %v", err)
 	}
@@ -323,6 +341,19 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/notices", http.StatusSeeOther)
 }
 
+// canAccessNotice - 현재 사용자가 게시글의 지점에 접근 가능한지 확인
+func canAccessNotice(r *http.Request, id int) bool {
+	n, err := database.GetNoticeByID(id)
+	if err != nil {
+		return false
+	}
+	if !middleware.CanAccessBranch(r, n.BranchSeq) {
+		log.Printf("다른 지점 공지사항 접근 시도 - ID: %d, BranchSeq: %d", id, n.BranchSeq)
+		return false
+	}
+	return true
+}
+
 // noticeToDetail - DB Notice를 NoticeDetail로 변환
 func noticeToDetail(n *database.Notice) NoticeDetail {
 	if n == nil {
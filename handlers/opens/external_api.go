@@ -28,13 +28,14 @@ type ExternalCustomerRequest struct {
 // @Param        request  body      ExternalCustomerRequest  true  "고객 정보"
 // @Success      200      {object}  map[string]interface{}  "성공"
 // @Failure      400      {string}  string  "잘못된 요청"
+// @Failure      409      {object}  map[string]interface{}  "같은 전화번호 고객 존재 (CUSTOMER_DUPLICATE_POLICY=reject)"
 // @Failure      500      {string}  string  "서버 오류"
 // @Router       /external/customers [post]
 func ExternalRegisterCustomerHandler(w http.ResponseWriter, r *http.Request) {
 	// 요청 정보 로깅
 	log.Println("=== 외부 고객 등록 API 호출 ===")
 	log.Printf("요청 메소드: %s", r.Method)
-	log.Printf("요청 URL: %s", r.URL.String())
+	log.Printf("요청 URL: %s", utils.RedactPII(r.URL.String()))
 	log.Printf("클라이언트 IP: %s", r.RemoteAddr)
 	log.Printf("User-Agent: %s", r.UserAgent())
 
@@ -58,8 +59,6 @@ func ExternalRegisterCustomerHandler(w http.ResponseWriter, r *http.Request) {
 		Language:   0,
 	}
 
-	log.Printf("req object is : %s", fmt.Sprintf("%+v", req))
-
 	// language는 숫자이므로 변환
 	if langStr := query.Get("language"); langStr != "" {
 		var lang int
@@ -70,8 +69,8 @@ func ExternalRegisterCustomerHandler(w http.ResponseWriter, r *http.Request) {
 
 	// 요청 데이터 로깅
 	log.Printf("요청 데이터:")
-	log.Printf("  - Name: %s", req.Name)
-	log.Printf("  - Phone: %s", req.Phone)
+	log.Printf("  - Name: %s", utils.MaskName(req.Name))
+	log.Printf("  - Phone: %s", utils.MaskPhoneNumber(req.Phone))
 	log.Printf("  - Location: %s", req.Location)
 	log.Printf("  - Job: %s", req.Job)
 	log.Printf("  - AdPlatform: %s", req.AdPlatform)
@@ -89,22 +88,36 @@ func ExternalRegisterCustomerHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("지점 조회 성공: branch_seq=%d", branchSeq)
 
 	// 2단계: customers 테이블에 고객 등록
-	customerSeq, err := database.InsertExternalCustomer(branchSeq, req.Name, req.Phone, req.AdPlatform, req.AdName)
+	result, err := database.InsertExternalCustomer(branchSeq, req.Name, req.Phone, req.AdPlatform, req.AdName)
 	if err != nil {
 		log.Printf("고객 등록 실패: %v", err)
+		if dupErr, ok := err.(*database.DuplicateCustomerError); ok {
+			// 중복 정책이 reject인 경우 409로 기존 고객 seq 전달
+			utils.JSONResponse(w, http.StatusConflict, map[string]interface{}{
+				"success":      false,
+				"error":        "같은 전화번호의 고객이 이미 등록되어 있습니다",
+				"duplicate_of": dupErr.ExistingSeq,
+			})
+			return
+		}
 		utils.JSONError(w, http.StatusInternalServerError, "고객 등록에 실패했습니다")
 		return
 	}
-	log.Printf("고객 등록 성공: customer_seq=%d", customerSeq)
+	log.Printf("고객 등록 성공: customer_seq=%d, duplicate_of=%d, attached=%t", result.Seq, result.DuplicateOf, result.Attached)
 
 	log.Println("=== 외부 고객 등록 API 호출 완료!!!!!!! ===")
 
-	// 성공 응답
-	utils.JSONSuccess(w, map[string]interface{}{
+	// 성공 응답 (duplicate_of: 같은 전화번호의 기존 고객 seq, attached: 기존 고객에 연결되어 새로 등록되지 않음)
+	response := map[string]interface{}{
 		"message":      "고객이 성공적으로 등록되었습니다",
-		"customer_seq": customerSeq,
+		"customer_seq": result.Seq,
 		"branch_seq":   branchSeq,
-	})
+		"attached":     result.Attached,
+	}
+	if result.DuplicateOf > 0 {
+		response["duplicate_of"] = result.DuplicateOf
+	}
+	utils.JSONSuccess(w, response)
 }
 
 // validateExternalCustomerRequest 외부 고객 등록 요청 검증
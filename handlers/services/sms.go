@@ -5,10 +5,12 @@ import (
 	"backoffice/middleware"
 	"backoffice/services/sms"
 	"backoffice/utils"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // SendSMSHandler godoc
@@ -19,13 +21,14 @@ import (
 // @Produce      json
 // @Param        customer_seq    formData  string  true  "고객 시퀀스"
 // @Param        sender_phone    formData  string  true  "발신번호"
-// @Param        receiver_phone  formData  string  true  "수신번호"
+// @Param        receiver_phone  formData  string  true  "수신번호 (전화번호 전체 보기 권한이 없거나 마스킹된 번호면 무시하고 고객 정보의 번호로 발송)"
 // @Param        message         formData  string  true  "메시지 내용"
 // @Success      200  {object}  map[string]interface{}  "성공"
-// @Failure      400  {string}  string  "잘못된 요청"
+// @Failure      400  {string}  string  "잘못된 요청 또는 수신거부 번호"
 // @Failure      401  {string}  string  "인증 실패"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /service/sms [post]
 func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -60,13 +63,27 @@ func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if receiverPhone == "" {
-		utils.JSONError(w, http.StatusBadRequest, "수신번호가 없습니다.")
+	if message == "" {
+		utils.JSONError(w, http.StatusBadRequest, "메시지 내용을 입력해주세요.")
 		return
 	}
 
-	if message == "" {
-		utils.JSONError(w, http.StatusBadRequest, "메시지 내용을 입력해주세요.")
+	// 전화번호 전체 보기 권한이 없는 사용자는 마스킹된 번호(010-****-5678)만 알고 있으므로
+	// 요청한 수신번호는 무시하고 지점 고객 정보의 번호로 발송
+	user := middleware.GetCurrentUser(r)
+	fullPhone := user != nil && middleware.HasPermission(user.Role, middleware.PermCustomerPhoneView)
+	if !fullPhone || strings.Contains(receiverPhone, "*") {
+		customer, err := database.GetCustomerDetail(customerSeq)
+		if err != nil || !customer.BranchSeq.Valid || int(customer.BranchSeq.Int64) != branchSeq {
+			log.Printf("수신번호 확인 실패 - 고객 ID: %d, 에러: %v", customerSeq, err)
+			utils.JSONError(w, http.StatusBadRequest, "수신번호를 확인할 수 없습니다.")
+			return
+		}
+		receiverPhone = customer.PhoneNumber
+	}
+
+	if receiverPhone == "" {
+		utils.JSONError(w, http.StatusBadRequest, "수신번호가 없습니다.")
 		return
 	}
 
@@ -96,9 +113,14 @@ func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 		SenderPhone:   senderPhone,
 		ReceiverPhone: receiverPhone,
 		Message:       message,
+		BranchSeq:     branchSeq,
 	}
 
 	sendResp, err := sms.Send(sendReq)
+	if errors.Is(err, sms.ErrOptedOut) {
+		utils.JSONError(w, http.StatusBadRequest, "수신거부 등록된 번호입니다. 수신거부 번호로는 문자를 발송할 수 없습니다.")
+		return
+	}
 	if err != nil {
 		log.Printf("SMS 전송 오류: %v", err)
 		utils.JSONError(w, http.StatusInternalServerError, "SMS 전송 중 오류가 발생했습니다.")
@@ -106,13 +128,13 @@ func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !sendResp.Success {
-		log.Printf("SMS 전송 실패: %s (코드: %s)", sendResp.Message, sendResp.Code)
+		log.Printf("SMS 전송 실패: %s (코드: %s)", utils.RedactPII(sendResp.Message), sendResp.Code)
 		errorMsg := fmt.Sprintf("SMS 전송 실패: %s", sendResp.Message)
 		utils.JSONError(w, http.StatusInternalServerError, errorMsg)
 		return
 	}
 
-	log.Printf("SMS 전송 성공: %s (코드: %s) ", sendResp.Message, sendResp.Code)
+	log.Printf("SMS 전송 성공: %s (코드: %s) ", utils.RedactPII(sendResp.Message), sendResp.Code)
 
 	// SMS 전송 성공 후 잔여건수 업데이트
 	if sendResp.Cols != "" {
@@ -123,8 +145,18 @@ func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// 발송 이력 감사 로그 (외부 발송은 되돌릴 수 없으므로 기록 실패는 로그만 남김)
+	// 감사 로그에는 마스킹된 수신번호와 개인정보를 가린 메시지만 저장 (전화번호 열람 감사 로그와 동일한 기준)
+	database.RecordAudit(middleware.GetAuditActor(r), database.AuditSMSSend, "customer", customerSeq, nil, map[string]interface{}{
+		"branch_seq":     branchSeq,
+		"sender_phone":   senderPhone,
+		"receiver_phone": utils.MaskPhoneNumber(receiverPhone),
+		"msg_type":       sendResp.MsgType,
+		"message":        utils.RedactPII(message),
+	})
+
 	// 성공 응답
-	log.Printf("SMS 전송 성공 - 고객 ID: %d, 수신번호: %s", customerSeq, receiverPhone)
+	log.Printf("SMS 전송 성공 - 고객 ID: %d, 수신번호: %s", customerSeq, utils.MaskPhoneNumber(receiverPhone))
 	utils.JSONSuccess(w, map[string]interface{}{
 		"message": "메시지가 성공적으로 전송되었습니다.",
 		"nums":    sendResp.Nums,
@@ -142,6 +174,7 @@ func SendSMSHandler(w http.ResponseWriter, r *http.Request) {
 // @Failure      401  {string}  string  "인증 실패"
 // @Failure      500  {string}  string  "서버 오류"
 // @Security     SessionAuth
+// @Security     BearerAuth
 // @Router       /service/reservation-sms-config [get]
 func GetReservationSMSConfigHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
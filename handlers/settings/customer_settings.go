@@ -0,0 +1,230 @@
+package settings
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CustomerSettingsHandler 고객 관리 설정 페이지 (지점 통화 횟수 제한 + 고객 상태 목록 + 지점 고객 태그)
+// POST: 선택된 지점의 통화 횟수 제한 저장
+func CustomerSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	branchSeq := middleware.GetSelectedBranch(r)
+
+	if r.Method == http.MethodPost {
+		limit, err := ValidateCallLimit(r.FormValue("call_limit"))
+		if err != nil {
+			utils.SetFlashMessage(w, r, "error", err.Error())
+			http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+			return
+		}
+
+		if err := database.UpdateBranchCallLimit(middleware.GetAuditActor(r), branchSeq, limit); err != nil {
+			log.Printf("통화 횟수 제한 저장 오류: %v", err)
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+
+		utils.SetFlashMessage(w, r, "success", "통화 횟수 제한이 저장되었습니다.")
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	callLimit, err := database.GetBranchCallLimit(branchSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	statuses, err := database.GetCustomerStatusDefs()
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	tags, err := database.GetCustomerTags(branchSeq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	data := CustomerSettingsPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "고객 관리 설정",
+		ActiveMenu:     "settings",
+		CallLimit:      callLimit,
+		Statuses:       statuses,
+		Tags:           tags,
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	if err := Templates.ExecuteTemplate(w, "settings/customer-settings.html", data); err != nil {
+		log.Printf("템플릿 실행 오류: %v", err)
+		http.Error(w, "템플릿 렌더링 오류", http.StatusInternalServerError)
+	}
+}
+
+// SaveCustomerStatusHandler 고객 상태 추가/수정 (POST seq가 없으면 추가)
+func SaveCustomerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	def := database.CustomerStatusDef{
+		Name:       strings.TrimSpace(r.FormValue("name")),
+		Color:      strings.TrimSpace(r.FormValue("color")),
+		IsActive:   r.FormValue("is_active") == "on",
+		IsTerminal: r.FormValue("is_terminal") == "on",
+	}
+	def.SortOrder, _ = strconv.Atoi(r.FormValue("sort_order"))
+
+	seqStr := r.FormValue("seq")
+	if err := ValidateCustomerStatus(def, seqStr == ""); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	actor := middleware.GetAuditActor(r)
+	if seqStr == "" {
+		if _, err := database.CreateCustomerStatus(actor, def); err != nil {
+			log.Printf("고객 상태 추가 오류: %v", err)
+			utils.SetFlashMessage(w, r, "error", "상태를 추가하지 못했습니다. 같은 이름의 상태가 있는지 확인해주세요.")
+			http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+			return
+		}
+		utils.SetFlashMessage(w, r, "success", "'"+def.Name+"' 상태가 추가되었습니다.")
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	seq, err := strconv.Atoi(seqStr)
+	if err != nil {
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+	def.Seq = seq
+
+	if _, err := database.UpdateCustomerStatus(actor, def); err != nil {
+		log.Printf("고객 상태 수정 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", "상태가 저장되었습니다.")
+	http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+}
+
+// DeleteCustomerStatusHandler 추가한 고객 상태 삭제 (POST seq)
+func DeleteCustomerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	seq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.DeleteCustomerStatus(middleware.GetAuditActor(r), seq)
+	if inUse, ok := err.(*database.CustomerStatusInUseError); ok {
+		utils.SetFlashMessage(w, r, "error", inUse.Error()+". 고객 상태를 먼저 변경해주세요.")
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "상태가 삭제되었습니다.")
+	}
+	http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+}
+
+// SaveCustomerTagHandler 선택된 지점의 고객 태그 추가/수정 (POST seq가 없으면 추가)
+func SaveCustomerTagHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	color := strings.TrimSpace(r.FormValue("color"))
+	if err := ValidateCustomerTag(name, color); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	branchSeq := middleware.GetSelectedBranch(r)
+	actor := middleware.GetAuditActor(r)
+
+	seqStr := r.FormValue("seq")
+	if seqStr == "" {
+		if _, err := database.CreateCustomerTag(actor, branchSeq, name, color); err != nil {
+			log.Printf("고객 태그 추가 오류: %v", err)
+			utils.SetFlashMessage(w, r, "error", "태그를 추가하지 못했습니다. 같은 이름의 태그가 있는지 확인해주세요.")
+			http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+			return
+		}
+		utils.SetFlashMessage(w, r, "success", "'"+name+"' 태그가 추가되었습니다.")
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	seq, err := strconv.Atoi(seqStr)
+	if err != nil {
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := database.UpdateCustomerTag(actor, branchSeq, seq, name, color); err != nil {
+		log.Printf("고객 태그 수정 오류: %v", err)
+		utils.SetFlashMessage(w, r, "error", "태그를 저장하지 못했습니다. 같은 이름의 태그가 있는지 확인해주세요.")
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", "태그가 저장되었습니다.")
+	http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+}
+
+// DeleteCustomerTagHandler 선택된 지점의 고객 태그 삭제 (POST seq, 고객에 붙은 태그도 함께 삭제)
+func DeleteCustomerTagHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	seq, err := strconv.Atoi(r.FormValue("seq"))
+	if err != nil {
+		http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+		return
+	}
+
+	rowsAffected, err := database.DeleteCustomerTag(middleware.GetAuditActor(r), middleware.GetSelectedBranch(r), seq)
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if rowsAffected > 0 {
+		utils.SetFlashMessage(w, r, "success", "태그가 삭제되었습니다.")
+	}
+	http.Redirect(w, r, "/settings/customers", http.StatusSeeOther)
+}
@@ -122,3 +122,15 @@ type ReservationSMSConfigPageData struct {
 	SenderNumbers []string
 	Config        *database.ReservationSMSConfig
 }
+
+// CustomerSettingsPageData 고객 관리 설정 페이지 데이터
+type CustomerSettingsPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	CallLimit      int // 선택된 지점의 통화 횟수 제한 (0이면 제한 없음)
+	Statuses       []database.CustomerStatusDef
+	Tags           []database.CustomerTag // 선택된 지점의 고객 태그 (고객 수 포함)
+	SuccessMessage string
+	ErrorMessage   string
+}
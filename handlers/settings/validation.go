@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"backoffice/database"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCallLimit - 통화 횟수 제한 최대값
+const maxCallLimit = 99
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateCallLimit 통화 횟수 제한 검증 (0~99, 0이면 제한 없음)
+func ValidateCallLimit(value string) (int, error) {
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 0 || limit > maxCallLimit {
+		return 0, fmt.Errorf("통화 횟수 제한은 0~%d 사이의 숫자여야 합니다", maxCallLimit)
+	}
+	return limit, nil
+}
+
+// ValidateCustomerStatus 고객 상태 입력값 검증 (이름은 추가할 때만 검사)
+func ValidateCustomerStatus(def database.CustomerStatusDef, isNew bool) error {
+	if isNew {
+		if def.Name == "" {
+			return fmt.Errorf("상태 이름을 입력해주세요")
+		}
+		if utf8.RuneCountInString(def.Name) > 20 {
+			return fmt.Errorf("상태 이름은 20자 이하여야 합니다")
+		}
+	}
+	if !colorPattern.MatchString(def.Color) {
+		return fmt.Errorf("색상은 #RRGGBB 형식이어야 합니다")
+	}
+	if def.IsActive && def.IsTerminal {
+		return fmt.Errorf("처리중 목록 표시와 종료 상태는 함께 선택할 수 없습니다")
+	}
+	return nil
+}
+
+// ValidateCustomerTag 고객 태그 입력값 검증 (이름 1~20자, 색상 #RRGGBB)
+func ValidateCustomerTag(name, color string) error {
+	if name == "" {
+		return fmt.Errorf("태그 이름을 입력해주세요")
+	}
+	if utf8.RuneCountInString(name) > 20 {
+		return fmt.Errorf("태그 이름은 20자 이하여야 합니다")
+	}
+	if !colorPattern.MatchString(color) {
+		return fmt.Errorf("색상은 #RRGGBB 형식이어야 합니다")
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package users
+
+import (
+	"backoffice/database"
+	"backoffice/handlers/account"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// APITokensHandler - API 토큰 관리 페이지 핸들러 (전체 또는 ?userSeq= 사용자별)
+// 사용자별 보기에서는 해당 계정 소유의 서비스 토큰을 발급할 수 있음
+func APITokensHandler(w http.ResponseWriter, r *http.Request) {
+	data := APITokensPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "API 토큰",
+		ActiveMenu:     "users",
+		SuccessMessage: utils.GetFlashMessage(w, r, "success"),
+		ErrorMessage:   utils.GetFlashMessage(w, r, "error"),
+	}
+
+	userSeq := 0
+	if userSeqStr := r.URL.Query().Get("userSeq"); userSeqStr != "" {
+		seq, err := ValidateUserSeq(userSeqStr)
+		if err != nil {
+			http.Redirect(w, r, "/users/api-tokens", http.StatusSeeOther)
+			return
+		}
+
+		owner, err := database.GetUserAccountBySeq(seq)
+		if err == sql.ErrNoRows {
+			utils.SetFlashMessage(w, r, "error", "사용자를 찾을 수 없습니다.")
+			http.Redirect(w, r, "/users/api-tokens", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
+			http.Redirect(w, r, "/error", http.StatusSeeOther)
+			return
+		}
+
+		item := toUserItem(*owner)
+		data.Owner = &item
+		data.Scopes = account.ScopeOptionsForRole(owner.Role)
+		data.ExpiryOptions = account.ExpiryOptions()
+		userSeq = seq
+	}
+
+	renderAPITokens(w, r, data, userSeq)
+}
+
+// IssueAPITokenHandler - 사용자 소유의 API 토큰 발급 핸들러 (POST, 서비스 계정용)
+// 토큰 원문은 응답 화면에만 한 번 표시
+func IssueAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	userSeq, err := ValidateUserSeq(r.FormValue("user_seq"))
+	if err != nil {
+		http.Redirect(w, r, "/users/api-tokens", http.StatusSeeOther)
+		return
+	}
+	redirectURL := fmt.Sprintf("/users/api-tokens?userSeq=%d", userSeq)
+
+	owner, err := database.GetUserAccountBySeq(userSeq)
+	if err == sql.ErrNoRows {
+		utils.SetFlashMessage(w, r, "error", "사용자를 찾을 수 없습니다.")
+		http.Redirect(w, r, "/users/api-tokens", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if !owner.IsActive {
+		utils.SetFlashMessage(w, r, "error", "비활성 계정에는 API 토큰을 발급할 수 없습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	name := r.FormValue("name")
+	scopes := r.Form["scopes"]
+	branchSeq, expiresDays, err := account.ValidateAPITokenForm(owner.Role, owner.BranchSeq, name, scopes, r.FormValue("branch_seq"), r.FormValue("expires_days"))
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	issuedBy := ""
+	if user := middleware.GetCurrentUser(r); user != nil {
+		issuedBy = user.UserID
+	}
+
+	token, err := database.CreateAPIToken(owner.Seq, name, scopes, branchSeq, expiresDays, issuedBy)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "API 토큰 발급에 실패했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("API 토큰 발급 (관리자) - 소유자: %s, 발급자: %s, 이름: %s", owner.UserID, issuedBy, name)
+
+	item := toUserItem(*owner)
+	data := APITokensPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "API 토큰",
+		ActiveMenu:     "users",
+		Owner:          &item,
+		Scopes:         account.ScopeOptionsForRole(owner.Role),
+		ExpiryOptions:  account.ExpiryOptions(),
+		NewToken:       token,
+		SuccessMessage: fmt.Sprintf("%s 계정의 API 토큰이 발급되었습니다.", owner.UserID),
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	renderAPITokens(w, r, data, owner.Seq)
+}
+
+// RevokeAPITokenHandler - API 토큰 폐기 핸들러 (POST, 소유자와 무관하게 폐기)
+func RevokeAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	redirectURL := "/users/api-tokens"
+	if userSeq, err := ValidateUserSeq(r.FormValue("user_seq")); err == nil {
+		redirectURL = fmt.Sprintf("/users/api-tokens?userSeq=%d", userSeq)
+	}
+
+	tokenSeq, err := strconv.Atoi(r.FormValue("token_seq"))
+	if err != nil || tokenSeq <= 0 {
+		utils.SetFlashMessage(w, r, "error", "폐기할 토큰이 없습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	count, err := database.RevokeAPIToken(tokenSeq, 0)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "API 토큰 폐기에 실패했습니다.")
+	} else if count == 0 {
+		utils.SetFlashMessage(w, r, "error", "이미 폐기되었거나 존재하지 않는 토큰입니다.")
+	} else {
+		log.Printf("API 토큰 폐기 (관리자) - 토큰: %d", tokenSeq)
+		utils.SetFlashMessage(w, r, "success", "API 토큰을 폐기했습니다.")
+	}
+
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// renderAPITokens - 토큰 목록을 채워 API 토큰 템플릿 렌더링
+// userSeq: 소유자 (0이면 전체)
+func renderAPITokens(w http.ResponseWriter, r *http.Request, data APITokensPageData, userSeq int) {
+	tokens, err := database.GetAPITokens(userSeq)
+	if err != nil {
+		log.Printf("API 토큰 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+	for _, t := range tokens {
+		data.Tokens = append(data.Tokens, account.ToAPITokenItem(t))
+	}
+
+	renderTemplate(w, r, "users/api-tokens.html", data)
+}
@@ -0,0 +1,445 @@
+package users
+
+import (
+	"backoffice/database"
+	"backoffice/handlers/errorhandler"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"database/sql"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+var Templates *template.Template
+
+// tempPasswordLength - 관리자가 발급하는 임시 비밀번호 길이
+const tempPasswordLength = 10
+
+// roleOptions - 역할 선택박스 목록 (권한이 넓은 순)
+func roleOptions() []RoleOption {
+	roles := []string{database.RoleSuperAdmin, database.RoleBranchManager, database.RoleCaller}
+	options := make([]RoleOption, 0, len(roles))
+	for _, role := range roles {
+		options = append(options, RoleOption{Value: role, Name: database.RoleDisplayNames[role]})
+	}
+	return options
+}
+
+// toUserItem - DB 계정 정보를 화면용 구조체로 변환
+func toUserItem(a database.UserAccount) UserItem {
+	item := UserItem{
+		ID:                 strconv.Itoa(a.Seq),
+		UserID:             a.UserID,
+		Role:               a.Role,
+		RoleName:           database.RoleDisplayNames[a.Role],
+		BranchName:         a.BranchName,
+		IsActive:           a.IsActive,
+		MustChangePassword: a.MustChangePassword,
+		TOTPEnabled:        a.TOTPEnabled,
+		CreatedDate:        a.CreatedDate,
+		LastUpdateDate:     a.LastUpdateDate,
+	}
+	if a.BranchSeq.Valid {
+		item.BranchSeq = fmt.Sprintf("%d", a.BranchSeq.Int64)
+	}
+	return item
+}
+
+// isCurrentUser - 대상 계정이 로그인한 본인인지 확인
+func isCurrentUser(r *http.Request, userSeq int) bool {
+	user := middleware.GetCurrentUser(r)
+	return user != nil && user.Seq == userSeq
+}
+
+// Handler - 사용자 목록 페이지 핸들러
+func Handler(w http.ResponseWriter, r *http.Request) {
+	successMessage := utils.GetFlashMessage(w, r, "success")
+	errorMessage := utils.GetFlashMessage(w, r, "error")
+
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	searchKeyword := r.URL.Query().Get("searchKeyword")
+	roleFilter := r.URL.Query().Get("role")
+	if roleFilter != "" && !database.IsValidRole(roleFilter) {
+		roleFilter = ""
+	}
+
+	itemsPerPage := 10
+
+	totalItems, err := database.GetUserAccountsCount(searchKeyword, roleFilter)
+	if err != nil {
+		log.Printf("사용자 수 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	accounts, err := database.GetUserAccounts(searchKeyword, roleFilter, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		log.Printf("사용자 목록 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var users []UserItem
+	for _, a := range accounts {
+		users = append(users, toUserItem(a))
+	}
+
+	data := ListPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "사용자 관리",
+		ActiveMenu:     "users",
+		Users:          users,
+		Roles:          roleOptions(),
+		Pagination:     pagination,
+		SearchKeyword:  searchKeyword,
+		RoleFilter:     roleFilter,
+		TotalCount:     totalItems,
+		SuccessMessage: successMessage,
+		ErrorMessage:   errorMessage,
+	}
+
+	if err := Templates.ExecuteTemplate(w, "users/list.html", data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
+
+// AddHandler - 사용자 추가 페이지 핸들러 (GET: 폼, POST: 생성 처리)
+// 초기 비밀번호를 비워두면 임시 비밀번호를 발급하며, 신규 계정은 첫 로그인 시 비밀번호 변경이 강제됨
+func AddHandler(w http.ResponseWriter, r *http.Request) {
+	branches, err := database.GetBranchesForSelect()
+	if err != nil {
+		log.Printf("지점 목록 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	data := FormPageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "사용자 추가",
+		ActiveMenu:   "users",
+		User:         UserItem{Role: database.RoleCaller, IsActive: true},
+		Roles:        roleOptions(),
+		Branches:     branches,
+	}
+
+	if r.Method == http.MethodGet {
+		renderTemplate(w, r, "users/add.html", data)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	userID := r.FormValue("user_id")
+	password := r.FormValue("password")
+	role := r.FormValue("role")
+	branchSeqStr := r.FormValue("branch_seq")
+
+	data.User.UserID = userID
+	data.User.Role = role
+	data.User.BranchSeq = branchSeqStr
+
+	branchSeq, err := ValidateAddUserForm(userID, password, role, branchSeqStr)
+	if err != nil {
+		data.ErrorMessage = err.Error()
+		renderTemplate(w, r, "users/add.html", data)
+		return
+	}
+
+	// 초기 비밀번호 미입력 시 임시 비밀번호 발급
+	issued := password == ""
+	if issued {
+		password, err = utils.GenerateTempPassword(tempPasswordLength)
+		if err != nil {
+			log.Printf("임시 비밀번호 생성 오류: %v", err)
+			data.ErrorMessage = "임시 비밀번호 생성에 실패했습니다."
+			renderTemplate(w, r, "users/add.html", data)
+			return
+		}
+	}
+
+	if _, err := database.InsertUser(userID, password, role, branchSeq); err != nil {
+		log.Printf("사용자 추가 오류: %v", err)
+		data.ErrorMessage = "사용자 추가에 실패했습니다."
+		renderTemplate(w, r, "users/add.html", data)
+		return
+	}
+
+	if issued {
+		renderPasswordIssued(w, r, userID, password, true)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", "사용자가 추가되었습니다. 첫 로그인 시 비밀번호 변경이 필요합니다.")
+	http.Redirect(w, r, "/users", http.StatusSeeOther)
+}
+
+// EditHandler - 사용자 수정 페이지 핸들러 (역할, 소속 지점, 활성 여부)
+func EditHandler(w http.ResponseWriter, r *http.Request) {
+	userSeq, err := ValidateUserSeq(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	account, err := database.GetUserAccountBySeq(userSeq)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			errorhandler.Handler404(w, r)
+			return
+		}
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	branches, err := database.GetBranchesForSelect()
+	if err != nil {
+		log.Printf("지점 목록 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	data := FormPageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "사용자 수정",
+		ActiveMenu:   "users",
+		User:         toUserItem(*account),
+		Roles:        roleOptions(),
+		Branches:     branches,
+		IsSelf:       isCurrentUser(r, userSeq),
+	}
+
+	if r.Method == http.MethodGet {
+		renderTemplate(w, r, "users/edit.html", data)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	role := r.FormValue("role")
+	branchSeqStr := r.FormValue("branch_seq")
+	isActive := r.FormValue("is_active") == "1"
+
+	// 본인 계정은 역할/활성 상태를 바꿀 수 없음 (스스로 권한을 잃는 것 방지)
+	if data.IsSelf {
+		role = account.Role
+		isActive = true
+	}
+
+	data.User.Role = role
+	data.User.BranchSeq = branchSeqStr
+	data.User.IsActive = isActive
+
+	branchSeq, err := ValidateRoleAndBranch(role, branchSeqStr)
+	if err != nil {
+		data.ErrorMessage = err.Error()
+		renderTemplate(w, r, "users/edit.html", data)
+		return
+	}
+
+	if role != database.RoleSuperAdmin || !isActive {
+		if err := ValidateSuperAdminRemains(account); err != nil {
+			data.ErrorMessage = err.Error()
+			renderTemplate(w, r, "users/edit.html", data)
+			return
+		}
+	}
+
+	if _, err := database.UpdateUserAccount(userSeq, role, branchSeq, isActive); err != nil {
+		log.Printf("사용자 수정 오류: %v", err)
+		data.ErrorMessage = "사용자 수정에 실패했습니다."
+		renderTemplate(w, r, "users/edit.html", data)
+		return
+	}
+
+	// 비활성화된 계정은 즉시 강제 로그아웃
+	if !isActive {
+		database.DeleteUserSessions(userSeq, "")
+	}
+
+	utils.SetFlashMessage(w, r, "success", "사용자 정보가 수정되었습니다.")
+	http.Redirect(w, r, "/users", http.StatusSeeOther)
+}
+
+// ResetPasswordHandler - 사용자 비밀번호 초기화 핸들러 (POST)
+// 임시 비밀번호를 발급하고 다음 로그인 시 변경을 강제
+func ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userSeq, err := ValidateUserSeq(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	account, err := database.GetUserAccountBySeq(userSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "사용자를 찾을 수 없습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	tempPassword, err := utils.GenerateTempPassword(tempPasswordLength)
+	if err != nil {
+		log.Printf("임시 비밀번호 생성 오류: %v", err)
+		utils.SetFlashMessage(w, r, "error", "비밀번호 초기화에 실패했습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	if err := database.ChangeUserPassword(userSeq, tempPassword, true); err != nil {
+		utils.SetFlashMessage(w, r, "error", "비밀번호 초기화에 실패했습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	// 이전 비밀번호로 로그인된 세션 종료
+	database.DeleteUserSessions(userSeq, "")
+
+	log.Printf("비밀번호 초기화 - 대상: %s", account.UserID)
+	renderPasswordIssued(w, r, account.UserID, tempPassword, false)
+}
+
+// ResetTwoFactorHandler - 사용자 2단계 인증 초기화 핸들러 (POST)
+// 인증 앱 분실 등으로 로그인할 수 없는 직원을 위해 관리자가 2단계 인증을 해제
+func ResetTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userSeq, err := ValidateUserSeq(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	account, err := database.GetUserAccountBySeq(userSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "사용자를 찾을 수 없습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	if err := database.DisableTOTP(userSeq); err != nil {
+		utils.SetFlashMessage(w, r, "error", "2단계 인증 초기화에 실패했습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("2단계 인증 초기화 - 대상: %s", account.UserID)
+	utils.SetFlashMessage(w, r, "success", account.UserID+" 계정의 2단계 인증이 해제되었습니다.")
+	http.Redirect(w, r, "/users", http.StatusSeeOther)
+}
+
+// DeleteHandler - 사용자 삭제 핸들러 (POST)
+// 예약 이력이 있는 계정은 예약이 함께 삭제되므로 비활성화만 허용
+func DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userSeq, err := ValidateUserSeq(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	if isCurrentUser(r, userSeq) {
+		utils.SetFlashMessage(w, r, "error", "본인 계정은 삭제할 수 없습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	account, err := database.GetUserAccountBySeq(userSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "사용자를 찾을 수 없습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	if err := ValidateSuperAdminRemains(account); err != nil {
+		utils.SetFlashMessage(w, r, "error", err.Error())
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	reservationCount, err := database.CountUserReservations(userSeq)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "사용자 삭제에 실패했습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+	if reservationCount > 0 {
+		utils.SetFlashMessage(w, r, "error", fmt.Sprintf("예약 이력이 %d건 있는 계정은 삭제할 수 없습니다. 비활성화해주세요.", reservationCount))
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := database.DeleteUser(userSeq); err != nil {
+		utils.SetFlashMessage(w, r, "error", "사용자 삭제에 실패했습니다.")
+		http.Redirect(w, r, "/users", http.StatusSeeOther)
+		return
+	}
+
+	database.DeleteUserSessions(userSeq, "")
+
+	log.Printf("사용자 삭제 성공 - 대상: %s", account.UserID)
+	utils.SetFlashMessage(w, r, "success", "사용자가 삭제되었습니다.")
+	http.Redirect(w, r, "/users", http.StatusSeeOther)
+}
+
+// renderPasswordIssued - 임시 비밀번호 안내 페이지 렌더링
+// 비밀번호를 세션/URL에 남기지 않도록 리다이렉트 없이 바로 응답
+func renderPasswordIssued(w http.ResponseWriter, r *http.Request, userID, tempPassword string, isNewUser bool) {
+	w.Header().Set("Cache-Control", "no-store")
+	data := PasswordIssuedPageData{
+		BasePageData: middleware.GetBasePageData(r),
+		Title:        "임시 비밀번호 발급",
+		ActiveMenu:   "users",
+		UserID:       userID,
+		TempPassword: tempPassword,
+		IsNewUser:    isNewUser,
+	}
+	renderTemplate(w, r, "users/password-issued.html", data)
+}
+
+// renderTemplate - 사용자 관리 템플릿 렌더링
+func renderTemplate(w http.ResponseWriter, r *http.Request, name string, data interface{}) {
+	if err := Templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Println("Template error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,141 @@
+package users
+
+import (
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// resultOptions - 로그인 결과 필터 목록
+func resultOptions() []ResultOption {
+	results := []string{
+		database.LoginResultFailure,
+		database.LoginResultLocked,
+		database.LoginResultDisabled,
+		database.LoginResultSuccess,
+		database.LoginResultUnlock,
+	}
+	options := make([]ResultOption, 0, len(results))
+	for _, result := range results {
+		options = append(options, ResultOption{Value: result, Name: database.LoginResultDisplayNames[result]})
+	}
+	return options
+}
+
+// LoginAttemptsHandler - 로그인 시도 이력 페이지 핸들러
+// 기본 필터는 실패 건이며, result=all이면 전체 이력 표시
+func LoginAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	successMessage := utils.GetFlashMessage(w, r, "success")
+	errorMessage := utils.GetFlashMessage(w, r, "error")
+
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	searchUserID := r.URL.Query().Get("userId")
+	searchIP := r.URL.Query().Get("ip")
+	resultFilter := utils.GetQueryParam(r, "result", database.LoginResultFailure)
+
+	queryResult := resultFilter
+	if _, ok := database.LoginResultDisplayNames[resultFilter]; !ok {
+		resultFilter = "all"
+		queryResult = ""
+	}
+
+	itemsPerPage := 20
+
+	totalItems, err := database.GetLoginAttemptsCount(searchUserID, searchIP, queryResult)
+	if err != nil {
+		log.Printf("로그인 이력 수 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	dbAttempts, err := database.GetLoginAttempts(searchUserID, searchIP, queryResult, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		log.Printf("로그인 이력 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	var attempts []LoginAttemptItem
+	for _, a := range dbAttempts {
+		attempts = append(attempts, LoginAttemptItem{
+			UserID:      a.UserID,
+			IPAddress:   a.IPAddress,
+			UserAgent:   a.UserAgent,
+			Result:      a.Result,
+			ResultName:  database.LoginResultDisplayNames[a.Result],
+			CreatedBy:   a.CreatedBy,
+			CreatedDate: a.CreatedDate,
+		})
+	}
+
+	data := LoginAttemptsPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "로그인 이력",
+		ActiveMenu:     "users",
+		Attempts:       attempts,
+		Results:        resultOptions(),
+		Pagination:     pagination,
+		SearchUserID:   searchUserID,
+		SearchIP:       searchIP,
+		ResultFilter:   resultFilter,
+		TotalCount:     totalItems,
+		SuccessMessage: successMessage,
+		ErrorMessage:   errorMessage,
+	}
+
+	renderTemplate(w, r, "users/login-attempts.html", data)
+}
+
+// UnlockLoginHandler - 계정 또는 IP 로그인 잠금 해제 핸들러 (POST)
+// 폼 값 user_id 또는 ip 중 하나 이상 필요
+func UnlockLoginHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	userID := r.FormValue("user_id")
+	ipAddress := r.FormValue("ip")
+	redirectURL := "/users/login-attempts"
+	if returnQuery := r.FormValue("return_query"); returnQuery != "" {
+		if _, err := url.ParseQuery(returnQuery); err == nil {
+			redirectURL += "?" + returnQuery
+		}
+	}
+
+	if userID == "" && ipAddress == "" {
+		utils.SetFlashMessage(w, r, "error", "잠금 해제할 아이디 또는 IP가 없습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	unlockedBy := ""
+	if user := middleware.GetCurrentUser(r); user != nil {
+		unlockedBy = user.UserID
+	}
+
+	if err := database.UnlockLogin(userID, ipAddress, unlockedBy); err != nil {
+		utils.SetFlashMessage(w, r, "error", "잠금 해제에 실패했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	target := userID
+	if target == "" {
+		target = ipAddress
+	}
+	utils.SetFlashMessage(w, r, "success", target+" 의 로그인 잠금을 해제했습니다.")
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
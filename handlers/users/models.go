@@ -0,0 +1,139 @@
+package users
+
+import (
+	"backoffice/handlers/account"
+	"backoffice/middleware"
+	"backoffice/utils"
+)
+
+// UserItem - 사용자 목록/수정 화면용 계정 데이터
+type UserItem struct {
+	ID                 string
+	UserID             string
+	Role               string
+	RoleName           string
+	BranchSeq          string // 전체 지점 계정이면 빈 문자열
+	BranchName         string
+	IsActive           bool
+	MustChangePassword bool
+	TOTPEnabled        bool
+	CreatedDate        string
+	LastUpdateDate     string
+}
+
+// RoleOption - 역할 선택박스 항목
+type RoleOption struct {
+	Value string
+	Name  string
+}
+
+// ListPageData - 사용자 목록 페이지 데이터 구조체
+type ListPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Users          []UserItem
+	Roles          []RoleOption
+	Pagination     utils.Pagination
+	SearchKeyword  string
+	RoleFilter     string
+	TotalCount     int
+	SuccessMessage string
+	ErrorMessage   string
+}
+
+// FormPageData - 사용자 추가/수정 페이지 데이터 구조체
+type FormPageData struct {
+	middleware.BasePageData
+	Title        string
+	ActiveMenu   string
+	User         UserItem
+	Roles        []RoleOption
+	Branches     []map[string]string
+	IsSelf       bool // 본인 계정 수정 여부 (역할/활성 상태 변경 불가)
+	ErrorMessage string
+}
+
+// PasswordIssuedPageData - 임시 비밀번호 안내 페이지 데이터 구조체
+type PasswordIssuedPageData struct {
+	middleware.BasePageData
+	Title        string
+	ActiveMenu   string
+	UserID       string
+	TempPassword string
+	IsNewUser    bool
+}
+
+// LoginAttemptItem - 로그인 이력 화면용 데이터
+type LoginAttemptItem struct {
+	UserID      string
+	IPAddress   string
+	UserAgent   string
+	Result      string
+	ResultName  string
+	CreatedBy   string
+	CreatedDate string
+}
+
+// ResultOption - 로그인 결과 필터 선택박스 항목
+type ResultOption struct {
+	Value string
+	Name  string
+}
+
+// LoginAttemptsPageData - 로그인 이력 페이지 데이터 구조체
+type LoginAttemptsPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Attempts       []LoginAttemptItem
+	Results        []ResultOption
+	Pagination     utils.Pagination
+	SearchUserID   string
+	SearchIP       string
+	ResultFilter   string
+	TotalCount     int
+	SuccessMessage string
+	ErrorMessage   string
+}
+
+// SessionItem - 활성 세션 화면용 데이터
+type SessionItem struct {
+	SessionID    string
+	UserSeq      int
+	UserID       string
+	IPAddress    string
+	UserAgent    string
+	CreatedDate  string
+	LastActivity string
+	ExpiresAt    string
+	IsCurrent    bool
+}
+
+// SessionsPageData - 활성 세션 페이지 데이터 구조체
+type SessionsPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Sessions       []SessionItem
+	Pagination     utils.Pagination
+	SearchUserID   string
+	IdleMinutes    int
+	TotalCount     int
+	SuccessMessage string
+	ErrorMessage   string
+}
+
+// APITokensPageData - API 토큰 관리 페이지 데이터 구조체
+type APITokensPageData struct {
+	middleware.BasePageData
+	Title          string
+	ActiveMenu     string
+	Tokens         []account.APITokenItem
+	Owner          *UserItem // 사용자별 보기일 때 대상 계정 (발급 폼 표시)
+	Scopes         []account.ScopeOption
+	ExpiryOptions  []account.ExpiryOption
+	NewToken       string // 새로 발급된 토큰 원문 (발급 직후 한 번만 표시)
+	SuccessMessage string
+	ErrorMessage   string
+}
@@ -0,0 +1,132 @@
+package users
+
+import (
+	"backoffice/config"
+	"backoffice/database"
+	"backoffice/middleware"
+	"backoffice/utils"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// SessionsHandler - 활성 로그인 세션 목록 페이지 핸들러
+func SessionsHandler(w http.ResponseWriter, r *http.Request) {
+	successMessage := utils.GetFlashMessage(w, r, "success")
+	errorMessage := utils.GetFlashMessage(w, r, "error")
+
+	currentPage := utils.GetCurrentPageFromRequest(r)
+	searchUserID := r.URL.Query().Get("userId")
+	idleSeconds := config.GetConfig().Session.IdleTimeout
+	itemsPerPage := 20
+
+	totalItems, err := database.GetActiveSessionsCount(searchUserID, idleSeconds)
+	if err != nil {
+		log.Printf("활성 세션 수 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	pagination := utils.CalculatePagination(currentPage, totalItems, itemsPerPage)
+
+	dbSessions, err := database.GetActiveSessions(searchUserID, idleSeconds, pagination.CurrentPage, itemsPerPage)
+	if err != nil {
+		log.Printf("활성 세션 조회 오류: %v", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	currentSessionID := middleware.GetCurrentSessionID(r)
+
+	var sessionItems []SessionItem
+	for _, s := range dbSessions {
+		sessionItems = append(sessionItems, SessionItem{
+			SessionID:    s.SessionID,
+			UserSeq:      s.UserSeq,
+			UserID:       s.UserID,
+			IPAddress:    s.IPAddress,
+			UserAgent:    s.UserAgent,
+			CreatedDate:  s.CreatedDate,
+			LastActivity: s.LastActivity,
+			ExpiresAt:    s.ExpiresAt,
+			IsCurrent:    s.SessionID == currentSessionID,
+		})
+	}
+
+	data := SessionsPageData{
+		BasePageData:   middleware.GetBasePageData(r),
+		Title:          "활성 세션",
+		ActiveMenu:     "users",
+		Sessions:       sessionItems,
+		Pagination:     pagination,
+		SearchUserID:   searchUserID,
+		IdleMinutes:    idleSeconds / 60,
+		TotalCount:     totalItems,
+		SuccessMessage: successMessage,
+		ErrorMessage:   errorMessage,
+	}
+
+	renderTemplate(w, r, "users/sessions.html", data)
+}
+
+// RevokeSessionHandler - 세션 강제 종료 핸들러 (POST)
+// 폼 값 session_id(개별 세션) 또는 user_seq(해당 사용자의 모든 세션) 중 하나 필요
+// 현재 사용 중인 본인 세션은 종료하지 않음 (로그아웃 메뉴 사용)
+func RevokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		log.Printf("허용되지 않은 HTTP 메서드: %s", r.Method)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		log.Println("Form parse error:", err)
+		http.Redirect(w, r, "/error", http.StatusSeeOther)
+		return
+	}
+
+	redirectURL := "/users/sessions"
+	if returnQuery := r.FormValue("return_query"); returnQuery != "" {
+		if _, err := url.ParseQuery(returnQuery); err == nil {
+			redirectURL += "?" + returnQuery
+		}
+	}
+
+	currentSessionID := middleware.GetCurrentSessionID(r)
+
+	if sessionID := r.FormValue("session_id"); sessionID != "" {
+		if sessionID == currentSessionID {
+			utils.SetFlashMessage(w, r, "error", "현재 사용 중인 세션은 종료할 수 없습니다.")
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+
+		if err := database.DeleteSession(sessionID); err != nil {
+			utils.SetFlashMessage(w, r, "error", "세션 종료에 실패했습니다.")
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+
+		utils.SetFlashMessage(w, r, "success", "세션을 종료했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	userSeq, err := ValidateUserSeq(r.FormValue("user_seq"))
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "종료할 세션 또는 사용자가 없습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	count, err := database.DeleteUserSessions(userSeq, currentSessionID)
+	if err != nil {
+		utils.SetFlashMessage(w, r, "error", "세션 종료에 실패했습니다.")
+		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+		return
+	}
+
+	utils.SetFlashMessage(w, r, "success", fmt.Sprintf("%d개의 세션을 종료했습니다.", count))
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
@@ -0,0 +1,82 @@
+package users
+
+import (
+	"backoffice/database"
+	"backoffice/utils"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// userIDPattern - 아이디 형식 (영문/숫자/._-, 4~50자)
+var userIDPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{4,50}$`)
+
+// ValidateUserSeq 사용자 seq 유효성 검증
+func ValidateUserSeq(idStr string) (int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("잘못된 사용자 ID: %s", idStr)
+	}
+	return id, nil
+}
+
+// ValidateRoleAndBranch 역할/소속 지점 조합 검증
+// 최고 관리자는 전체 지점 계정(nil), 그 외 역할은 소속 지점이 반드시 필요
+func ValidateRoleAndBranch(role, branchSeqStr string) (*int, error) {
+	if !database.IsValidRole(role) {
+		return nil, fmt.Errorf("올바르지 않은 역할입니다")
+	}
+
+	if role == database.RoleSuperAdmin {
+		return nil, nil
+	}
+
+	branchSeq, err := strconv.Atoi(branchSeqStr)
+	if err != nil || branchSeq <= 0 {
+		return nil, fmt.Errorf("지점 관리자와 상담원은 소속 지점을 선택해야 합니다")
+	}
+
+	return &branchSeq, nil
+}
+
+// ValidateAddUserForm 사용자 추가 폼 검증
+// password가 비어 있으면 임시 비밀번호를 발급하므로 정책 검사를 생략
+func ValidateAddUserForm(userID, password, role, branchSeqStr string) (*int, error) {
+	if !userIDPattern.MatchString(userID) {
+		return nil, fmt.Errorf("아이디는 영문, 숫자, '.', '_', '-' 조합 4~50자로 입력해주세요")
+	}
+
+	exists, err := database.UserIDExists(userID)
+	if err != nil {
+		return nil, fmt.Errorf("아이디 중복 확인에 실패했습니다")
+	}
+	if exists {
+		return nil, fmt.Errorf("이미 사용 중인 아이디입니다")
+	}
+
+	if password != "" {
+		if err := utils.ValidatePassword(password); err != nil {
+			return nil, err
+		}
+	}
+
+	return ValidateRoleAndBranch(role, branchSeqStr)
+}
+
+// ValidateSuperAdminRemains 마지막 활성 최고 관리자가 사라지지 않는지 확인
+// 대상 계정이 활성 최고 관리자이고, 수정/삭제 후 최고 관리자 권한을 잃는 경우에만 검사
+func ValidateSuperAdminRemains(target *database.UserAccount) error {
+	if target.Role != database.RoleSuperAdmin || !target.IsActive {
+		return nil
+	}
+
+	count, err := database.CountActiveSuperAdmins()
+	if err != nil {
+		return fmt.Errorf("최고 관리자 수 확인에 실패했습니다")
+	}
+	if count <= 1 {
+		return fmt.Errorf("마지막 최고 관리자 계정은 변경하거나 삭제할 수 없습니다")
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"backoffice/config"
 	"backoffice/database"
+	"backoffice/handlers/account"
+	"backoffice/handlers/audit"
 	"backoffice/handlers/board"
 	"backoffice/handlers/branches"
 	"backoffice/handlers/consultation"
@@ -16,11 +18,13 @@ import (
 	"backoffice/handlers/opens"
 	"backoffice/handlers/services"
 	"backoffice/handlers/settings"
+	"backoffice/handlers/users"
 	"backoffice/middleware"
 	"encoding/gob"
 	"html/template"
 	"log"
 	"net/http"
+	"time"
 
 	_ "backoffice/docs" // Swagger docs
 
@@ -45,6 +49,11 @@ import (
 // @in cookie
 // @name user-session
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description API 토큰 인증 ("Bearer cct_..." 형식, 계정 > API 토큰 메뉴에서 발급)
+
 func init() {
 	// 커스텀 템플릿 함수 정의
 	funcMap := template.FuncMap{
@@ -67,6 +76,9 @@ func init() {
 	templates = template.Must(templates.ParseGlob("templates/auth/*.html"))
 	templates = template.Must(templates.ParseGlob("templates/consultation/*.html"))
 	templates = template.Must(templates.ParseGlob("templates/notices/*.html"))
+	templates = template.Must(templates.ParseGlob("templates/users/*.html"))
+	templates = template.Must(templates.ParseGlob("templates/account/*.html"))
+	templates = template.Must(templates.ParseGlob("templates/audit/*.html"))
 	templates = template.Must(templates.ParseGlob("templates/error.html"))
 
 	home.Templates = templates
@@ -79,6 +91,9 @@ func init() {
 	errorhandler.Templates = templates
 	consultation.Templates = templates
 	notices.Templates = templates
+	users.Templates = templates
+	account.Templates = templates
+	audit.Templates = templates
 
 	// 공개 게시판 템플릿 (백오피스 레이아웃과 완전 분리)
 	publicFuncMap := template.FuncMap{
@@ -104,8 +119,18 @@ func main() {
 	}
 	defer database.Close()
 
-	// 세션 초기화
-	config.InitSession()
+	// 세션 초기화 (DB 기반 서버 세션) 및 만료 세션 주기적 정리
+	config.InitSession(database.NewSessionStore)
+	database.StartSessionCleanup(10*time.Minute, config.GetConfig().Session.IdleTimeout)
+
+	// 보관 기간이 지난 휴지통 고객 주기적 영구 삭제
+	database.StartCustomerTrashPurge(time.Hour, config.GetConfig().Customer.TrashRetentionDays)
+
+	// 고객이 종료 상태(예약확정, 전화상거절 등)로 바뀌면 남은 콜백 예정 자동 취소
+	database.OnCustomerStatusChange(database.CancelCallbacksOnTerminalStatus)
+
+	// 휴지통 고객이 영구 삭제되면 카카오 연결 해제 (휴지통 이동 시에는 복원될 수 있으므로 해제하지 않음)
+	database.OnKakaoCustomerPurged(board.UnlinkPurgedCustomer)
 
 	// gob 타입 등록 (세션에 복잡한 타입 저장을 위해)
 	gob.Register([]map[string]string{})
@@ -116,6 +141,7 @@ func main() {
 
 	// 공개 라우트 (인증 불필요)
 	mux.HandleFunc("/login", middleware.RecoverFunc(login.LoginHandler))                       // 로그인 처리
+	mux.HandleFunc("/login/verify", middleware.RecoverFunc(login.VerifyHandler))               // 2단계 인증 코드 확인
 
 	mux.HandleFunc("/privacy", opens.PrivacyPolicyHandler)                                         // 개인정보 처리방침
 	mux.HandleFunc("/consultation/register", middleware.RecoverFunc(consultation.RegisterHandler)) // 상담 신청 페이지
@@ -132,49 +158,104 @@ func main() {
 	mux.HandleFunc("/board/logout", middleware.RecoverFunc(board.BoardLogoutHandler))           // 게시판 로그아웃
 	mux.HandleFunc("/board/withdraw", middleware.RecoverFunc(board.WithdrawHandler))            // 회원탈퇴
 
-	// 라우트 설정 (인증 필요한 라우트는 RequireAuthRecover 미들웨어 적용)
+	// 라우트 설정 (인증 필요한 라우트는 RequireAuthRecover, 역할 제한 라우트는 RequirePermissionRecover 미들웨어 적용)
 	mux.HandleFunc("/dashboard", middleware.RequireAuthRecover(middleware.InjectBranchData(home.Handler)))                                        // 대시보드
-	mux.HandleFunc("/api/dashboard/caller-stats", middleware.RequireAuthRecover(home.GetCallerStatsAPI))                                          // CALLER별 통계 API
+	mux.HandleFunc("/api/dashboard/caller-stats", middleware.RequireAPIAuthRecover(database.APIScopeCustomersRead, home.GetCallerStatsAPI))                            // CALLER별 통계 API
 	mux.HandleFunc("/customers", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.Handler)))                                   // 고객 관리
 	mux.HandleFunc("/customers/add", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.AddHandler)))                            // 고객 추가
-	mux.HandleFunc("/api/customers/comment", middleware.RequireAuthRecover(customers.UpdateCommentHandler))                                       // 고객 코멘트 업데이트
-	mux.HandleFunc("/api/customers/process-call", middleware.RequireAuthRecover(customers.ProcessCallHandler))                                    // 통화 처리 (CALLER 선택 + 통화 횟수 증가)
-	mux.HandleFunc("/api/customers/mark-no-phone-interview", middleware.RequireAuthRecover(customers.MarkNoPhoneInterviewHandler))                // 전화상안함 처리
-	mux.HandleFunc("/api/customers/reservation", middleware.RequireAuthRecover(customers.CreateReservationHandler))                               // 예약 정보 생성
-	mux.HandleFunc("/api/customers/update-name", middleware.RequireAuthRecover(customers.UpdateCustomerNameHandler))                              // 고객 이름 업데이트
-	mux.HandleFunc("/api/customers/delete", middleware.RequireAuthRecover(customers.DeleteCustomerHandler))                                       // 고객 삭제 API
-	mux.HandleFunc("/api/integrations/check-sms", middleware.RequireAuthRecover(integrations.CheckSMSIntegrationHandler))                         // SMS 연동 상태 확인
-	mux.HandleFunc("/api/integrations/sms-senders", middleware.RequireAuthRecover(integrations.GetSMSSenderNumbersHandler))                       // SMS 발신번호 목록 조회
+	mux.HandleFunc("/customers/import", middleware.RequirePermissionRecover(middleware.PermCustomerImport, middleware.InjectBranchData(customers.ImportHandler))) // 고객 일괄 가져오기 (CSV/XLSX)
+	mux.HandleFunc("/customers/export", middleware.RequirePermissionRecover(middleware.PermCustomerExport, middleware.InjectBranchData(customers.ExportHandler))) // 고객 목록 내보내기 (CSV/XLSX)
+	mux.HandleFunc("/customers/presets/save", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.SavePresetHandler)))            // 고객 검색 조건 저장
+	mux.HandleFunc("/customers/presets/delete", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.DeletePresetHandler)))        // 고객 검색 조건 삭제
+	mux.HandleFunc("/customers/inbox", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, middleware.InjectBranchData(customers.InboxHandler))) // 미배정 고객 배정 대기함
+	mux.HandleFunc("/customers/inbox/assign", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, customers.AssignHandler))        // 미배정 고객 지점 일괄 배정
+	mux.HandleFunc("/customers/inbox/apply-rules", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, customers.ApplyRulesHandler)) // 미배정 고객에 자동 배정 규칙 적용
+	mux.HandleFunc("/customers/inbox/rules/save", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, customers.RuleSaveHandler))  // 자동 배정 규칙 추가
+	mux.HandleFunc("/customers/inbox/rules/toggle", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, customers.RuleToggleHandler)) // 자동 배정 규칙 사용/중지
+	mux.HandleFunc("/customers/inbox/rules/delete", middleware.RequirePermissionRecover(middleware.PermCustomerAssign, customers.RuleDeleteHandler)) // 자동 배정 규칙 삭제
+	mux.HandleFunc("/customers/trash", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.TrashHandler)))                        // 고객 휴지통
+	mux.HandleFunc("/customers/trash/restore", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.RestoreHandler)))              // 휴지통 고객 복원
+	mux.HandleFunc("/customers/trash/purge", middleware.RequirePermissionRecover(middleware.PermCustomerPurge, middleware.InjectBranchData(customers.PurgeHandler))) // 휴지통 고객 영구 삭제
+	mux.HandleFunc("/customers/sms-opt-outs", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.SMSOptOutsHandler)))             // 문자 수신거부 번호 관리
+	mux.HandleFunc("/customers/sms-opt-outs/add", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.SMSOptOutAddHandler)))       // 수신거부 번호 등록
+	mux.HandleFunc("/customers/sms-opt-outs/delete", middleware.RequirePermissionRecover(middleware.PermSMSOptOutManage, middleware.InjectBranchData(customers.SMSOptOutDeleteHandler))) // 수신거부 번호 해제
+	mux.HandleFunc("/customers/sms-opt-outs/import", middleware.RequirePermissionRecover(middleware.PermSMSOptOutManage, middleware.InjectBranchData(customers.SMSOptOutImportHandler))) // 080 수신거부 목록 가져오기
+	mux.HandleFunc("/customers/tags/bulk", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.BulkTagHandler)))                  // 선택 고객 태그 일괄 추가/제거
+	mux.HandleFunc("/customers/callbacks/create", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.CallbackCreateHandler)))    // 고객 콜백 등록
+	mux.HandleFunc("/customers/callbacks/complete", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.CallbackCompleteHandler))) // 고객 콜백 완료 (통화 처리 포함)
+	mux.HandleFunc("/customers/callbacks/cancel", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.CallbackCancelHandler)))    // 고객 콜백 취소
+	mux.HandleFunc("/customers/detail", middleware.RequireAuthRecover(middleware.InjectBranchData(customers.DetailHandler)))                      // 고객 상세 (활동 타임라인)
+	mux.HandleFunc("/customers/merge", middleware.RequirePermissionRecover(middleware.PermCustomerMerge, middleware.InjectBranchData(customers.MergeHandler))) // 중복 고객 병합
+	mux.HandleFunc("/api/customers/comment", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.UpdateCommentHandler))                        // 고객 코멘트 업데이트
+	mux.HandleFunc("/api/customers/notes", middleware.RequireAPIAuthRecover(database.APIScopeCustomersRead, customers.NotesHandler))                                    // 고객 메모 목록 (작성자, 수정 이력 포함)
+	mux.HandleFunc("/api/customers/notes/add", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.AddNoteHandler))                             // 고객 메모 추가
+	mux.HandleFunc("/api/customers/notes/update", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.UpdateNoteHandler))                       // 고객 메모 수정 (수정 이력 보관)
+	mux.HandleFunc("/api/customers/notes/pin", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.PinNoteHandler))                             // 고객 메모 고정/고정 해제
+	mux.HandleFunc("/api/customers/reveal-phone", middleware.RequireAPIAuthRecover(database.APIScopeCustomersRead, customers.RevealPhoneHandler))                       // 마스킹된 고객 전화번호 열람 (감사 로그 기록)
+	mux.HandleFunc("/api/customers/process-call", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.ProcessCallHandler))                     // 통화 처리 (CALLER 선택 + 통화 횟수 증가)
+	mux.HandleFunc("/api/customers/mark-no-phone-interview", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.MarkNoPhoneInterviewHandler)) // 전화상안함 처리
+	mux.HandleFunc("/api/customers/reservation", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.CreateReservationHandler))                // 예약 정보 생성
+	mux.HandleFunc("/api/customers/status/revert", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.RevertStatusHandler))                   // 고객 상태 되돌리기
+	mux.HandleFunc("/api/customers/update-name", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.UpdateCustomerNameHandler))               // 고객 이름 업데이트
+	mux.HandleFunc("/api/customers/delete", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.DeleteCustomerHandler))                        // 고객 삭제 API
+	mux.HandleFunc("/api/customers/bulk/status", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.BulkStatusHandler))                       // 선택 고객 상태 일괄 변경
+	mux.HandleFunc("/api/customers/bulk/delete", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.BulkDeleteHandler))                       // 선택 고객 일괄 삭제 (휴지통 이동)
+	mux.HandleFunc("/api/customers/bulk/transfer", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, customers.BulkTransferHandler))                   // 선택 고객 지점 일괄 이동
+	mux.HandleFunc("/api/customers/bulk/sms", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, customers.BulkSMSHandler))                                    // 선택 고객 템플릿 문자 일괄 발송
+	mux.HandleFunc("/api/integrations/check-sms", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, integrations.CheckSMSIntegrationHandler))                 // SMS 연동 상태 확인
+	mux.HandleFunc("/api/integrations/sms-senders", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, integrations.GetSMSSenderNumbersHandler))               // SMS 발신번호 목록 조회
 	mux.HandleFunc("/api/external/customers", opens.ExternalRegisterCustomerHandler)                                                              // 외부 고객 등록 API (인증 불필요)
-	mux.HandleFunc("/api/service/sms", middleware.RequireAuthRecover(services.SendSMSHandler))                                                    // SMS 메시지 전송
-	mux.HandleFunc("/api/service/reservation-sms-config", middleware.RequireAuthRecover(services.GetReservationSMSConfigHandler))                 // 예약 SMS 설정 조회
-	mux.HandleFunc("/api/message-templates", middleware.RequireAuthRecover(messagetemplates.GetTemplatesAPI))                                     // 메시지 템플릿 목록 API
-	mux.HandleFunc("/branches", middleware.RequireAuthRecover(middleware.InjectBranchData(branches.Handler)))                                     // 지점 관리
-	mux.HandleFunc("/branches/detail", middleware.RequireAuthRecover(middleware.InjectBranchData(branches.DetailHandler)))                        // 지점 상세
-	mux.HandleFunc("/branches/edit", middleware.RequireAuthRecover(middleware.InjectBranchData(branches.EditHandler)))                            // 지점 수정
-	mux.HandleFunc("/branches/add", middleware.RequireAuthRecover(middleware.InjectBranchData(branches.AddHandler)))                              // 지점 추가
-	mux.HandleFunc("/branches/delete", middleware.RequireAuthRecover(branches.DeleteHandler))                                                     // 지점 삭제
-	mux.HandleFunc("/integrations", middleware.RequireAuthRecover(middleware.InjectBranchData(integrations.Handler)))                             // 외부 시스템 연동
-	mux.HandleFunc("/integrations/kakao-sync", middleware.RequireAuthRecover(middleware.InjectBranchData(integrations.KakaoSyncHandler)))          // 카카오싱크 URL 생성
-	mux.HandleFunc("/integrations/configure", middleware.RequireAuthRecover(middleware.InjectBranchData(integrations.ConfigureHandler)))          // 연동 설정
-	mux.HandleFunc("/integrations/manage", middleware.RequireAuthRecover(middleware.InjectBranchData(integrations.ConfigureHandler)))             // 연동 관리 (설정과 동일)
-	mux.HandleFunc("/api/external/sms", middleware.RequireAuthRecover(integrations.SMSTestHandler))                                               // SMS 테스트 발송 API
-	mux.HandleFunc("/api/sms/config", middleware.RequireAuthRecover(integrations.SMSConfigSaveHandler))                                           // SMS 설정 저장 API
-	mux.HandleFunc("/api/integrations/activate", middleware.RequireAuthRecover(integrations.ActivateHandler))                                     // 연동 활성화 API
-	mux.HandleFunc("/api/integrations/disconnect", middleware.RequireAuthRecover(integrations.DisconnectHandler))                                 // 연동 해제 API
-	mux.HandleFunc("/api/calendar/create-event", middleware.RequireAuthRecover(integrations.CreateCalendarEventHandler))                          // 구글 캘린더 이벤트 생성 API
-	mux.HandleFunc("/message-templates", middleware.RequireAuthRecover(middleware.InjectBranchData(messagetemplates.Handler)))                    // 메시지 템플릿 목록
-	mux.HandleFunc("/message-templates/add", middleware.RequireAuthRecover(middleware.InjectBranchData(messagetemplates.AddHandler)))             // 메시지 템플릿 추가
-	mux.HandleFunc("/message-templates/edit", middleware.RequireAuthRecover(middleware.InjectBranchData(messagetemplates.EditHandler)))           // 메시지 템플릿 수정
-	mux.HandleFunc("/message-templates/delete", middleware.RequireAuthRecover(messagetemplates.DeleteHandler))                                    // 메시지 템플릿 삭제
-	mux.HandleFunc("/message-templates/set-default", middleware.RequireAuthRecover(messagetemplates.SetDefaultHandler))                           // 메시지 템플릿 기본값 설정
+	mux.HandleFunc("/api/service/sms", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, services.SendSMSHandler))                                            // SMS 메시지 전송
+	mux.HandleFunc("/api/service/reservation-sms-config", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, services.GetReservationSMSConfigHandler))         // 예약 SMS 설정 조회
+	mux.HandleFunc("/api/message-templates", middleware.RequireAPIAuthRecover(database.APIScopeSMSSend, messagetemplates.GetTemplatesAPI))                             // 메시지 템플릿 목록 API
+	mux.HandleFunc("/branches", middleware.RequirePermissionRecover(middleware.PermBranchManage, middleware.InjectBranchData(branches.Handler)))                                     // 지점 관리
+	mux.HandleFunc("/branches/detail", middleware.RequirePermissionRecover(middleware.PermBranchManage, middleware.InjectBranchData(branches.DetailHandler)))                        // 지점 상세
+	mux.HandleFunc("/branches/edit", middleware.RequirePermissionRecover(middleware.PermBranchManage, middleware.InjectBranchData(branches.EditHandler)))                            // 지점 수정
+	mux.HandleFunc("/branches/add", middleware.RequirePermissionRecover(middleware.PermBranchManage, middleware.InjectBranchData(branches.AddHandler)))                              // 지점 추가
+	mux.HandleFunc("/branches/delete", middleware.RequirePermissionRecover(middleware.PermBranchManage, branches.DeleteHandler))                                                     // 지점 삭제
+	mux.HandleFunc("/integrations", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, middleware.InjectBranchData(integrations.Handler)))                             // 외부 시스템 연동
+	mux.HandleFunc("/integrations/kakao-sync", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, middleware.InjectBranchData(integrations.KakaoSyncHandler)))          // 카카오싱크 URL 생성
+	mux.HandleFunc("/integrations/configure", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, middleware.InjectBranchData(integrations.ConfigureHandler)))          // 연동 설정
+	mux.HandleFunc("/integrations/manage", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, middleware.InjectBranchData(integrations.ConfigureHandler)))             // 연동 관리 (설정과 동일)
+	mux.HandleFunc("/api/external/sms", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, integrations.SMSTestHandler))                                               // SMS 테스트 발송 API
+	mux.HandleFunc("/api/sms/config", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, integrations.SMSConfigSaveHandler))                                           // SMS 설정 저장 API
+	mux.HandleFunc("/api/integrations/activate", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, integrations.ActivateHandler))                                     // 연동 활성화 API
+	mux.HandleFunc("/api/integrations/disconnect", middleware.RequirePermissionRecover(middleware.PermIntegrationManage, integrations.DisconnectHandler))                                 // 연동 해제 API
+	mux.HandleFunc("/api/calendar/create-event", middleware.RequireAPIAuthRecover(database.APIScopeCustomersWrite, integrations.CreateCalendarEventHandler))                              // 구글 캘린더 이벤트 생성 API
+	mux.HandleFunc("/message-templates", middleware.RequirePermissionRecover(middleware.PermTemplateManage, middleware.InjectBranchData(messagetemplates.Handler)))                    // 메시지 템플릿 목록
+	mux.HandleFunc("/message-templates/add", middleware.RequirePermissionRecover(middleware.PermTemplateManage, middleware.InjectBranchData(messagetemplates.AddHandler)))             // 메시지 템플릿 추가
+	mux.HandleFunc("/message-templates/edit", middleware.RequirePermissionRecover(middleware.PermTemplateManage, middleware.InjectBranchData(messagetemplates.EditHandler)))           // 메시지 템플릿 수정
+	mux.HandleFunc("/message-templates/delete", middleware.RequirePermissionRecover(middleware.PermTemplateManage, messagetemplates.DeleteHandler))                                    // 메시지 템플릿 삭제
+	mux.HandleFunc("/message-templates/set-default", middleware.RequirePermissionRecover(middleware.PermTemplateManage, messagetemplates.SetDefaultHandler))                           // 메시지 템플릿 기본값 설정
 	mux.HandleFunc("/notices", middleware.RequireAuthRecover(middleware.InjectBranchData(notices.Handler)))                                       // 공지사항/이벤트 목록
 	mux.HandleFunc("/notices/detail", middleware.RequireAuthRecover(middleware.InjectBranchData(notices.DetailHandler)))                          // 공지사항/이벤트 상세
-	mux.HandleFunc("/notices/add", middleware.RequireAuthRecover(middleware.InjectBranchData(notices.AddHandler)))                                // 공지사항/이벤트 등록
-	mux.HandleFunc("/notices/edit", middleware.RequireAuthRecover(middleware.InjectBranchData(notices.EditHandler)))                              // 공지사항/이벤트 수정
-	mux.HandleFunc("/notices/delete", middleware.RequireAuthRecover(notices.DeleteHandler))                                                       // 공지사항/이벤트 삭제
-	mux.HandleFunc("/settings", middleware.RequireAuthRecover(middleware.InjectBranchData(settings.Handler)))                                     // 설정 메인 페이지
-	mux.HandleFunc("/settings/reservation-sms", middleware.RequireAuthRecover(middleware.InjectBranchData(settings.ReservationSMSConfigHandler))) // 예약 SMS 설정
+	mux.HandleFunc("/notices/add", middleware.RequirePermissionRecover(middleware.PermNoticeManage, middleware.InjectBranchData(notices.AddHandler)))                                // 공지사항/이벤트 등록
+	mux.HandleFunc("/notices/edit", middleware.RequirePermissionRecover(middleware.PermNoticeManage, middleware.InjectBranchData(notices.EditHandler)))                              // 공지사항/이벤트 수정
+	mux.HandleFunc("/notices/delete", middleware.RequirePermissionRecover(middleware.PermNoticeManage, notices.DeleteHandler))                                                       // 공지사항/이벤트 삭제
+	mux.HandleFunc("/settings", middleware.RequirePermissionRecover(middleware.PermSettingsManage, middleware.InjectBranchData(settings.Handler)))                                     // 설정 메인 페이지
+	mux.HandleFunc("/settings/reservation-sms", middleware.RequirePermissionRecover(middleware.PermSettingsManage, middleware.InjectBranchData(settings.ReservationSMSConfigHandler))) // 예약 SMS 설정
+	mux.HandleFunc("/settings/customers", middleware.RequirePermissionRecover(middleware.PermSettingsManage, middleware.InjectBranchData(settings.CustomerSettingsHandler)))           // 고객 관리 설정 (통화 횟수 제한, 고객 상태, 태그)
+	mux.HandleFunc("/settings/customer-statuses/save", middleware.RequirePermissionRecover(middleware.PermCustomerStatusManage, settings.SaveCustomerStatusHandler))                   // 고객 상태 추가/수정
+	mux.HandleFunc("/settings/customer-statuses/delete", middleware.RequirePermissionRecover(middleware.PermCustomerStatusManage, settings.DeleteCustomerStatusHandler))               // 고객 상태 삭제
+	mux.HandleFunc("/settings/customer-tags/save", middleware.RequirePermissionRecover(middleware.PermSettingsManage, middleware.InjectBranchData(settings.SaveCustomerTagHandler)))   // 지점 고객 태그 추가/수정
+	mux.HandleFunc("/settings/customer-tags/delete", middleware.RequirePermissionRecover(middleware.PermSettingsManage, middleware.InjectBranchData(settings.DeleteCustomerTagHandler))) // 지점 고객 태그 삭제
+	mux.HandleFunc("/users", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.Handler)))                                               // 사용자 관리
+	mux.HandleFunc("/users/add", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.AddHandler)))                                        // 사용자 추가
+	mux.HandleFunc("/users/edit", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.EditHandler)))                                      // 사용자 수정 (역할/지점/활성 여부)
+	mux.HandleFunc("/users/reset-password", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.ResetPasswordHandler)))                   // 사용자 비밀번호 초기화
+	mux.HandleFunc("/users/reset-2fa", middleware.RequirePermissionRecover(middleware.PermUserManage, users.ResetTwoFactorHandler))                                                    // 사용자 2단계 인증 초기화
+	mux.HandleFunc("/users/delete", middleware.RequirePermissionRecover(middleware.PermUserManage, users.DeleteHandler))                                                              // 사용자 삭제
+	mux.HandleFunc("/users/login-attempts", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.LoginAttemptsHandler)))                   // 로그인 시도 이력 (실패/잠금)
+	mux.HandleFunc("/users/login-attempts/unlock", middleware.RequirePermissionRecover(middleware.PermUserManage, users.UnlockLoginHandler))                                          // 계정/IP 로그인 잠금 해제
+	mux.HandleFunc("/users/sessions", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.SessionsHandler)))                               // 활성 로그인 세션 목록
+	mux.HandleFunc("/users/sessions/revoke", middleware.RequirePermissionRecover(middleware.PermUserManage, users.RevokeSessionHandler))                                              // 세션 강제 종료 (개별/사용자 전체)
+	mux.HandleFunc("/users/api-tokens", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.APITokensHandler)))                          // API 토큰 목록 (전체/사용자별)
+	mux.HandleFunc("/users/api-tokens/issue", middleware.RequirePermissionRecover(middleware.PermUserManage, middleware.InjectBranchData(users.IssueAPITokenHandler)))                // 사용자 소유 API 토큰 발급 (서비스 계정)
+	mux.HandleFunc("/users/api-tokens/revoke", middleware.RequirePermissionRecover(middleware.PermUserManage, users.RevokeAPITokenHandler))                                           // API 토큰 폐기
+	mux.HandleFunc("/account/password", middleware.RequireAuthRecover(middleware.InjectBranchData(account.PasswordHandler)))                                                          // 본인 비밀번호 변경
+	mux.HandleFunc("/account/2fa", middleware.RequireAuthRecover(middleware.InjectBranchData(account.TwoFactorHandler)))                                                              // 2단계 인증 설정 (TOTP 등록/해제, 복구 코드)
+	mux.HandleFunc("/account/tokens", middleware.RequireAuthRecover(middleware.InjectBranchData(account.APITokensHandler)))                                                           // 본인 API 토큰 발급/폐기
+	mux.HandleFunc("/audit", middleware.RequirePermissionRecover(middleware.PermAuditView, middleware.InjectBranchData(audit.Handler)))                                               // 감사 로그 (최고 관리자)
 	mux.HandleFunc("/logout", middleware.RequireAuthRecover(login.LogoutHandler))                                                                 // 로그아웃 처리
 	mux.HandleFunc("/error", middleware.RecoverFunc(errorhandler.Handler404))                                                                     // 에러 페이지
 
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"backoffice/database"
+	"backoffice/utils"
+	"context"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strings"
+)
+
+// apiBranchKey - API 토큰 요청의 지점 seq (세션 대신 GetSelectedBranch에서 사용)
+const apiBranchKey contextKey = "apiBranch"
+
+// apiScopePermissions - API 토큰 범위별로 소유자 역할에 필요한 권한
+// 토큰 발급 후 역할이 바뀌어도 현재 역할 권한을 넘지 못하도록 요청마다 확인
+var apiScopePermissions = map[string]Permission{
+	database.APIScopeCustomersRead:  PermCustomerManage,
+	database.APIScopeCustomersWrite: PermCustomerManage,
+	database.APIScopeSMSSend:        PermCustomerManage,
+}
+
+// CanUseAPIScope - 역할이 해당 API 토큰 범위를 사용할 수 있는지 확인 (발급 시 범위 선택에도 사용)
+func CanUseAPIScope(role, scope string) bool {
+	perm, ok := apiScopePermissions[scope]
+	return ok && HasPermission(role, perm)
+}
+
+// RequireAPIAuthRecover - /api 라우트용 인증 + Panic Recovery 미들웨어 (RequireAuthRecover 대체)
+// Authorization: Bearer 헤더가 있으면 API 토큰으로 인증하고 범위(scope)를 확인
+// 헤더가 없으면 기존과 같이 세션 인증 + CSRF 검증
+func RequireAPIAuthRecover(scope string, next http.HandlerFunc) http.HandlerFunc {
+	sessionHandler := RequireAuthRecover(next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			sessionHandler(w, r)
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("PANIC: %v\n%s", err, debug.Stack())
+				utils.JSONError(w, http.StatusInternalServerError, "서버 내부 오류가 발생했습니다")
+			}
+		}()
+
+		authenticateAPIToken(w, r, token, scope, next)
+	}
+}
+
+// bearerToken - Authorization 헤더에서 Bearer 토큰 추출
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, token != ""
+}
+
+// authenticateAPIToken - API 토큰 검증 후 세션 로그인과 같은 사용자/지점 context로 핸들러 실행
+func authenticateAPIToken(w http.ResponseWriter, r *http.Request, rawToken, scope string, next http.HandlerFunc) {
+	clientIP := utils.GetClientIP(r)
+
+	token, err := database.GetActiveAPITokenByHash(database.HashAPIToken(rawToken))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "토큰 확인 중 오류가 발생했습니다")
+		return
+	}
+	if token == nil {
+		log.Printf("유효하지 않은 API 토큰 사용: %s %s, IP: %s", r.Method, r.URL.Path, clientIP)
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		utils.JSONError(w, http.StatusUnauthorized, "유효하지 않거나 만료된 API 토큰입니다")
+		return
+	}
+
+	user, err := database.GetUserBySeq(token.UserSeq)
+	if err != nil || user == nil || !user.IsActive {
+		log.Printf("비활성 계정의 API 토큰 사용: token=%d, %s %s", token.Seq, r.Method, r.URL.Path)
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		utils.JSONError(w, http.StatusUnauthorized, "사용할 수 없는 계정의 API 토큰입니다")
+		return
+	}
+
+	if user.MustChangePassword {
+		utils.JSONError(w, http.StatusForbidden, "비밀번호 변경이 필요합니다")
+		return
+	}
+
+	if !token.HasScope(scope) || !CanUseAPIScope(user.Role, scope) {
+		log.Printf("API 토큰 범위 부족: token=%d, user=%s, scope=%s, %s %s", token.Seq, user.UserID, scope, r.Method, r.URL.Path)
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+		utils.JSONError(w, http.StatusForbidden, "API 토큰에 필요한 권한("+scope+")이 없습니다")
+		return
+	}
+
+	database.TouchAPIToken(token.Seq, clientIP)
+
+	// 지점 소속 계정은 GetSelectedBranch가 소속 지점을 사용하므로 토큰 지점은 전체 지점 계정에만 적용
+	ctx := context.WithValue(r.Context(), currentUserKey, user)
+	if token.BranchSeq.Valid {
+		ctx = context.WithValue(ctx, apiBranchKey, int(token.BranchSeq.Int64))
+	}
+
+	next(w, r.WithContext(ctx))
+}
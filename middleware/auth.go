@@ -2,12 +2,20 @@ package middleware
 
 import (
 	"backoffice/config"
+	"backoffice/database"
+	"backoffice/utils"
+	"context"
 	"log"
 	"net/http"
+	"strings"
 )
 
+// PasswordChangePath - 비밀번호 변경 강제 시 이동할 페이지
+const PasswordChangePath = "/account/password"
+
 // RequireAuth - 인증 필요 미들웨어
 // 세션이 없으면 로그인 페이지로 리다이렉트
+// 비활성화된 계정은 세션을 끊고, 비밀번호 변경이 필요한 계정은 변경 페이지로 보냄
 func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// 세션 확인
@@ -27,12 +35,95 @@ func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// 계정 상태 확인 (로그인 이후 비활성화/삭제된 계정 차단)
+		user := GetCurrentUser(r)
+		if user == nil || !user.IsActive {
+			log.Printf("비활성화되었거나 존재하지 않는 계정의 접근: %v, %s %s", session.Values["user_id"], r.Method, r.URL.Path)
+			session.Options.MaxAge = -1
+			session.Save(r, w)
+			http.Redirect(w, r, "/login?error=account_disabled", http.StatusSeeOther)
+			return
+		}
+
+		// 비밀번호 변경이 필요한 계정은 변경 페이지와 로그아웃만 허용
+		if user.MustChangePassword && r.URL.Path != PasswordChangePath && r.URL.Path != "/logout" {
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				utils.JSONError(w, http.StatusForbidden, "비밀번호 변경이 필요합니다")
+				return
+			}
+			http.Redirect(w, r, PasswordChangePath, http.StatusSeeOther)
+			return
+		}
+
 		// 인증된 경우 다음 핸들러 실행
 		next(w, r)
 	}
 }
 
-// RequireAuthRecover - 인증 + Panic Recovery 미들웨어
+// RequireAuthRecover - 인증 + CSRF 검증 + Panic Recovery 미들웨어
 func RequireAuthRecover(next http.HandlerFunc) http.HandlerFunc {
-	return RecoverFunc(RequireAuth(next))
+	return RecoverFunc(RequireAuth(VerifyCSRF(next)))
+}
+
+// GetCurrentUser - 현재 로그인한 사용자 조회
+// RecoverFunc에서 요청 context에 미리 로드한 사용자를 우선 사용하고, 없으면 세션의 user_seq로 DB 조회
+// 로그인하지 않았거나 사용자가 없으면 nil 반환
+func GetCurrentUser(r *http.Request) *database.User {
+	if user, ok := r.Context().Value(currentUserKey).(*database.User); ok {
+		return user
+	}
+	return loadUserFromSession(r)
+}
+
+// loadUserFromSession - 세션의 user_seq로 사용자를 DB에서 조회
+func loadUserFromSession(r *http.Request) *database.User {
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return nil
+	}
+
+	userSeq, ok := session.Values["user_seq"].(int)
+	if !ok || userSeq <= 0 {
+		return nil
+	}
+
+	user, err := database.GetUserBySeq(userSeq)
+	if err != nil {
+		return nil
+	}
+
+	return user
+}
+
+// GetCurrentSessionID - 현재 요청의 로그인 세션 ID 반환 (없으면 빈 문자열)
+// 본인 세션을 제외하고 강제 종료하거나 목록에서 현재 세션을 표시할 때 사용
+func GetCurrentSessionID(r *http.Request) string {
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return ""
+	}
+	return session.ID
+}
+
+// GetAuditActor - 감사 로그 기록용 작업자 정보 (로그인 사용자 + 클라이언트 IP)
+func GetAuditActor(r *http.Request) database.AuditActor {
+	actor := database.AuditActor{IPAddress: utils.GetClientIP(r)}
+	if user := GetCurrentUser(r); user != nil {
+		actor.UserSeq = user.Seq
+		actor.UserID = user.UserID
+	}
+	return actor
+}
+
+// withCurrentUser - 로그인 사용자를 요청 context에 한 번만 로드
+// 지점 목록/선택 지점 헬퍼가 요청마다 여러 번 호출되므로 DB 조회를 줄이기 위함
+func withCurrentUser(r *http.Request) *http.Request {
+	if _, ok := r.Context().Value(currentUserKey).(*database.User); ok {
+		return r
+	}
+	user := loadUserFromSession(r)
+	if user == nil {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), currentUserKey, user))
 }
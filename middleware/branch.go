@@ -15,6 +15,7 @@ type contextKey string
 const (
 	branchListKey     contextKey = "branchList"
 	selectedBranchKey contextKey = "selectedBranch"
+	currentUserKey    contextKey = "currentUser"
 )
 
 // BasePageData - 모든 페이지에 공통으로 필요한 데이터
@@ -25,6 +26,16 @@ type BasePageData struct {
 	SelectedBranchManager    string // 담당자
 	SelectedBranchAddress    string // 주소
 	SelectedBranchDirections string // 오시는 길
+	UserID                   string // 로그인 사용자 아이디
+	UserRole                 string // 로그인 사용자 역할
+	UserRoleName             string // 역할 표시 이름
+	CSRFToken                string // 폼/fetch 요청 검증용 CSRF 토큰
+}
+
+// Can - 템플릿에서 권한별 메뉴/버튼 표시 여부 확인용
+// 사용 예: {{if .Can "branches:manage"}} ... {{end}}
+func (d BasePageData) Can(perm string) bool {
+	return HasPermission(d.UserRole, Permission(perm))
 }
 
 // GetBasePageData - context에서 공통 데이터를 가져오는 헬퍼 함수
@@ -35,15 +46,25 @@ func GetBasePageData(r *http.Request) BasePageData {
 	selectedBranchManager, _ := r.Context().Value(contextKey("selectedBranchManager")).(string)
 	selectedBranchAddress, _ := r.Context().Value(contextKey("selectedBranchAddress")).(string)
 	selectedBranchDirections, _ := r.Context().Value(contextKey("selectedBranchDirections")).(string)
+	currentUser := GetCurrentUser(r)
 
-	return BasePageData{
+	data := BasePageData{
 		BranchList:               branchList,
 		SelectedBranch:           selectedBranch,           // 헤더 표시용 alias
 		SelectedBranchName:       selectedBranchName,       // 한글 이름
 		SelectedBranchManager:    selectedBranchManager,    // 담당자
 		SelectedBranchAddress:    selectedBranchAddress,    // 주소
 		SelectedBranchDirections: selectedBranchDirections, // 오시는 길
+		CSRFToken:                GetCSRFToken(r),
+	}
+
+	if currentUser != nil {
+		data.UserID = currentUser.UserID
+		data.UserRole = currentUser.Role
+		data.UserRoleName = database.RoleDisplayNames[currentUser.Role]
 	}
+
+	return data
 }
 
 // InjectBranchData - context에 지점 데이터를 주입하는 미들웨어
@@ -77,8 +98,9 @@ func BranchSession(next http.HandlerFunc) http.HandlerFunc {
 		branchParam := r.URL.Query().Get("branch")
 
 		if branchParam != "" {
-			// alias로 seq를 찾아서 저장
+			// alias로 seq를 찾아서 저장 (사용자가 접근 가능한 지점 목록 안에서만)
 			branchList := GetBranchList(r)
+			found := false
 			for _, branch := range branchList {
 				if branch["alias"] == branchParam {
 					// 세션에 seq 저장
@@ -86,9 +108,13 @@ func BranchSession(next http.HandlerFunc) http.HandlerFunc {
 					session.Values["selectedBranch"] = branch["seq"]
 					session.Save(r, w)
 					log.Printf("지점 변경: %s (seq: %s)", branchParam, branch["seq"])
+					found = true
 					break
 				}
 			}
+			if !found {
+				log.Printf("지점 변경 거부 - 접근 불가 또는 존재하지 않는 지점: %s (%s)", branchParam, r.URL.Path)
+			}
 		}
 
 		// 다음 핸들러 호출
@@ -97,7 +123,17 @@ func BranchSession(next http.HandlerFunc) http.HandlerFunc {
 }
 
 // GetSelectedBranch - 세션에서 선택된 지점 코드(seq)를 가져오는 헬퍼 함수
+// 지점 소속 사용자(user_info.branch_seq가 있는 계정)는 세션 값과 무관하게 항상 소속 지점을 반환
 func GetSelectedBranch(r *http.Request) int {
+	if user := GetCurrentUser(r); user != nil && user.BranchSeq.Valid {
+		return int(user.BranchSeq.Int64)
+	}
+
+	// API 토큰 요청은 세션 대신 토큰에 지정된 지점 사용
+	if branchSeq, ok := r.Context().Value(apiBranchKey).(int); ok {
+		return branchSeq
+	}
+
 	session, _ := config.SessionStore.Get(r, "app-session")
 	if branchSeq, ok := session.Values["selectedBranch"].(string); ok && branchSeq != "" {
 		// string을 int로 변환
@@ -111,6 +147,19 @@ func GetSelectedBranch(r *http.Request) int {
 	return 0
 }
 
+// CanAccessBranch - 현재 사용자가 해당 지점 데이터에 접근 가능한지 확인
+// 전체 지점 계정(branch_seq NULL)은 모든 지점 접근 가능, 지점 소속 계정은 소속 지점만 가능
+func CanAccessBranch(r *http.Request, branchSeq int) bool {
+	user := GetCurrentUser(r)
+	if user == nil {
+		return false
+	}
+	if !user.BranchSeq.Valid {
+		return true
+	}
+	return int(user.BranchSeq.Int64) == branchSeq
+}
+
 // GetSelectedBranchAlias - 세션에서 선택된 지점의 alias를 가져오는 헬퍼 함수 (헤더 표시용)
 func GetSelectedBranchAlias(r *http.Request) string {
 	branchSeq := GetSelectedBranch(r)
@@ -205,6 +254,7 @@ func GetSelectedBranchDirections(r *http.Request) string {
 }
 
 // GetBranchList - DB에서 지점 목록을 직접 가져오기 (매 요청마다 최신 데이터)
+// 지점 소속 사용자는 소속 지점만 포함된 목록을 반환
 func GetBranchList(r *http.Request) []map[string]string {
 	branchList, err := database.GetBranchesForSelect()
 	if err != nil {
@@ -212,5 +262,22 @@ func GetBranchList(r *http.Request) []map[string]string {
 		return []map[string]string{}
 	}
 
-	return branchList
+	user := GetCurrentUser(r)
+	if user == nil || !user.BranchSeq.Valid {
+		return branchList
+	}
+
+	return filterBranchList(branchList, int(user.BranchSeq.Int64))
+}
+
+// filterBranchList - 지점 목록에서 특정 seq의 지점만 남김
+func filterBranchList(branchList []map[string]string, branchSeq int) []map[string]string {
+	seqStr := fmt.Sprintf("%d", branchSeq)
+	filtered := []map[string]string{}
+	for _, branch := range branchList {
+		if branch["seq"] == seqStr {
+			filtered = append(filtered, branch)
+		}
+	}
+	return filtered
 }
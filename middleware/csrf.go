@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"backoffice/config"
+	"backoffice/handlers/errorhandler"
+	"backoffice/utils"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	// CSRFFieldName - 폼 전송 시 CSRF 토큰 hidden 필드 이름
+	CSRFFieldName = "csrf_token"
+	// CSRFHeaderName - fetch 요청 시 CSRF 토큰 헤더 이름
+	CSRFHeaderName = "X-CSRF-Token"
+
+	csrfSessionKey = "csrf_token"
+)
+
+// VerifyCSRF - CSRF 토큰 검증 미들웨어
+// GET/HEAD/OPTIONS 요청은 세션에 토큰이 없으면 발급만 하고 통과
+// 그 외 요청은 X-CSRF-Token 헤더 또는 csrf_token 폼 값이 세션 토큰과 일치해야 함
+func VerifyCSRF(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ensureCSRFToken(w, r)
+			next(w, r)
+			return
+		}
+
+		expected := GetCSRFToken(r)
+		submitted := r.Header.Get(CSRFHeaderName)
+		if submitted == "" {
+			submitted = r.PostFormValue(CSRFFieldName)
+		}
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(submitted)) != 1 {
+			log.Printf("CSRF 토큰 검증 실패: %s %s (토큰 전달 여부: %v)", r.Method, r.URL.Path, submitted != "")
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				utils.JSONError(w, http.StatusForbidden, "요청 검증에 실패했습니다. 페이지를 새로고침한 후 다시 시도해주세요")
+				return
+			}
+			errorhandler.ShowError(w, http.StatusForbidden,
+				"요청을 처리할 수 없습니다",
+				"요청 검증에 실패했습니다.",
+				"페이지를 새로고침한 후 다시 시도해주세요.")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// GetCSRFToken - 현재 로그인 세션의 CSRF 토큰 반환 (없으면 빈 문자열)
+func GetCSRFToken(r *http.Request) string {
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return ""
+	}
+	token, _ := session.Values[csrfSessionKey].(string)
+	return token
+}
+
+// ensureCSRFToken - 세션에 CSRF 토큰이 없으면 새로 발급해 저장
+// 세션이 유지되는 동안 같은 토큰을 사용 (로그아웃/세션 만료 시 함께 폐기)
+func ensureCSRFToken(w http.ResponseWriter, r *http.Request) {
+	if GetCSRFToken(r) != "" {
+		return
+	}
+
+	session, err := config.SessionStore.Get(r, "user-session")
+	if err != nil {
+		return
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("CSRF 토큰 생성 실패: %v", err)
+		return
+	}
+
+	session.Values[csrfSessionKey] = base64.RawURLEncoding.EncodeToString(b)
+	if err := session.Save(r, w); err != nil {
+		log.Printf("CSRF 토큰 저장 실패: %v", err)
+	}
+}
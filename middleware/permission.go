@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"backoffice/database"
+	"backoffice/handlers/errorhandler"
+	"backoffice/utils"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Permission - 기능 단위 권한
+type Permission string
+
+const (
+	PermCustomerManage       Permission = "customers:manage"         // 고객 목록 조회/통화 처리/예약/SMS 발송
+	PermCustomerImport       Permission = "customers:import"         // 고객 일괄 가져오기 (CSV/XLSX)
+	PermCustomerExport       Permission = "customers:export"         // 고객 목록 파일 내보내기 (개인정보 반출)
+	PermCustomerPhoneView    Permission = "customers:phone_view"     // 고객 전화번호 전체 보기 (없으면 010-****-5678로 마스킹)
+	PermCustomerAssign       Permission = "customers:assign"         // 미배정 고객 지점 배정 및 자동 배정 규칙 관리
+	PermCustomerPurge        Permission = "customers:purge"          // 휴지통 고객 영구 삭제
+	PermCustomerMerge        Permission = "customers:merge"          // 중복 고객 병합 (병합된 고객은 휴지통으로 이동)
+	PermBranchManage         Permission = "branches:manage"          // 지점 추가/수정/삭제
+	PermIntegrationManage    Permission = "integrations:manage"      // 외부 연동 및 마이문자 계정 설정
+	PermTemplateManage       Permission = "templates:manage"         // 메시지 템플릿 관리
+	PermNoticeManage         Permission = "notices:manage"           // 공지사항/이벤트 등록/수정/삭제
+	PermSettingsManage       Permission = "settings:manage"          // 지점 설정 (예약 SMS, 통화 횟수 제한 등)
+	PermSMSOptOutManage      Permission = "sms_opt_outs:manage"      // 문자 수신거부 해제 및 080 수신거부 목록 가져오기 (등록은 모든 직원 가능)
+	PermCustomerStatusManage Permission = "customer_statuses:manage" // 고객 상태 목록 관리 (전 지점 공통)
+	PermUserManage           Permission = "users:manage"             // 백오피스 사용자 계정 관리
+	PermAuditView            Permission = "audit:view"               // 감사 로그 조회
+)
+
+// rolePermissions - 역할별 허용 권한
+var rolePermissions = map[string][]Permission{
+	database.RoleSuperAdmin: {
+		PermCustomerManage,
+		PermCustomerImport,
+		PermCustomerExport,
+		PermCustomerPhoneView,
+		PermCustomerAssign,
+		PermCustomerPurge,
+		PermCustomerMerge,
+		PermBranchManage,
+		PermIntegrationManage,
+		PermTemplateManage,
+		PermNoticeManage,
+		PermSettingsManage,
+		PermSMSOptOutManage,
+		PermCustomerStatusManage,
+		PermUserManage,
+		PermAuditView,
+	},
+	database.RoleBranchManager: {
+		PermCustomerManage,
+		PermCustomerImport,
+		PermCustomerExport,
+		PermCustomerPhoneView,
+		PermCustomerPurge,
+		PermCustomerMerge,
+		PermIntegrationManage,
+		PermTemplateManage,
+		PermNoticeManage,
+		PermSettingsManage,
+		PermSMSOptOutManage,
+	},
+	database.RoleCaller: {
+		PermCustomerManage,
+	},
+}
+
+// HasPermission - 역할이 해당 권한을 가지고 있는지 확인
+func HasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission - 권한 확인 미들웨어 (RequireAuth 뒤에 적용)
+// 권한이 없으면 페이지 요청은 403 페이지, /api 요청은 403 JSON 응답
+func RequirePermission(perm Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := GetCurrentUser(r)
+		if user == nil {
+			http.Redirect(w, r, "/login?error=unauthorized", http.StatusSeeOther)
+			return
+		}
+
+		if !HasPermission(user.Role, perm) {
+			log.Printf("권한 없는 접근 시도: user=%s, role=%s, perm=%s, %s %s", user.UserID, user.Role, perm, r.Method, r.URL.Path)
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				utils.JSONError(w, http.StatusForbidden, "권한이 없습니다")
+				return
+			}
+			errorhandler.Handler403(w, r)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// RequirePermissionRecover - 인증 + 권한 확인 + Panic Recovery 미들웨어
+func RequirePermissionRecover(perm Permission, next http.HandlerFunc) http.HandlerFunc {
+	return RequireAuthRecover(RequirePermission(perm, next))
+}
@@ -50,7 +50,7 @@ func RecoverFunc(handler http.HandlerFunc) http.HandlerFunc {
 			}
 		}()
 
-		// 지점 세션 처리 → 지점 데이터 주입 → 핸들러 실행 순서로 적용
-		BranchSession(InjectBranchData(handler))(w, r)
+		// 로그인 사용자 로드 → 지점 세션 처리 → 지점 데이터 주입 → 핸들러 실행 순서로 적용
+		BranchSession(InjectBranchData(handler))(w, withCurrentUser(r))
 	}
 }
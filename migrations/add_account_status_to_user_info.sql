@@ -0,0 +1,7 @@
+-- user_info 테이블에 계정 상태 컬럼 추가
+-- is_active: 비활성화된 계정은 로그인 불가 (기존 세션도 다음 요청 시 차단)
+-- must_change_password: 신규 계정/비밀번호 초기화 시 다음 로그인에서 비밀번호 변경 강제
+
+ALTER TABLE user_info
+ADD COLUMN is_active tinyint(1) NOT NULL DEFAULT 1 COMMENT '계정 활성 여부' AFTER user_password,
+ADD COLUMN must_change_password tinyint(1) NOT NULL DEFAULT 0 COMMENT '다음 로그인 시 비밀번호 변경 필요' AFTER is_active;
@@ -0,0 +1,26 @@
+-- API 토큰 테이블 생성
+-- 내부 스크립트가 세션 쿠키 없이 /api 라우트를 호출할 때 사용 (Authorization: Bearer 헤더)
+-- 토큰 원문은 발급 시 한 번만 보여주고 SHA-256 해시만 저장
+-- scopes: 쉼표 구분 (customers:read, customers:write, sms:send)
+-- branch_seq: 토큰 요청에 사용할 지점 (지점 소속 계정은 항상 소속 지점 사용)
+
+CREATE TABLE IF NOT EXISTS `api_tokens` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_seq` int(10) unsigned NOT NULL COMMENT '토큰 소유자 (user_info.seq, 토큰은 이 사용자 권한으로 동작)',
+  `name` varchar(100) NOT NULL COMMENT '토큰 이름 (용도)',
+  `token_prefix` varchar(16) NOT NULL COMMENT '토큰 앞부분 (목록 식별용)',
+  `token_hash` char(64) NOT NULL COMMENT '토큰 SHA-256 해시',
+  `scopes` varchar(255) NOT NULL COMMENT '허용 범위 (쉼표 구분)',
+  `branch_seq` int(10) unsigned DEFAULT NULL COMMENT '요청에 사용할 지점',
+  `expires_at` datetime DEFAULT NULL COMMENT '만료 일시 (NULL이면 만료 없음)',
+  `last_used_at` datetime DEFAULT NULL COMMENT '마지막 사용 일시',
+  `last_used_ip` varchar(45) DEFAULT NULL COMMENT '마지막 사용 IP',
+  `revoked_at` datetime DEFAULT NULL COMMENT '폐기 일시',
+  `created_by` varchar(100) DEFAULT NULL COMMENT '발급한 사용자 아이디',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '발급 일시',
+  PRIMARY KEY (`seq`),
+  UNIQUE KEY `api_tokens_token_hash_unique` (`token_hash`),
+  KEY `api_tokens_user_seq_IDX` (`user_seq`) USING BTREE,
+  CONSTRAINT `api_tokens_user_info_FK` FOREIGN KEY (`user_seq`) REFERENCES `user_info` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `api_tokens_branches_FK` FOREIGN KEY (`branch_seq`) REFERENCES `branches` (`seq`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API 토큰';
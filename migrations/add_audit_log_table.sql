@@ -0,0 +1,21 @@
+-- 감사 로그 테이블 생성
+-- 고객 삭제/수정, SMS 발송, 연동 설정 변경 등 백오피스의 변경 작업을 누가 언제 했는지 기록 (database/audit.go)
+-- before_data / after_data: 변경 전/후 값 (JSON 문자열, 비밀번호 등 민감 정보는 저장하지 않음)
+
+CREATE TABLE IF NOT EXISTS `audit_log` (
+  `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '작업자 (user_info.seq)',
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '작업자 아이디 (계정 삭제 후에도 조회 가능하도록 보관)',
+  `action` varchar(50) NOT NULL COMMENT '작업 종류 (customer.delete 등)',
+  `entity_type` varchar(50) NOT NULL COMMENT '대상 종류 (customer, branch 등)',
+  `entity_id` varchar(50) NOT NULL DEFAULT '' COMMENT '대상 ID',
+  `before_data` longtext DEFAULT NULL COMMENT '변경 전 값 (JSON)',
+  `after_data` longtext DEFAULT NULL COMMENT '변경 후 값 (JSON)',
+  `ip_address` varchar(45) NOT NULL DEFAULT '' COMMENT '작업자 IP',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '작업 일시',
+  PRIMARY KEY (`seq`),
+  KEY `audit_log_user_seq_IDX` (`user_seq`, `createdDate`) USING BTREE,
+  KEY `audit_log_entity_IDX` (`entity_type`, `entity_id`) USING BTREE,
+  KEY `audit_log_action_IDX` (`action`) USING BTREE,
+  KEY `audit_log_createdDate_IDX` (`createdDate`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='감사 로그';
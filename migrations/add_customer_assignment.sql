@@ -0,0 +1,24 @@
+-- 미배정 고객 지점 배정 (상담신청 등 branch_seq가 NULL인 고객)
+-- customers.region: 상담신청 시 입력한 지역 (지역 배정 규칙에 사용)
+-- customer_assignment_rules: 자동 배정 규칙 (region → ad_source → round_robin 순서로 적용, 같은 종류는 priority 오름차순)
+--   region: match_value가 고객 지역에 포함되면 배정
+--   ad_source: match_value가 고객 광고 출처와 같으면 배정
+--   round_robin: 지역/광고 출처 규칙에 맞지 않는 고객을 규칙 지점에 번갈아 배정 (last_assigned_at이 가장 오래된 지점부터)
+
+ALTER TABLE customers
+ADD COLUMN region varchar(50) NULL COMMENT '상담신청 시 입력한 지역'
+AFTER ad_source;
+
+CREATE TABLE IF NOT EXISTS `customer_assignment_rules` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `rule_type` varchar(20) NOT NULL COMMENT '규칙 종류 (region/ad_source/round_robin)',
+  `match_value` varchar(100) NOT NULL DEFAULT '' COMMENT '비교 값 (round_robin은 빈 문자열)',
+  `branch_seq` int(10) unsigned NOT NULL COMMENT '배정할 지점',
+  `priority` int(10) NOT NULL DEFAULT 0 COMMENT '같은 종류 규칙 간 적용 순서 (작을수록 먼저)',
+  `is_active` tinyint(1) NOT NULL DEFAULT 1 COMMENT '사용 여부',
+  `last_assigned_at` datetime(6) NULL COMMENT '마지막 배정 일시 (round_robin 순서 결정)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`seq`),
+  KEY `customer_assignment_rules_type_idx` (`rule_type`, `is_active`, `priority`),
+  CONSTRAINT `customer_assignment_rules_branches_FK` FOREIGN KEY (`branch_seq`) REFERENCES `branches` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='미배정 고객 자동 배정 규칙';
@@ -0,0 +1,22 @@
+-- 고객 콜백 예정 (예: "내일 오후 6시 이후 연락 요청")
+-- customer_callbacks: 고객별 콜백 예정 일시, 담당자, 메모
+--   완료 시 completed_at/completed_by/completed_caller 기록 (완료와 함께 통화 처리: CALLER 이력 + 통화 횟수 증가)
+--   지점은 고객의 지점(customers.branch_seq)을 따르므로 별도로 저장하지 않음
+
+CREATE TABLE IF NOT EXISTS `customer_callbacks` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `customer_seq` int(10) unsigned NOT NULL,
+  `due_at` datetime NOT NULL COMMENT '콜백 예정 일시',
+  `owner_user_seq` int(10) unsigned NULL COMMENT '담당자 (NULL이면 담당자 없음)',
+  `note` varchar(200) NOT NULL DEFAULT '' COMMENT '메모',
+  `created_by` int(10) unsigned NULL COMMENT '등록한 사용자',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp(),
+  `completed_at` datetime NULL COMMENT '완료 일시 (NULL이면 예정)',
+  `completed_by` int(10) unsigned NULL COMMENT '완료 처리한 사용자',
+  `completed_caller` varchar(1) NULL COMMENT '완료 시 통화한 CALLER',
+  PRIMARY KEY (`seq`),
+  KEY `customer_callbacks_customer_idx` (`customer_seq`),
+  KEY `customer_callbacks_due_idx` (`completed_at`, `due_at`),
+  CONSTRAINT `customer_callbacks_customers_FK` FOREIGN KEY (`customer_seq`) REFERENCES `customers` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `customer_callbacks_owner_FK` FOREIGN KEY (`owner_user_seq`) REFERENCES `user_info` (`seq`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 콜백 예정';
@@ -0,0 +1,21 @@
+-- 고객 중복 등록 감지
+-- phone_normalized: 중복 비교용 정규화 전화번호 (숫자만, 국제번호 82 → 0)
+-- duplicate_of: 등록 시 같은 전화번호의 기존 고객 (CUSTOMER_DUPLICATE_POLICY=flag일 때 설정, 병합 대상 표시용)
+
+ALTER TABLE customers
+  ADD COLUMN `phone_normalized` varchar(20) DEFAULT NULL COMMENT '중복 비교용 정규화 전화번호' AFTER `phone_number`,
+  ADD COLUMN `duplicate_of` int(10) unsigned DEFAULT NULL COMMENT '중복 의심 기존 고객 (customers.seq)' AFTER `status`,
+  ADD KEY `customers_phone_normalized_IDX` (`phone_normalized`) USING BTREE,
+  ADD CONSTRAINT `customers_duplicate_of_FK` FOREIGN KEY (`duplicate_of`) REFERENCES `customers` (`seq`) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- 기존 고객 정규화 전화번호 채우기
+UPDATE customers
+SET phone_normalized = REGEXP_REPLACE(phone_number, '[^0-9]', '');
+
+UPDATE customers
+SET phone_normalized = CONCAT('0', SUBSTRING(phone_normalized, 3))
+WHERE phone_normalized LIKE '82%' AND SUBSTRING(phone_normalized, 3, 1) <> '0';
+
+UPDATE customers
+SET phone_normalized = SUBSTRING(phone_normalized, 3)
+WHERE phone_normalized LIKE '820%';
@@ -0,0 +1,6 @@
+-- 중복 고객 병합 표시
+-- 병합된 고객은 영구 삭제하지 않고 휴지통으로 이동 (deleted_at/deleted_by 설정, 보관 기간이 지나면 영구 삭제)
+-- merged_into: 병합되어 휴지통으로 이동한 경우 남긴 고객 (customers.seq, 복원하면 NULL)
+
+ALTER TABLE customers
+ADD COLUMN merged_into int(10) unsigned NULL COMMENT '병합되어 휴지통으로 이동한 경우 남긴 고객 (customers.seq)';
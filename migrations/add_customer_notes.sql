@@ -0,0 +1,38 @@
+-- 고객 메모 (여러 건, 작성자/작성 일시/고정/수정 이력)
+-- customer_notes: 고객별 메모. is_pinned=1인 메모 중 가장 최근 메모가 고객 목록의 코멘트(customers.comment)로 표시됨
+--   customers.comment는 목록/검색/내보내기용 표시 값으로 유지하며 메모 추가/수정/고정 시 함께 갱신 (최대 200자)
+-- customer_note_revisions: 메모 수정 시 수정 전 내용과 수정한 사용자 기록
+
+CREATE TABLE IF NOT EXISTS `customer_notes` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `customer_seq` int(10) unsigned NOT NULL COMMENT '고객 (customers.seq)',
+  `content` varchar(1000) NOT NULL COMMENT '메모 내용',
+  `is_pinned` tinyint(1) NOT NULL DEFAULT 0 COMMENT '고정 여부 (고정 메모 중 최근 메모가 목록 코멘트로 표시)',
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '작성자 (user_info.seq, 이관/외부 유입 메모는 NULL)',
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '작성자 아이디 (계정 삭제 후에도 조회 가능하도록 보관)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '작성 일시',
+  `updatedDate` datetime DEFAULT NULL COMMENT '마지막 수정 일시',
+  PRIMARY KEY (`seq`),
+  KEY `customer_notes_customer_IDX` (`customer_seq`, `is_pinned`, `createdDate`) USING BTREE,
+  CONSTRAINT `customer_notes_customers_FK` FOREIGN KEY (`customer_seq`) REFERENCES `customers` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `customer_notes_user_FK` FOREIGN KEY (`user_seq`) REFERENCES `user_info` (`seq`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 메모';
+
+CREATE TABLE IF NOT EXISTS `customer_note_revisions` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `note_seq` int(10) unsigned NOT NULL COMMENT '메모 (customer_notes.seq)',
+  `content` varchar(1000) NOT NULL COMMENT '수정 전 내용',
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '수정한 사용자 (user_info.seq)',
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '수정한 사용자 아이디',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '수정 일시',
+  PRIMARY KEY (`seq`),
+  KEY `customer_note_revisions_note_IDX` (`note_seq`, `seq`) USING BTREE,
+  CONSTRAINT `customer_note_revisions_notes_FK` FOREIGN KEY (`note_seq`) REFERENCES `customer_notes` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 메모 수정 이력';
+
+-- 기존 코멘트를 첫 메모로 이관 (고정 메모로 이관하므로 목록 표시는 그대로 유지)
+INSERT INTO `customer_notes` (`customer_seq`, `content`, `is_pinned`, `user_seq`, `user_id`, `createdDate`)
+SELECT c.`seq`, c.`comment`, 1, NULL, '', c.`createdDate`
+FROM `customers` c
+WHERE c.`comment` IS NOT NULL AND TRIM(c.`comment`) <> ''
+  AND NOT EXISTS (SELECT 1 FROM `customer_notes` n WHERE n.`customer_seq` = c.`seq`);
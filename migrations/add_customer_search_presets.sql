@@ -0,0 +1,13 @@
+-- 고객 검색 조건 저장 (사용자별 프리셋)
+-- query_string: 고객 목록 검색 파라미터 (URL 쿼리 문자열, 저장 시 허용된 파라미터만 남김)
+
+CREATE TABLE IF NOT EXISTS `customer_search_presets` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_seq` int(10) unsigned NOT NULL COMMENT '프리셋 소유자 (user_info.seq)',
+  `name` varchar(50) NOT NULL COMMENT '프리셋 이름',
+  `query_string` text NOT NULL COMMENT '검색 조건 (URL 쿼리 문자열)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '저장 일시',
+  PRIMARY KEY (`seq`),
+  UNIQUE KEY `customer_search_presets_user_name_unique` (`user_seq`, `name`),
+  CONSTRAINT `customer_search_presets_user_info_FK` FOREIGN KEY (`user_seq`) REFERENCES `user_info` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 검색 프리셋';
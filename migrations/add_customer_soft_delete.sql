@@ -0,0 +1,10 @@
+-- 고객 휴지통 (소프트 삭제)
+-- deleted_at: 삭제 일시 (NULL이면 정상 고객, 값이 있으면 휴지통)
+-- deleted_by: 삭제한 사용자 (user_info.seq, API 토큰 등 사용자가 없으면 NULL)
+-- 휴지통 고객은 CUSTOMER_TRASH_RETENTION_DAYS(기본 30일)가 지나면 영구 삭제
+
+ALTER TABLE customers
+ADD COLUMN deleted_at datetime NULL COMMENT '삭제 일시 (NULL: 정상, 값이 있으면 휴지통)',
+ADD COLUMN deleted_by int(10) unsigned NULL COMMENT '삭제한 사용자 (user_info.seq)';
+
+CREATE INDEX customers_deleted_at_IDX ON customers (deleted_at);
@@ -0,0 +1,18 @@
+-- 고객 상태 변경 이력 테이블 생성
+-- 상태 변경 규칙(database/customer_status.go)을 거친 모든 상태 변경을 작업자, 사유와 함께 기록
+-- reverted_seq: 되돌리기로 생긴 이력이면 되돌린 이력 seq (가장 최근 변경만 되돌릴 수 있음)
+
+CREATE TABLE IF NOT EXISTS `customer_status_history` (
+  `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `customer_seq` int(10) unsigned NOT NULL COMMENT '고객 (customers.seq)',
+  `from_status` varchar(20) NOT NULL COMMENT '변경 전 상태',
+  `to_status` varchar(20) NOT NULL COMMENT '변경 후 상태',
+  `reason` varchar(200) NOT NULL DEFAULT '' COMMENT '변경 사유',
+  `reverted_seq` bigint(20) unsigned DEFAULT NULL COMMENT '되돌린 이력 (customer_status_history.seq)',
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '작업자 (user_info.seq)',
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '작업자 아이디 (계정 삭제 후에도 조회 가능하도록 보관)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '변경 일시',
+  PRIMARY KEY (`seq`),
+  KEY `customer_status_history_customer_IDX` (`customer_seq`, `seq`) USING BTREE,
+  CONSTRAINT `customer_status_history_customers_FK` FOREIGN KEY (`customer_seq`) REFERENCES `customers` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 상태 변경 이력';
@@ -0,0 +1,31 @@
+-- 지점별 통화 횟수 제한 + 고객 상태 목록 관리
+-- branches.call_limit: 이 횟수 이상 통화하면 '콜수초과'로 변경 (0이면 제한 없음)
+-- customer_statuses: 고객 상태 목록 (is_active: '처리중' 목록에 표시, is_terminal: 처리가 끝난 상태, is_system: 기본 상태로 삭제/이름 변경 불가)
+-- customers.status는 ENUM 대신 customer_statuses.name 값을 저장
+
+ALTER TABLE branches
+ADD COLUMN call_limit INT(10) UNSIGNED NOT NULL DEFAULT 5 COMMENT '통화 횟수 제한 (0이면 제한 없음)'
+AFTER directions;
+
+CREATE TABLE IF NOT EXISTS `customer_statuses` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(20) NOT NULL COMMENT '상태 이름 (customers.status 값)',
+  `color` varchar(7) NOT NULL DEFAULT '#6b7280' COMMENT '표시 색상 (#RRGGBB)',
+  `is_active` tinyint(1) NOT NULL DEFAULT 0 COMMENT '처리중 목록에 표시',
+  `is_terminal` tinyint(1) NOT NULL DEFAULT 0 COMMENT '처리가 끝난 상태 (진행 중 상태로만 변경 가능)',
+  `is_system` tinyint(1) NOT NULL DEFAULT 0 COMMENT '기본 상태 (삭제/이름 변경 불가)',
+  `sort_order` int(10) NOT NULL DEFAULT 0 COMMENT '표시 순서',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`seq`),
+  UNIQUE KEY `customer_statuses_name_UN` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객 상태 목록';
+
+INSERT IGNORE INTO customer_statuses (name, color, is_active, is_terminal, is_system, sort_order) VALUES
+('신규', '#4a90e2', 1, 0, 1, 10),
+('진행중', '#10b981', 1, 0, 1, 20),
+('예약확정', '#8b5cf6', 0, 1, 1, 30),
+('전화상거절', '#e53e3e', 0, 1, 1, 40),
+('콜수초과', '#b45309', 0, 1, 1, 50);
+
+ALTER TABLE customers
+MODIFY COLUMN status varchar(20) NOT NULL DEFAULT '신규' COMMENT '고객 상태 (customer_statuses.name)';
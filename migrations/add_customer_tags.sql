@@ -0,0 +1,24 @@
+-- 고객 태그 (코멘트 대신 "VIP", "학생", "재방문" 같은 라벨을 고객에 붙여 분류)
+-- customer_tag_defs: 지점별 태그 목록 (같은 지점 안에서 이름 중복 불가, 지점 삭제 시 함께 삭제)
+-- customer_tags: 고객-태그 연결 (고객 또는 태그 삭제 시 함께 삭제)
+
+CREATE TABLE IF NOT EXISTS `customer_tag_defs` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `branch_seq` int(10) unsigned NOT NULL COMMENT '소속 지점',
+  `name` varchar(20) NOT NULL COMMENT '태그 이름',
+  `color` varchar(7) NOT NULL DEFAULT '#6b7280' COMMENT '표시 색상 (#RRGGBB)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`seq`),
+  UNIQUE KEY `customer_tag_defs_branch_name_uk` (`branch_seq`, `name`),
+  CONSTRAINT `customer_tag_defs_branches_FK` FOREIGN KEY (`branch_seq`) REFERENCES `branches` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='지점별 고객 태그 목록';
+
+CREATE TABLE IF NOT EXISTS `customer_tags` (
+  `customer_seq` int(10) unsigned NOT NULL,
+  `tag_seq` int(10) unsigned NOT NULL,
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`customer_seq`, `tag_seq`),
+  KEY `customer_tags_tag_idx` (`tag_seq`),
+  CONSTRAINT `customer_tags_customers_FK` FOREIGN KEY (`customer_seq`) REFERENCES `customers` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `customer_tags_tag_defs_FK` FOREIGN KEY (`tag_seq`) REFERENCES `customer_tag_defs` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='고객-태그 연결';
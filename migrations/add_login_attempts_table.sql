@@ -0,0 +1,17 @@
+-- 로그인 시도 이력 테이블 생성
+-- 계정별/IP별 연속 실패 횟수에 따라 점진적으로 로그인을 잠그는 데 사용 (handlers/login/lockout.go)
+-- result: success(성공), failure(아이디/비밀번호 불일치), disabled(비활성 계정), locked(잠금 중 시도), unlock(관리자 잠금 해제)
+
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '입력한 아이디 (존재하지 않는 아이디 포함)',
+  `ip_address` varchar(45) NOT NULL DEFAULT '' COMMENT '클라이언트 IP',
+  `user_agent` varchar(255) DEFAULT NULL COMMENT '브라우저 정보',
+  `result` varchar(20) NOT NULL COMMENT '시도 결과',
+  `created_by` varchar(100) DEFAULT NULL COMMENT '잠금 해제한 관리자 (unlock일 때)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '시도 일시',
+  PRIMARY KEY (`seq`),
+  KEY `login_attempts_user_id_IDX` (`user_id`, `createdDate`) USING BTREE,
+  KEY `login_attempts_ip_address_IDX` (`ip_address`, `createdDate`) USING BTREE,
+  KEY `login_attempts_createdDate_IDX` (`createdDate`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='로그인 시도 이력';
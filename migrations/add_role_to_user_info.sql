@@ -0,0 +1,12 @@
+-- user_info 테이블에 역할(role) 컬럼 추가
+-- 역할: super_admin(최고 관리자), branch_manager(지점 관리자), caller(상담원)
+-- 권한 매핑은 middleware/permission.go의 rolePermissions 참고
+
+ALTER TABLE user_info
+ADD COLUMN role ENUM('super_admin', 'branch_manager', 'caller') NOT NULL DEFAULT 'caller' COMMENT '사용자 역할'
+AFTER branch_seq;
+
+-- 기존 계정은 접근 범위를 유지하도록 역할 부여
+-- 전체 지점 계정(branch_seq NULL) → 최고 관리자, 지점 소속 계정 → 지점 관리자
+UPDATE user_info SET role = 'super_admin' WHERE branch_seq IS NULL;
+UPDATE user_info SET role = 'branch_manager' WHERE branch_seq IS NOT NULL;
@@ -0,0 +1,21 @@
+-- 지점별 문자 수신거부 번호 (정보통신망법 광고성 정보 수신거부 처리)
+-- sms.Send는 발송 전에 이 목록을 확인하고 등록된 번호로는 발송하지 않음 (일괄 발송 포함)
+-- phone_number는 숫자만 남긴 국내번호 형식으로 저장 (utils.NormalizePhoneNumber)
+-- source: manual (직원 등록), 080 (080 수신거부 목록 가져오기)
+
+CREATE TABLE IF NOT EXISTS `sms_opt_outs` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `branch_seq` int(10) unsigned NOT NULL COMMENT '지점 (branches.seq)',
+  `phone_number` varchar(20) NOT NULL COMMENT '수신거부 번호 (숫자만)',
+  `reason` varchar(200) NOT NULL DEFAULT '' COMMENT '수신거부 사유',
+  `source` varchar(20) NOT NULL DEFAULT 'manual' COMMENT '등록 경로 (manual, 080)',
+  `opted_out_date` date NOT NULL COMMENT '수신거부 일자',
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '등록한 사용자 (user_info.seq)',
+  `user_id` varchar(100) NOT NULL DEFAULT '' COMMENT '등록한 사용자 아이디',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '등록 일시',
+  PRIMARY KEY (`seq`),
+  UNIQUE KEY `sms_opt_outs_branch_phone_UN` (`branch_seq`, `phone_number`),
+  KEY `sms_opt_outs_phone_IDX` (`phone_number`) USING BTREE,
+  CONSTRAINT `sms_opt_outs_branches_FK` FOREIGN KEY (`branch_seq`) REFERENCES `branches` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `sms_opt_outs_user_FK` FOREIGN KEY (`user_seq`) REFERENCES `user_info` (`seq`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='문자 수신거부 번호';
@@ -0,0 +1,21 @@
+-- 직원 로그인 2단계 인증(TOTP, RFC 6238) 지원
+-- totp_secret: 등록 진행 중이거나 활성화된 Base32 비밀키 (totp_enabled=0이면 등록 미완료)
+-- totp_last_step: 마지막으로 사용된 코드의 step (같은 코드 재사용 방지)
+
+ALTER TABLE user_info
+ADD COLUMN totp_secret varchar(64) DEFAULT NULL COMMENT 'TOTP 비밀키 (Base32)' AFTER must_change_password,
+ADD COLUMN totp_enabled tinyint(1) NOT NULL DEFAULT 0 COMMENT '2단계 인증 사용 여부' AFTER totp_secret,
+ADD COLUMN totp_last_step bigint(20) NOT NULL DEFAULT 0 COMMENT '마지막 사용 TOTP step' AFTER totp_enabled;
+
+-- 2단계 인증 복구 코드 (인증 앱 분실 시 1회용 로그인 코드)
+-- 코드는 SHA-256 해시로만 저장하고 발급 시 한 번만 화면에 표시
+CREATE TABLE IF NOT EXISTS `user_recovery_codes` (
+  `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_seq` int(10) unsigned NOT NULL COMMENT '사용자',
+  `code_hash` char(64) NOT NULL COMMENT '복구 코드 SHA-256 해시',
+  `used_at` datetime DEFAULT NULL COMMENT '사용 일시 (NULL이면 미사용)',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '발급 일시',
+  PRIMARY KEY (`seq`),
+  KEY `user_recovery_codes_user_seq_IDX` (`user_seq`) USING BTREE,
+  CONSTRAINT `user_recovery_codes_user_info_FK` FOREIGN KEY (`user_seq`) REFERENCES `user_info` (`seq`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='2단계 인증 복구 코드';
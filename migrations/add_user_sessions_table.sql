@@ -0,0 +1,19 @@
+-- 서버 세션 테이블 생성
+-- 쿠키에는 서명된 세션 ID만 저장하고 세션 값은 DB에 보관 (database/session_store.go)
+-- 관리자 세션 강제 종료, 활성 세션 조회, 유휴 시간 초과 로그아웃에 사용
+-- user_seq: 로그인된 user-session일 때만 값이 있음 (그 외 세션은 NULL)
+
+CREATE TABLE IF NOT EXISTS `user_sessions` (
+  `session_id` varchar(64) NOT NULL COMMENT '세션 ID',
+  `session_name` varchar(50) NOT NULL COMMENT '세션 이름 (user-session, app-session, flash-session, board-session)',
+  `user_seq` int(10) unsigned DEFAULT NULL COMMENT '로그인 사용자 (user_info.seq)',
+  `data` mediumblob NOT NULL COMMENT '세션 값 (gob 인코딩)',
+  `ip_address` varchar(45) NOT NULL DEFAULT '' COMMENT '세션 생성 IP',
+  `user_agent` varchar(255) DEFAULT NULL COMMENT '브라우저 정보',
+  `createdDate` datetime NOT NULL DEFAULT current_timestamp() COMMENT '생성 일시',
+  `last_activity` datetime NOT NULL DEFAULT current_timestamp() COMMENT '마지막 활동 일시',
+  `expires_at` datetime NOT NULL COMMENT '만료 일시',
+  PRIMARY KEY (`session_id`),
+  KEY `user_sessions_user_seq_IDX` (`user_seq`) USING BTREE,
+  KEY `user_sessions_expires_at_IDX` (`expires_at`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='서버 세션';
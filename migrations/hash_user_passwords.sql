@@ -0,0 +1,9 @@
+-- user_info 비밀번호 bcrypt 해시 전환
+-- 기존 평문 비밀번호는 다음 로그인 성공 시 AuthenticateUser에서 자동으로 해시로 변환됨
+-- bcrypt 해시는 60자이므로 기존 varchar(200) 컬럼으로 충분함 (컬럼 변경 없음)
+
+ALTER TABLE user_info
+MODIFY COLUMN user_password varchar(200) NOT NULL COMMENT '비밀번호 (bcrypt 해시, 미전환 계정은 평문)';
+
+-- 전환 현황 확인용 (평문으로 남아있는 계정 수)
+-- SELECT COUNT(*) FROM user_info WHERE user_password NOT LIKE '$2_$%';
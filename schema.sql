@@ -76,8 +76,14 @@ CREATE TABLE `placeholders` (
 CREATE TABLE `user_info` (
   `seq` int(10) unsigned NOT NULL AUTO_INCREMENT,
   `branch_seq` int(10) unsigned DEFAULT NULL COMMENT '소속 지점 (NULL이면 전체)',
+  `role` ENUM('super_admin', 'branch_manager', 'caller') NOT NULL DEFAULT 'caller' COMMENT '사용자 역할',
   `user_id` varchar(100) NOT NULL,
   `user_password` varchar(200) NOT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 1 COMMENT '계정 활성 여부',
+  `must_change_password` tinyint(1) NOT NULL DEFAULT 0 COMMENT '다음 로그인 시 비밀번호 변경 필요',
+  `totp_secret` varchar(64) DEFAULT NULL COMMENT 'TOTP 비밀키 (Base32)',
+  `totp_enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '2단계 인증 사용 여부',
+  `totp_last_step` bigint(20) NOT NULL DEFAULT 0 COMMENT '마지막 사용 TOTP step',
   `createdDate` date NOT NULL DEFAULT curdate() COMMENT '계정 추가 일자',
   `lastUpdateDate` date NOT NULL DEFAULT curdate() COMMENT '계정 수정 일자',
   PRIMARY KEY (`seq`),
@@ -222,9 +228,9 @@ INSERT INTO `placeholders` (`name`, `comment`, `examples`, `value`) VALUES
 ('{{예약일자}}', '예약 확정 일시', '2026년 2월 15일 14:30', 'reservation.interview_date'),
 ('{{예약시간}}', '예약 확정 날짜와 시간', '2026년 2월 15일 오후 2:30', 'reservation.interview_datetime');
 
--- 관리자 계정 (root/root - 평문 저장, 테스트용)
-INSERT INTO `user_info` ('seq',`branch_seq`, `user_id`, `user_password`) VALUES
-(1, NULL, 'root', 'root');
+-- 관리자 계정 (root/root - bcrypt 해시 저장, 최초 로그인 시 비밀번호 변경 강제)
+INSERT INTO `user_info` ('seq',`branch_seq`, `role`, `user_id`, `user_password`, `must_change_password`) VALUES
+(1, NULL, 'super_admin', 'root', '$2a$10$nq7UWA38wvNEchebw5IpOO486Vz7kUOxRMbHlYvlRz7Uz/axjK3e2', 1);
 
 ALTER TABLE customers 
 MODIFY COLUMN createdDate datetime NOT NULL DEFAULT current_timestamp() COMMENT '고객 추가 일시',
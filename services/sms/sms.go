@@ -5,6 +5,7 @@ import (
 	"backoffice/database"
 	"backoffice/utils"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -23,8 +24,12 @@ type SendRequest struct {
 	ReceiverPhone string
 	Message       string
 	Subject       string // LMS 제목 (선택)
+	BranchSeq     int    // 수신거부 목록을 확인할 지점 (0이면 전체 지점 수신거부 목록 확인)
 }
 
+// ErrOptedOut 수신번호가 수신거부 목록에 있어 발송하지 않음 (정보통신망법상 수신거부 번호로 발송 불가)
+var ErrOptedOut = errors.New("수신거부 등록된 번호입니다")
+
 // SendResponse SMS 발송 응답 구조체
 type SendResponse struct {
 	Success bool
@@ -90,8 +95,27 @@ func getResponseMessage(code string) string {
 	return "알 수 없는 오류"
 }
 
+// MessageType 메시지 바이트 길이(UTF-8)에 따른 발송 타입 ("SMS" 또는 "LMS")
+// Send와 같은 기준이므로 발송 전 잔여건수 확인에 사용
+func MessageType(message string) string {
+	if len([]byte(message)) > config.GetConfig().SMS.MaxLength {
+		return "LMS"
+	}
+	return "SMS"
+}
+
 // Send SMS/LMS 발송 함수
+// 수신번호가 수신거부 목록에 있으면 발송하지 않고 ErrOptedOut 반환 (Mock 모드 포함)
 func Send(req SendRequest) (*SendResponse, error) {
+	optedOut, err := database.IsSMSOptedOut(req.BranchSeq, req.ReceiverPhone)
+	if err != nil {
+		return nil, fmt.Errorf("수신거부 목록을 확인할 수 없습니다: %w", err)
+	}
+	if optedOut {
+		log.Printf("수신거부 번호 발송 차단 - BranchSeq: %d, 수신번호: %s", req.BranchSeq, utils.MaskPhoneNumber(req.ReceiverPhone))
+		return nil, ErrOptedOut
+	}
+
 	// Mock 모드 체크 (local 또는 test 환경)
 	if config.IsMockMode() {
 		log.Println("[Mock Mode] 실제 SMS 발송 없이 성공 응답 반환")
@@ -118,7 +142,7 @@ func Send(req SendRequest) (*SendResponse, error) {
 	// 메시지 길이에 따라 SMS/LMS 엔드포인트 결정
 	// SMS: 90바이트 이내, LMS: 2000바이트 이내
 	endpoint := cfg.SMS.APIBaseURL + cfg.SMS.SMSEndpoint
-	isLMS := messageByteLength > cfg.SMS.MaxLength
+	isLMS := MessageType(req.Message) == "LMS"
 
 	if isLMS {
 		endpoint = cfg.SMS.APIBaseURL + cfg.SMS.LMSEndpoint
@@ -157,7 +181,7 @@ func Send(req SendRequest) (*SendResponse, error) {
 	}
 
 	log.Printf("SMS API 요청 - 수신번호: %s, 발신번호: %s, 메시지 길이: %d바이트",
-		req.ReceiverPhone, req.SenderPhone, messageByteLength)
+		utils.MaskPhoneNumber(req.ReceiverPhone), utils.MaskPhoneNumber(req.SenderPhone), messageByteLength)
 	// HTTPS 클라이언트 생성
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -183,13 +207,13 @@ func Send(req SendRequest) (*SendResponse, error) {
 		return nil, fmt.Errorf("SMS 응답 처리 중 오류가 발생했습니다: %w", err)
 	}
 
-	log.Printf("SMS API 응답 (Status: %d): %s", resp.StatusCode, string(body))
+	log.Printf("SMS API 응답 (Status: %d): %s", resp.StatusCode, utils.RedactPII(string(body)))
 
 	// 응답 상태 코드 확인
 	if resp.StatusCode != http.StatusOK {
 		return &SendResponse{
 			Success: false,
-			Message: fmt.Sprintf("SMS 발송 실패 (HTTP %d): %s", resp.StatusCode, string(body)),
+			Message: fmt.Sprintf("SMS 발송 실패 (HTTP %d): %s", resp.StatusCode, utils.RedactPII(string(body))),
 		}, nil
 	}
 
@@ -200,7 +224,7 @@ func Send(req SendRequest) (*SendResponse, error) {
 
 	if len(parts) < 4 {
 		log.Printf("SMS API 응답 형식 오류: 예상된 4개 필드, 실제 %d개 필드", len(parts))
-		return nil, fmt.Errorf("SMS 응답 형식 오류: %s", responseText)
+		return nil, fmt.Errorf("SMS 응답 형식 오류: %s", utils.RedactPII(responseText))
 	}
 
 	apiResp := APIResponse{
@@ -335,7 +359,7 @@ func checkRemainingCountByType(endpoint, accountID, password, msgType string) (i
 	}
 
 	responseText := strings.TrimSpace(string(body))
-	log.Printf("마이문자 %s 잔여건수 조회 API 응답 (Status: %d): %s", strings.ToUpper(msgType), resp.StatusCode, responseText)
+	log.Printf("마이문자 %s 잔여건수 조회 API 응답 (Status: %d): %s", strings.ToUpper(msgType), resp.StatusCode, utils.RedactPII(responseText))
 
 	// 응답 상태 코드 확인
 	if resp.StatusCode != http.StatusOK {
@@ -361,6 +385,6 @@ func checkRemainingCountByType(endpoint, accountID, password, msgType string) (i
 	}
 
 	// 응답 형식이 올바르지 않은 경우
-	log.Printf("%s 잔여건수 조회 응답 형식 오류 (예상: 결과코드|결과메시지|잔여건수): %s", strings.ToUpper(msgType), responseText)
+	log.Printf("%s 잔여건수 조회 응답 형식 오류 (예상: 결과코드|결과메시지|잔여건수): %s", strings.ToUpper(msgType), utils.RedactPII(responseText))
 	return 0, fmt.Errorf("%s 잔여건수 조회 응답 형식 오류: %s", strings.ToUpper(msgType), responseText)
 }
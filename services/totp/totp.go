@@ -0,0 +1,137 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// RFC 6238 기본값 (Google Authenticator 등 일반 인증 앱 호환)
+const (
+	Period     = 30 // 코드 변경 주기 (초)
+	Digits     = 6  // 코드 자릿수
+	SkewSteps  = 1  // 시계 오차 허용 범위 (앞뒤 step 수)
+	secretSize = 20 // 비밀키 길이 (바이트, SHA-1 블록 기준 160비트)
+)
+
+// b32 - 패딩 없는 Base32 (인증 앱 입력용)
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret 새 TOTP 비밀키 생성 (Base32 문자열)
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return b32.EncodeToString(buf), nil
+}
+
+// generateCode 특정 step의 TOTP 코드 계산 (RFC 4226 HOTP 동적 절단)
+func generateCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
+
+// Validate 입력 코드 검증
+// 반환: 일치한 step (재사용 방지용으로 저장), 검증 성공 여부
+// lastStep 이하의 step은 이미 사용된 코드로 보고 거부
+func Validate(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := b32.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / Period
+	for i := -SkewSteps; i <= SkewSteps; i++ {
+		step := current + int64(i)
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// ProvisioningURI 인증 앱 등록용 otpauth:// URI 생성
+func ProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", Digits))
+	params.Set("period", fmt.Sprintf("%d", Period))
+	// 일부 인증 앱은 '+'를 공백으로 해석하지 않으므로 %20 사용
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+}
+
+// QRCodeDataURI 프로비저닝 URI를 QR 코드 PNG data URI로 변환 (img src에 바로 사용)
+func QRCodeDataURI(uri string) (string, error) {
+	png, err := qrcode.Encode(uri, qrcode.Medium, 240)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
+
+// recoveryCodeChars - 복구 코드 문자 집합 (혼동되는 0/o, 1/l/i 제외)
+const recoveryCodeChars = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCodes 1회용 복구 코드 생성 (xxxxx-xxxxx 형식)
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		buf := make([]byte, 10)
+		for j := range buf {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeChars))))
+			if err != nil {
+				return nil, err
+			}
+			buf[j] = recoveryCodeChars[n.Int64()]
+		}
+		codes = append(codes, string(buf[:5])+"-"+string(buf[5:]))
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode 복구 코드 해시 (대소문자/하이픈/공백 무시 후 SHA-256)
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(code)
+	normalized = strings.ReplaceAll(normalized, "-", "")
+	normalized = strings.ReplaceAll(normalized, " ", "")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,42 @@
+package totp
+
+import (
+	"backoffice/database"
+	"log"
+	"strings"
+	"time"
+)
+
+// VerifyUserCode 사용자의 2단계 인증 코드 확인 (로그인, 2단계 인증 해제/복구 코드 재발급 시 사용)
+// 숫자 6자리면 인증 앱 코드(TOTP), 그 외에는 1회용 복구 코드로 처리
+func VerifyUserCode(userSeq int, code string) bool {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false
+	}
+
+	if len(strings.ReplaceAll(code, " ", "")) == Digits {
+		setting, err := database.GetUserTOTP(userSeq)
+		if err != nil || !setting.Enabled || setting.Secret == "" {
+			return false
+		}
+
+		step, ok := Validate(setting.Secret, code, time.Now(), setting.LastStep)
+		if !ok {
+			return false
+		}
+
+		// 같은 코드가 동시에 두 번 쓰이지 않도록 step 선점
+		used, err := database.MarkTOTPStepUsed(userSeq, step)
+		return err == nil && used
+	}
+
+	used, err := database.UseRecoveryCode(userSeq, HashRecoveryCode(code))
+	if err != nil || !used {
+		return false
+	}
+
+	remaining, _ := database.CountUnusedRecoveryCodes(userSeq)
+	log.Printf("복구 코드 사용 - 사용자 seq: %d, 남은 복구 코드: %d", userSeq, remaining)
+	return true
+}
@@ -0,0 +1,69 @@
+/**
+ * CSRF 토큰 자동 첨부
+ * header.html의 <meta name="csrf-token">에 있는 토큰을 상태 변경 요청에 붙입니다
+ * - fetch: 같은 출처로 보내는 GET/HEAD/OPTIONS 이외 요청에 X-CSRF-Token 헤더 추가
+ * - form: POST 폼 제출 시 csrf_token hidden 필드가 없으면 추가 (동적으로 만든 폼의 form.submit() 포함)
+ */
+(function () {
+    const CSRF_HEADER = 'X-CSRF-Token';
+    const CSRF_FIELD = 'csrf_token';
+    const SAFE_METHODS = ['GET', 'HEAD', 'OPTIONS'];
+
+    /**
+     * 현재 페이지의 CSRF 토큰을 가져옵니다
+     * @returns {string} CSRF 토큰 (없으면 빈 문자열)
+     */
+    function getCSRFToken() {
+        const meta = document.querySelector('meta[name="csrf-token"]');
+        return meta ? meta.getAttribute('content') : '';
+    }
+
+    /**
+     * POST 폼에 CSRF hidden 필드를 추가합니다
+     * @param {HTMLFormElement} form - 대상 폼
+     */
+    function appendCSRFField(form) {
+        if (!(form instanceof HTMLFormElement) || form.method.toLowerCase() !== 'post') {
+            return;
+        }
+        if (form.querySelector(`input[name="${CSRF_FIELD}"]`)) {
+            return;
+        }
+        const input = document.createElement('input');
+        input.type = 'hidden';
+        input.name = CSRF_FIELD;
+        input.value = getCSRFToken();
+        form.appendChild(input);
+    }
+
+    // fetch 요청에 CSRF 헤더 추가
+    const originalFetch = window.fetch;
+    window.fetch = function (input, init) {
+        init = init || {};
+        const isRequest = input instanceof Request;
+        const method = (init.method || (isRequest ? input.method : 'GET')).toUpperCase();
+        const url = new URL(isRequest ? input.url : input, window.location.href);
+
+        if (!SAFE_METHODS.includes(method) && url.origin === window.location.origin) {
+            const headers = new Headers(init.headers || (isRequest ? input.headers : undefined));
+            headers.set(CSRF_HEADER, getCSRFToken());
+            init = Object.assign({}, init, { headers: headers });
+        }
+
+        return originalFetch.call(this, input, init);
+    };
+
+    // form.submit()은 submit 이벤트가 발생하지 않으므로 직접 필드 추가
+    const originalSubmit = HTMLFormElement.prototype.submit;
+    HTMLFormElement.prototype.submit = function () {
+        appendCSRFField(this);
+        return originalSubmit.call(this);
+    };
+
+    // 일반 폼 제출
+    document.addEventListener('submit', function (event) {
+        appendCSRFField(event.target);
+    }, true);
+
+    window.getCSRFToken = getCSRFToken;
+})();
@@ -29,7 +29,7 @@ function goToPage(page, options = {}) {
         // 모든 파라미터 유지 (removeParams 제외)
         for (const [key, value] of urlParams.entries()) {
             if (key !== 'page' && !removeParams.includes(key)) {
-                newParams.set(key, value);
+                newParams.append(key, value);
             }
         }
     } else {
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"time"
 )
 
@@ -18,6 +20,18 @@ func NormalizeKoreanPhoneNumber(phone string) string {
 	return phone
 }
 
+// NormalizePhoneNumber 중복 비교용 전화번호 정규화 (숫자만 남기고 국제번호 82를 국내번호로 변환)
+// 예: "010-1234-5678", "+82 10 1234 5678" → "01012345678"
+func NormalizePhoneNumber(phone string) string {
+	digits := make([]byte, 0, len(phone))
+	for i := 0; i < len(phone); i++ {
+		if phone[i] >= '0' && phone[i] <= '9' {
+			digits = append(digits, phone[i])
+		}
+	}
+	return NormalizeKoreanPhoneNumber(string(digits))
+}
+
 // MaskPassword 비밀번호 마스킹 헬퍼 함수 (로깅용)
 func MaskPassword(password string) string {
 	if len(password) <= 2 {
@@ -58,3 +72,29 @@ func FormatDateTime(dateStr string) string {
 	// 파싱 실패 시 원본 반환
 	return dateStr
 }
+
+// tempPasswordChars - 임시 비밀번호 문자 집합 (혼동되는 0/O, 1/l/I 제외)
+const tempPasswordChars = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateTempPassword 임시 비밀번호 생성 (영문+숫자, 비밀번호 정책 충족)
+func GenerateTempPassword(length int) (string, error) {
+	if length < MinPasswordLength {
+		length = MinPasswordLength
+	}
+
+	for {
+		buf := make([]byte, length)
+		for i := range buf {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(tempPasswordChars))))
+			if err != nil {
+				return "", err
+			}
+			buf[i] = tempPasswordChars[n.Int64()]
+		}
+
+		// 영문/숫자가 모두 포함될 때까지 재생성
+		if ValidatePassword(string(buf)) == nil {
+			return string(buf), nil
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 개인정보(고객 이름, 전화번호) 마스킹
+// 로그에는 고객 이름/전화번호를 그대로 남기지 않고 MaskName, MaskPhoneNumber, RedactPII를 거쳐 기록
+
+// piiPhonePattern - 문자열 안의 국내 전화번호 (구분자 -, ., 공백 허용, +82/82 국제번호 포함)
+var piiPhonePattern = regexp.MustCompile(`(?:\+?82[-. ]?0?|\b0)\d{1,2}[-. ]?\d{3,4}[-. ]?\d{4}\b`)
+
+// MaskPhoneNumber 전화번호 마스킹 (앞 3자리와 뒤 4자리만 표시)
+// 예: "010-1234-5678", "01012345678" → "010-****-5678"
+func MaskPhoneNumber(phone string) string {
+	digits := NormalizePhoneNumber(phone)
+	if len(digits) <= 7 {
+		return strings.Repeat("*", len(digits))
+	}
+	return digits[:3] + "-" + strings.Repeat("*", len(digits)-7) + "-" + digits[len(digits)-4:]
+}
+
+// MaskName 이름 마스킹 (첫 글자와 마지막 글자만 표시, 두 글자면 첫 글자만)
+// 예: "홍길동" → "홍*동", "홍길" → "홍*", "남궁민수" → "남**수"
+func MaskName(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1:
+		return "*"
+	case 2:
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-1])
+	}
+}
+
+// RedactPII 로그용 문자열에서 전화번호를 찾아 마스킹 (요청/응답 본문, 메시지 내용 등 형식을 알 수 없는 값에 사용)
+// 예: "홍길동 010-1234-5678 예약" → "홍길동 010-****-5678 예약"
+func RedactPII(text string) string {
+	if !utf8.ValidString(text) {
+		return "[binary]"
+	}
+	return piiPhonePattern.ReplaceAllStringFunc(text, MaskPhoneNumber)
+}
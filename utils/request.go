@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"backoffice/config"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// GetClientIP - 요청한 클라이언트 IP 반환
+// TRUST_PROXY가 켜진 경우에만 X-Forwarded-For(첫 번째 값) / X-Real-IP 헤더를 사용 (직접 노출 시 위조 가능)
+func GetClientIP(r *http.Request) string {
+	if config.GetConfig().Server.TrustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+				return ip
+			}
+		}
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -1,5 +1,10 @@
 package utils
 
+import "unicode"
+
+// MinPasswordLength - 계정 비밀번호 최소 길이
+const MinPasswordLength = 8
+
 // ValidationError 검증 오류 타입
 type ValidationError struct {
 	Message string
@@ -29,3 +34,25 @@ func IsValidPhoneNumber(phone string) bool {
 	}
 	return true
 }
+
+// ValidatePassword - 계정 비밀번호 정책 검증 (8자 이상, 영문과 숫자 포함)
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return NewValidationError("비밀번호는 8자 이상이어야 합니다")
+	}
+
+	hasLetter, hasDigit := false, false
+	for _, c := range password {
+		switch {
+		case unicode.IsLetter(c):
+			hasLetter = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return NewValidationError("비밀번호는 영문과 숫자를 모두 포함해야 합니다")
+	}
+
+	return nil
+}